@@ -153,8 +153,8 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForInterface(t *testing.T) {
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -290,8 +290,8 @@ func TestAppendTypeUsedToAddRuntimeCustomScalarTypeForInterface(t *testing.T) {
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -390,8 +390,8 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForUnion(t *testing.T) {
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -527,7 +527,7 @@ func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 		RequestString: query,
 	})
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -651,6 +651,6 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		RequestString: query,
 	})
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
@@ -0,0 +1,387 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/parser"
+)
+
+// BuildClientSchema builds a Schema from the result of an introspection query, such as
+// testutil.IntrospectionQuery or IntrospectionFromSchema. Either the "data" of the
+// response or its "__schema" entry may be given.
+//
+// The schema can validate documents, but it has no resolvers: abstract types cannot be
+// resolved and custom scalars pass values through unchanged.
+func BuildClientSchema(introspection map[string]interface{}) (Schema, error) {
+	schemaIntrospection, ok := introspection["__schema"].(map[string]interface{})
+	if !ok {
+		if _, ok := introspection["types"]; !ok {
+			return Schema{}, fmt.Errorf(`Invalid or incomplete introspection result. Ensure that you are passing the "data" ` +
+				`property of an introspection response and no "errors" were returned alongside.`)
+		}
+		schemaIntrospection = introspection
+	}
+	typeIntrospections, ok := schemaIntrospection["types"].([]interface{})
+	if !ok {
+		return Schema{}, fmt.Errorf(`Invalid or incomplete schema, missing "types": %v.`, schemaIntrospection)
+	}
+
+	b := &clientSchemaBuilder{
+		typeIntrospections: map[string]map[string]interface{}{},
+		types:              map[string]Type{},
+	}
+	var typeNames []string
+	for _, typeIntrospection := range typeIntrospections {
+		typeIntrospection, ok := typeIntrospection.(map[string]interface{})
+		if !ok {
+			return Schema{}, fmt.Errorf("Invalid or incomplete schema, unexpected type: %v.", typeIntrospection)
+		}
+		name, _ := typeIntrospection["name"].(string)
+		b.typeIntrospections[name] = typeIntrospection
+		typeNames = append(typeNames, name)
+	}
+
+	config := SchemaConfig{}
+	for _, name := range typeNames {
+		if ttype := b.namedType(name); ttype != nil && !isSpecifiedType(ttype) {
+			config.Types = append(config.Types, ttype)
+		}
+	}
+	config.Query = b.rootType(schemaIntrospection["queryType"])
+	config.Mutation = b.rootType(schemaIntrospection["mutationType"])
+	config.Subscription = b.rootType(schemaIntrospection["subscriptionType"])
+	if directives, ok := schemaIntrospection["directives"].([]interface{}); ok {
+		for _, directive := range directives {
+			if directive, ok := directive.(map[string]interface{}); ok {
+				config.Directives = append(config.Directives, b.directive(directive))
+			}
+		}
+	}
+	if b.err != nil {
+		return Schema{}, b.err
+	}
+
+	schema, err := NewSchema(config)
+	// Types are resolved lazily, so references to unknown types are only found here.
+	if b.err != nil {
+		return Schema{}, b.err
+	}
+	return schema, err
+}
+
+// clientSchemaBuilder builds the types of a client schema on demand. The first problem
+// found is kept in err, since type thunks cannot return errors.
+type clientSchemaBuilder struct {
+	typeIntrospections map[string]map[string]interface{}
+	types              map[string]Type
+	err                error
+}
+
+func (b *clientSchemaBuilder) fail(format string, a ...interface{}) {
+	if b.err == nil {
+		b.err = fmt.Errorf(format, a...)
+	}
+}
+
+func isSpecifiedType(ttype Type) bool {
+	switch ttype {
+	case String, Int, Float, Boolean, ID:
+		return true
+	}
+	return isIntrospectionType(ttype)
+}
+
+var specifiedTypes = map[string]Type{
+	String.Name():  String,
+	Int.Name():     Int,
+	Float.Name():   Float,
+	Boolean.Name(): Boolean,
+	ID.Name():      ID,
+}
+
+func (b *clientSchemaBuilder) rootType(ref interface{}) *Object {
+	refMap, ok := ref.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	name, _ := refMap["name"].(string)
+	object, ok := b.namedType(name).(*Object)
+	if !ok {
+		b.fail("Root type %v must be an Object type.", name)
+		return nil
+	}
+	return object
+}
+
+func (b *clientSchemaBuilder) namedType(name string) Type {
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	if ttype, ok := specifiedTypes[name]; ok {
+		return ttype
+	}
+	if SchemaType != nil {
+		for _, ttype := range []Type{SchemaType, DirectiveType, TypeType, FieldType, InputValueType, EnumValueType,
+			TypeKindEnumType, DirectiveLocationEnumType} {
+			if ttype.Name() == name {
+				return ttype
+			}
+		}
+	}
+	typeIntrospection, ok := b.typeIntrospections[name]
+	if !ok {
+		b.fail("Invalid or incomplete schema, unknown type: %v. Ensure that a full introspection query "+
+			"is used in order to build a client schema.", name)
+		return nil
+	}
+
+	description, _ := typeIntrospection["description"].(string)
+	kind, _ := typeIntrospection["kind"].(string)
+	var ttype Type
+	switch kind {
+	case TypeKindScalar:
+		specifiedByURL, _ := typeIntrospection["specifiedByURL"].(string)
+		ttype = NewScalar(ScalarConfig{
+			Name:           name,
+			Description:    description,
+			SpecifiedByURL: specifiedByURL,
+			Serialize:      func(value interface{}) interface{} { return value },
+			ParseValue:     func(value interface{}) interface{} { return value },
+			ParseLiteral:   func(valueAST ast.Value) interface{} { return valueAST.GetValue() },
+		})
+	case TypeKindObject:
+		ttype = NewObject(ObjectConfig{
+			Name:        name,
+			Description: description,
+			Interfaces:  b.interfacesThunk(typeIntrospection),
+			Fields:      b.fieldsThunk(typeIntrospection),
+		})
+	case TypeKindInterface:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: description,
+			Interfaces:  b.interfacesThunk(typeIntrospection),
+			Fields:      b.fieldsThunk(typeIntrospection),
+			ResolveType: unresolvableType,
+		})
+	case TypeKindUnion:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: description,
+			Types: UnionTypesThunk(func() []*Object {
+				var types []*Object
+				for _, ref := range introspectionList(typeIntrospection["possibleTypes"]) {
+					if object, ok := b.typeRef(ref).(*Object); ok {
+						types = append(types, object)
+					} else {
+						b.fail("Union %v can only include Object types.", name)
+					}
+				}
+				return types
+			}),
+			ResolveType: unresolvableType,
+		})
+	case TypeKindEnum:
+		values := EnumValueConfigMap{}
+		for _, value := range introspectionList(typeIntrospection["enumValues"]) {
+			valueName, _ := value["name"].(string)
+			valueDescription, _ := value["description"].(string)
+			values[valueName] = &EnumValueConfig{
+				Value:             valueName,
+				Description:       valueDescription,
+				DeprecationReason: deprecationReason(value),
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: description,
+			Values:      values,
+		})
+	case TypeKindInputObject:
+		isOneOf, _ := typeIntrospection["isOneOf"].(bool)
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: description,
+			IsOneOf:     isOneOf,
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for _, field := range introspectionList(typeIntrospection["inputFields"]) {
+					fieldName, _ := field["name"].(string)
+					fieldType := b.inputTypeRef(field["type"])
+					fieldDescription, _ := field["description"].(string)
+					fields[fieldName] = &InputObjectFieldConfig{
+						Type:              fieldType,
+						Description:       fieldDescription,
+						DefaultValue:      b.defaultValue(field, fieldType),
+						DeprecationReason: deprecationReason(field),
+					}
+				}
+				return fields
+			}),
+		})
+	default:
+		b.fail("Invalid or incomplete introspection result, unknown kind %q of type %v.", kind, name)
+		return nil
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+func unresolvableType(p ResolveTypeParams) *Object {
+	return nil
+}
+
+func (b *clientSchemaBuilder) interfacesThunk(typeIntrospection map[string]interface{}) InterfacesThunk {
+	return func() []*Interface {
+		interfaces := []*Interface{}
+		for _, ref := range introspectionList(typeIntrospection["interfaces"]) {
+			if iface, ok := b.typeRef(ref).(*Interface); ok {
+				interfaces = append(interfaces, iface)
+			} else {
+				b.fail("Type %v can only implement Interface types.", typeIntrospection["name"])
+			}
+		}
+		return interfaces
+	}
+}
+
+func (b *clientSchemaBuilder) fieldsThunk(typeIntrospection map[string]interface{}) FieldsThunk {
+	return func() Fields {
+		fields := Fields{}
+		for _, field := range introspectionList(typeIntrospection["fields"]) {
+			fieldName, _ := field["name"].(string)
+			fieldType, ok := b.typeRef(field["type"]).(Output)
+			if !ok {
+				b.fail("Field %v.%v must be an output type.", typeIntrospection["name"], fieldName)
+			}
+			fieldDescription, _ := field["description"].(string)
+			fields[fieldName] = &Field{
+				Name:              fieldName,
+				Type:              fieldType,
+				Description:       fieldDescription,
+				DeprecationReason: deprecationReason(field),
+				Args:              b.arguments(field["args"]),
+			}
+		}
+		return fields
+	}
+}
+
+func (b *clientSchemaBuilder) arguments(argIntrospections interface{}) FieldConfigArgument {
+	args := FieldConfigArgument{}
+	for _, arg := range introspectionList(argIntrospections) {
+		argName, _ := arg["name"].(string)
+		argType := b.inputTypeRef(arg["type"])
+		argDescription, _ := arg["description"].(string)
+		args[argName] = &ArgumentConfig{
+			Type:              argType,
+			Description:       argDescription,
+			DefaultValue:      b.defaultValue(arg, argType),
+			DeprecationReason: deprecationReason(arg),
+		}
+	}
+	return args
+}
+
+func (b *clientSchemaBuilder) directive(directiveIntrospection map[string]interface{}) *Directive {
+	name, _ := directiveIntrospection["name"].(string)
+	description, _ := directiveIntrospection["description"].(string)
+	isRepeatable, _ := directiveIntrospection["isRepeatable"].(bool)
+	var locations []string
+	if locationIntrospections, ok := directiveIntrospection["locations"].([]interface{}); ok {
+		for _, location := range locationIntrospections {
+			if location, ok := location.(string); ok {
+				locations = append(locations, location)
+			}
+		}
+	} else {
+		// Introspection results of older servers only have these flags.
+		if onOperation, _ := directiveIntrospection["onOperation"].(bool); onOperation {
+			locations = append(locations, DirectiveLocationQuery, DirectiveLocationMutation, DirectiveLocationSubscription)
+		}
+		if onFragment, _ := directiveIntrospection["onFragment"].(bool); onFragment {
+			locations = append(locations, DirectiveLocationFragmentSpread, DirectiveLocationInlineFragment, DirectiveLocationFragmentDefinition)
+		}
+		if onField, _ := directiveIntrospection["onField"].(bool); onField {
+			locations = append(locations, DirectiveLocationField)
+		}
+	}
+	return NewDirective(DirectiveConfig{
+		Name:         name,
+		Description:  description,
+		Locations:    locations,
+		Args:         b.arguments(directiveIntrospection["args"]),
+		IsRepeatable: isRepeatable,
+	})
+}
+
+func (b *clientSchemaBuilder) typeRef(ref interface{}) Type {
+	refMap, ok := ref.(map[string]interface{})
+	if !ok {
+		b.fail("Invalid or incomplete schema, missing type reference: %v.", ref)
+		return nil
+	}
+	switch refMap["kind"] {
+	case TypeKindList:
+		ofType := b.typeRef(refMap["ofType"])
+		if ofType == nil {
+			return nil
+		}
+		return NewList(ofType)
+	case TypeKindNonNull:
+		ofType := b.typeRef(refMap["ofType"])
+		if ofType == nil {
+			return nil
+		}
+		return NewNonNull(ofType)
+	}
+	name, _ := refMap["name"].(string)
+	return b.namedType(name)
+}
+
+func (b *clientSchemaBuilder) inputTypeRef(ref interface{}) Input {
+	ttype := b.typeRef(ref)
+	input, ok := ttype.(Input)
+	if !ok || !IsInputType(ttype) {
+		b.fail("Invalid or incomplete schema, %v must be an input type.", ttype)
+		return nil
+	}
+	return input
+}
+
+// defaultValue parses the default value printed by introspection back into a value.
+func (b *clientSchemaBuilder) defaultValue(inputValue map[string]interface{}, ttype Input) interface{} {
+	printed, ok := inputValue["defaultValue"].(string)
+	if !ok || ttype == nil {
+		return nil
+	}
+	valueAST, err := parser.ParseValue(parser.ParseParams{Source: printed})
+	if err != nil {
+		b.fail("Invalid default value %v of %v: %v", printed, inputValue["name"], err)
+		return nil
+	}
+	return valueFromAST(valueAST, ttype, nil)
+}
+
+func deprecationReason(introspection map[string]interface{}) string {
+	if reason, ok := introspection["deprecationReason"].(string); ok && reason != "" {
+		return reason
+	}
+	if isDeprecated, _ := introspection["isDeprecated"].(bool); isDeprecated {
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// introspectionList returns the objects of an introspected list, skipping anything else.
+func introspectionList(value interface{}) []map[string]interface{} {
+	values, _ := value.([]interface{})
+	result := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if value, ok := value.(map[string]interface{}); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
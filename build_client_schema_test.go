@@ -0,0 +1,253 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func clientSchemaTestSchema(t *testing.T) graphql.Schema {
+	dateType := graphql.NewScalar(graphql.ScalarConfig{
+		Name:           "Date",
+		Description:    "A calendar date.",
+		SpecifiedByURL: "https://tools.ietf.org/html/rfc3339",
+		Serialize:      func(value interface{}) interface{} { return value },
+	})
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0},
+			"GREEN": &graphql.EnumValueConfig{Value: 1, Description: "Not red."},
+			"BLUE":  &graphql.EnumValueConfig{Value: 2, DeprecationReason: "Use GREEN."},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"color":  &graphql.InputObjectFieldConfig{Type: colorType, DefaultValue: 2},
+			"colors": &graphql.InputObjectFieldConfig{Type: graphql.NewList(colorType), DefaultValue: []interface{}{0, 1}},
+			"limit":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int), DefaultValue: 10},
+		},
+	})
+	keyType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "Key",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"name": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	nodeType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	namedType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Named",
+		Interfaces: []*graphql.Interface{nodeType},
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{nodeType, namedType},
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":    &graphql.Field{Type: graphql.String},
+			"born":    &graphql.Field{Type: dateType},
+			"barks":   &graphql.Field{Type: graphql.Boolean, DeprecationReason: "Dogs always bark."},
+			"friends": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(namedType))},
+		},
+	})
+	catType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Cat",
+		Interfaces: []*graphql.Interface{nodeType},
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"lives": &graphql.Field{Type: graphql.Int},
+		},
+	})
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType, catType},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{
+							Type:         filterType,
+							DefaultValue: map[string]interface{}{"color": 0, "colors": []interface{}{0, 1}, "limit": 5},
+						},
+						"color": &graphql.ArgumentConfig{Type: colorType, DefaultValue: 1},
+					},
+				},
+				"node": &graphql.Field{
+					Type: nodeType,
+					Args: graphql.FieldConfigArgument{
+						"key": &graphql.ArgumentConfig{Type: graphql.NewNonNull(keyType)},
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"adopt": &graphql.Field{
+					Type: dogType,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "Rex"},
+					},
+				},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, graphql.NewDirective(graphql.DirectiveConfig{
+			Name:         "tag",
+			Description:  "Tags a field.",
+			Locations:    []string{graphql.DirectiveLocationField, graphql.DirectiveLocationFragmentSpread},
+			IsRepeatable: true,
+			Args: graphql.FieldConfigArgument{
+				"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			},
+		})),
+		Types: []graphql.Type{dogType, catType},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err)
+	}
+	return schema
+}
+
+func TestBuildClientSchema_RoundTripsIntrospectionFromSchema(t *testing.T) {
+	schema := clientSchemaTestSchema(t)
+	introspection := graphql.IntrospectionFromSchema(schema)
+
+	clientSchema, err := graphql.BuildClientSchema(introspection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result := graphql.IntrospectionFromSchema(clientSchema); !reflect.DeepEqual(result, introspection) {
+		t.Fatalf("Unexpected introspection of the client schema, Diff: %v", testutil.Diff(introspection, result))
+	}
+}
+
+func TestBuildClientSchema_BuildsFromAnExecutedIntrospectionQuery(t *testing.T) {
+	schema := clientSchemaTestSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: testutil.IntrospectionQuery,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+
+	clientSchema, err := graphql.BuildClientSchema(result.DataMap())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if clientSchema.MutationType() == nil || clientSchema.MutationType().Name() != "Mutation" {
+		t.Fatalf("Expected the Mutation root type, got %v", clientSchema.MutationType())
+	}
+	dog, ok := clientSchema.Type("Dog").(*graphql.Object)
+	if !ok {
+		t.Fatalf("Expected Dog to be an Object, got %v", clientSchema.Type("Dog"))
+	}
+	if reason := dog.Fields()["barks"].DeprecationReason; reason != "Dogs always bark." {
+		t.Fatalf("Unexpected deprecation reason: %v", reason)
+	}
+	if !clientSchema.IsSubType(clientSchema.Type("Node").(graphql.Abstract), dog) {
+		t.Fatalf("Expected Dog to implement Node")
+	}
+	pets := clientSchema.QueryType().Fields()["pets"]
+	for _, arg := range pets.Args {
+		switch arg.Name() {
+		case "color":
+			if arg.DefaultValue != "GREEN" {
+				t.Fatalf("Unexpected default value of color: %v", arg.DefaultValue)
+			}
+		case "filter":
+			expected := map[string]interface{}{"color": "RED", "colors": []interface{}{"RED", "GREEN"}, "limit": 5}
+			if !reflect.DeepEqual(arg.DefaultValue, expected) {
+				t.Fatalf("Unexpected default value of filter, Diff: %v", testutil.Diff(expected, arg.DefaultValue))
+			}
+		}
+	}
+	if directive := clientSchema.Directive("tag"); directive == nil || len(directive.Args) != 1 {
+		t.Fatalf("Expected the tag directive with one argument, got %v", directive)
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: `
+		query ($key: Key!) {
+			node(key: $key) { id ... on Named { name } }
+			pets(color: BLUE) { ... on Dog { born friends @tag(name: "a") { name } } }
+		}
+	`})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if validation := graphql.ValidateDocument(&clientSchema, document, nil); !validation.IsValid {
+		t.Fatalf("Unexpected validation errors: %v", validation.Errors)
+	}
+
+	document, err = parser.Parse(parser.ParseParams{Source: `{ pets(color: PURPLE) { ... on Cat { name } } }`})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if validation := graphql.ValidateDocument(&clientSchema, document, nil); len(validation.Errors) != 2 {
+		t.Fatalf("Expected two validation errors, got %v", validation.Errors)
+	}
+}
+
+func TestBuildClientSchema_RejectsIncompleteIntrospection(t *testing.T) {
+	tests := []struct {
+		introspection map[string]interface{}
+		expected      string
+	}{
+		{
+			introspection: map[string]interface{}{},
+			expected:      `Invalid or incomplete introspection result.`,
+		},
+		{
+			introspection: map[string]interface{}{
+				"__schema": map[string]interface{}{
+					"queryType": map[string]interface{}{"name": "Query"},
+					"types": []interface{}{
+						map[string]interface{}{
+							"kind": "OBJECT",
+							"name": "Query",
+							"fields": []interface{}{
+								map[string]interface{}{
+									"name": "missing",
+									"args": []interface{}{},
+									"type": map[string]interface{}{"kind": "OBJECT", "name": "Missing"},
+								},
+							},
+							"interfaces": []interface{}{},
+						},
+					},
+				},
+			},
+			expected: `Invalid or incomplete schema, unknown type: Missing.`,
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.BuildClientSchema(test.introspection)
+		if err == nil || !strings.HasPrefix(err.Error(), test.expected) {
+			t.Fatalf("Expected error %q, got %v", test.expected, err)
+		}
+	}
+}
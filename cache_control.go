@@ -0,0 +1,351 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/tailor-inc/graphql/gqlerrors"
+)
+
+// CacheScope is the scope of a cached response: PUBLIC responses may be shared by every
+// client, PRIVATE responses only belong to a single user.
+type CacheScope string
+
+const (
+	CacheScopePublic  CacheScope = "PUBLIC"
+	CacheScopePrivate CacheScope = "PRIVATE"
+)
+
+// CacheControlScopeEnum is the type of the scope argument of @cacheControl.
+var CacheControlScopeEnum = NewEnum(EnumConfig{
+	Name: "CacheControlScope",
+	Values: EnumValueConfigMap{
+		string(CacheScopePublic):  &EnumValueConfig{Value: string(CacheScopePublic)},
+		string(CacheScopePrivate): &EnumValueConfig{Value: string(CacheScopePrivate)},
+	},
+})
+
+// CacheControlDirective is used to give fields and types a cache hint. Add it to the
+// directives of the schema, and CacheControlScopeEnum to its types, to use it.
+var CacheControlDirective = NewDirective(DirectiveConfig{
+	Name:        "cacheControl",
+	Description: "Sets the maximum age and the scope of cached responses including the field or type.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+		DirectiveLocationObject,
+		DirectiveLocationInterface,
+		DirectiveLocationUnion,
+	},
+	Args: FieldConfigArgument{
+		"maxAge": &ArgumentConfig{
+			Type:        Int,
+			Description: "The maximum age of the response in seconds.",
+		},
+		"scope": &ArgumentConfig{
+			Type:        CacheControlScopeEnum,
+			Description: "Whether the response may be shared by every client.",
+		},
+		"inheritMaxAge": &ArgumentConfig{
+			Type:        Boolean,
+			Description: "Uses the maximum age of the parent field instead of the default one.",
+		},
+	},
+})
+
+// CacheHint is the cache hint of a field or a type. A nil MaxAge and an empty Scope are
+// unset.
+type CacheHint struct {
+	MaxAge        *int
+	Scope         CacheScope
+	InheritMaxAge bool
+}
+
+// replace overrides the settings of h with the ones set in hint.
+func (h *CacheHint) replace(hint CacheHint) {
+	if hint.MaxAge != nil {
+		maxAge := *hint.MaxAge
+		h.MaxAge = &maxAge
+	}
+	if hint.Scope != "" {
+		h.Scope = hint.Scope
+	}
+}
+
+// restrict lowers the max age of h and makes it private as required by hint.
+func (h *CacheHint) restrict(hint CacheHint) {
+	if hint.MaxAge != nil && (h.MaxAge == nil || *hint.MaxAge < *h.MaxAge) {
+		maxAge := *hint.MaxAge
+		h.MaxAge = &maxAge
+	}
+	if hint.Scope != "" && h.Scope != CacheScopePrivate {
+		h.Scope = hint.Scope
+	}
+}
+
+// CacheHintFromType returns the hint given by @cacheControl to an object, interface or
+// union type, or to the named type wrapped by ttype.
+func CacheHintFromType(ttype Type) CacheHint {
+	switch ttype := GetNamed(ttype).(type) {
+	case *Object:
+		return cacheHintFromDirectives(ttype.Directives())
+	case *Interface:
+		return cacheHintFromDirectives(ttype.Directives())
+	case *Union:
+		return cacheHintFromDirectives(ttype.Directives())
+	}
+	return CacheHint{}
+}
+
+func cacheHintFromDirectives(directives []*ObjectDirective) CacheHint {
+	hint := CacheHint{}
+	for _, directive := range directives {
+		if directive.Directive == nil || directive.Directive.Name != CacheControlDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Args {
+			switch arg.Name {
+			case "maxAge":
+				if maxAge, ok := arg.Value.(int); ok {
+					hint.MaxAge = &maxAge
+				}
+			case "scope":
+				if arg.Value != nil {
+					hint.Scope = CacheScope(fmt.Sprint(arg.Value))
+				}
+			case "inheritMaxAge":
+				hint.InheritMaxAge, _ = arg.Value.(bool)
+			}
+		}
+	}
+	return hint
+}
+
+// CacheControl holds the cache hint of a field while it is resolved. It is nil unless
+// the schema has a CacheControlExtension, its methods do nothing then.
+type CacheControl struct {
+	mu   sync.Mutex
+	hint CacheHint
+}
+
+// SetCacheHint overrides the hint of the field with the settings of hint.
+func (c *CacheControl) SetCacheHint(hint CacheHint) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hint.replace(hint)
+}
+
+// CacheHint returns the current hint of the field.
+func (c *CacheControl) CacheHint() CacheHint {
+	if c == nil {
+		return CacheHint{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hint
+}
+
+// CachePolicy is the policy of a cacheable response.
+type CachePolicy struct {
+	MaxAge int
+	Scope  CacheScope
+}
+
+// HTTPHeader returns the value of the Cache-Control header of a response with policy p.
+func (p CachePolicy) HTTPHeader() string {
+	return fmt.Sprintf("max-age=%d, %s", p.MaxAge, strings.ToLower(string(p.Scope)))
+}
+
+// ResponseCachePolicy is the overall cache policy of a response, computed by the
+// CacheControlExtension from the hints of every resolved field.
+type ResponseCachePolicy struct {
+	mu      sync.Mutex
+	hint    CacheHint
+	enabled bool
+	// pending restricts the policy with the hints of fields resolved by thunks, which
+	// are only final once the execution completes.
+	pending []func()
+}
+
+type responseCachePolicyKey struct{}
+
+// WithResponseCachePolicy returns a context which collects the policy of the response
+// of the request executed with it.
+func WithResponseCachePolicy(ctx context.Context) (context.Context, *ResponseCachePolicy) {
+	policy := &ResponseCachePolicy{}
+	return context.WithValue(ctx, responseCachePolicyKey{}, policy), policy
+}
+
+// ResponseCachePolicyFromContext returns the policy collected by ctx, if any.
+func ResponseCachePolicyFromContext(ctx context.Context) (*ResponseCachePolicy, bool) {
+	policy, ok := ctx.Value(responseCachePolicyKey{}).(*ResponseCachePolicy)
+	return policy, ok
+}
+
+// Enabled reports whether the policy was computed, which requires a
+// CacheControlExtension in the schema.
+func (p *ResponseCachePolicy) Enabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enabled
+}
+
+// Restrict lowers the max age of the response and makes it private as required by hint.
+func (p *ResponseCachePolicy) Restrict(hint CacheHint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hint.restrict(hint)
+}
+
+// restrictLater calls restrict once the execution of the response completes.
+func (p *ResponseCachePolicy) restrictLater(restrict func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, restrict)
+}
+
+// restrictPending applies the hints waiting for the execution to complete.
+func (p *ResponseCachePolicy) restrictPending() {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+	for _, restrict := range pending {
+		restrict()
+	}
+}
+
+// PolicyIfCacheable returns the policy of the response, unless its max age is unset or
+// zero. The scope defaults to PUBLIC.
+func (p *ResponseCachePolicy) PolicyIfCacheable() (CachePolicy, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.hint.MaxAge == nil || *p.hint.MaxAge <= 0 {
+		return CachePolicy{}, false
+	}
+	policy := CachePolicy{MaxAge: *p.hint.MaxAge, Scope: p.hint.Scope}
+	if policy.Scope == "" {
+		policy.Scope = CacheScopePublic
+	}
+	return policy, true
+}
+
+// CacheControlExtension computes the cache policy of responses from the @cacheControl
+// hints of the resolved fields and the hints set by resolvers with
+// ResolveInfo.CacheControl, following the rules of Apollo Server:
+//
+//   - A field returning an object, interface or union type, and a root field, without a
+//     max age use the DefaultMaxAge, unless its type or itself inherit the max age.
+//   - Other fields without a max age inherit the one of their parent.
+//   - The hint of a field overrides the one of its type.
+//   - The response uses the lowest max age of its fields, and is private when any of
+//     them is.
+//
+// Hints set by thunks, the functions returned by resolvers to be called later, count
+// as well. The policy is available with ResponseCachePolicyFromContext on the context
+// of the request, or WithResponseCachePolicy before executing it, once the execution
+// completes.
+type CacheControlExtension struct {
+	// DefaultMaxAge is the max age in seconds of fields without one, 0 by default,
+	// which makes their responses uncacheable.
+	DefaultMaxAge int
+}
+
+// Init makes sure the context of the request collects its cache policy.
+func (e *CacheControlExtension) Init(ctx context.Context, p *Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	policy, ok := ResponseCachePolicyFromContext(ctx)
+	if !ok {
+		ctx, policy = WithResponseCachePolicy(ctx)
+	}
+	policy.mu.Lock()
+	policy.enabled = true
+	policy.mu.Unlock()
+	return ctx
+}
+
+func (e *CacheControlExtension) Name() string {
+	return "CacheControl"
+}
+
+func (e *CacheControlExtension) ParseDidStart(ctx context.Context) (context.Context, ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (e *CacheControlExtension) ValidationDidStart(ctx context.Context) (context.Context, ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart restricts the policy of the response with the hints of the fields
+// resolved by thunks once the execution completes.
+func (e *CacheControlExtension) ExecutionDidStart(ctx context.Context) (context.Context, ExecutionFinishFunc) {
+	return ctx, func(*Result) {
+		if policy, ok := ResponseCachePolicyFromContext(ctx); ok {
+			policy.restrictPending()
+		}
+	}
+}
+
+// ResolveFieldDidStart computes the hint of the field, which its resolver may change
+// with info.CacheControl, and restricts the policy of the response with it once the
+// field is resolved, or once the execution completes for a field resolved by a thunk.
+func (e *CacheControlExtension) ResolveFieldDidStart(ctx context.Context, info *ResolveInfo) (context.Context, ResolveFieldFinishFunc) {
+	policy, ok := ResponseCachePolicyFromContext(ctx)
+	if !ok {
+		return ctx, func(interface{}, error) {}
+	}
+
+	cacheControl := &CacheControl{}
+	inheritMaxAge := false
+	targetType := GetNamed(info.ReturnType)
+	composite := IsCompositeType(targetType)
+	if composite {
+		typeHint := CacheHintFromType(info.ReturnType)
+		cacheControl.hint.replace(typeHint)
+		inheritMaxAge = typeHint.InheritMaxAge
+	}
+	if parentType, ok := info.ParentType.(*Object); ok {
+		if fieldDef := getFieldDef(info.Schema, parentType, info.FieldName); fieldDef != nil {
+			fieldHint := cacheHintFromDirectives(fieldDef.Directives)
+			if fieldHint.InheritMaxAge && cacheControl.hint.MaxAge == nil {
+				inheritMaxAge = true
+				cacheControl.hint.replace(CacheHint{Scope: fieldHint.Scope})
+			} else {
+				cacheControl.hint.replace(fieldHint)
+			}
+		}
+	}
+	info.CacheControl = cacheControl
+
+	restrict := func() {
+		hint := cacheControl.CacheHint()
+		isRootField := info.Path == nil || info.Path.Prev == nil
+		if hint.MaxAge == nil && ((composite && !inheritMaxAge) || isRootField) {
+			defaultMaxAge := e.DefaultMaxAge
+			hint.restrict(CacheHint{MaxAge: &defaultMaxAge})
+		}
+		policy.Restrict(hint)
+	}
+	return ctx, func(result interface{}, err error) {
+		if result := reflect.ValueOf(result); result.IsValid() && result.Kind() == reflect.Func {
+			policy.restrictLater(restrict)
+			return
+		}
+		restrict()
+	}
+}
+
+func (e *CacheControlExtension) HasResult() bool {
+	return false
+}
+
+func (e *CacheControlExtension) GetResult(context.Context) interface{} {
+	return nil
+}
@@ -0,0 +1,198 @@
+package graphql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+)
+
+func cacheControl(args ...graphql.ObjectDirectiveArg) []*graphql.ObjectDirective {
+	return []*graphql.ObjectDirective{{Directive: graphql.CacheControlDirective, Args: args}}
+}
+
+func cacheControlTestSchema(t *testing.T, defaultMaxAge int) graphql.Schema {
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Book",
+		Directives: cacheControl(graphql.ObjectDirectiveArg{Name: "maxAge", Value: 60}),
+		Fields: graphql.Fields{
+			"title":  &graphql.Field{Type: graphql.String},
+			"author": &graphql.Field{Type: authorType},
+			"inheritedAuthor": &graphql.Field{
+				Type:       authorType,
+				Directives: cacheControl(graphql.ObjectDirectiveArg{Name: "inheritMaxAge", Value: true}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(map[string]interface{})["author"], nil
+				},
+			},
+			"price": &graphql.Field{
+				Type:       graphql.Int,
+				Directives: cacheControl(graphql.ObjectDirectiveArg{Name: "scope", Value: "PRIVATE"}),
+			},
+			"stock": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					maxAge := 5
+					p.Info.CacheControl.SetCacheHint(graphql.CacheHint{MaxAge: &maxAge})
+					return 3, nil
+				},
+			},
+			"reviews": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return func() (interface{}, error) {
+						maxAge := 7
+						p.Info.CacheControl.SetCacheHint(graphql.CacheHint{MaxAge: &maxAge, Scope: graphql.CacheScopePrivate})
+						return 12, nil
+					}, nil
+				},
+			},
+		},
+	})
+	book := map[string]interface{}{
+		"title":  "Dune",
+		"author": map[string]interface{}{"name": "Frank Herbert"},
+		"price":  10,
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"books": &graphql.Field{
+					Type: graphql.NewList(bookType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{book}, nil
+					},
+				},
+				"version": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "1.0", nil
+					},
+				},
+			},
+		}),
+		Types:      []graphql.Type{graphql.CacheControlScopeEnum},
+		Directives: append([]*graphql.Directive{graphql.CacheControlDirective}, graphql.SpecifiedDirectives...),
+		Extensions: []graphql.Extension{&graphql.CacheControlExtension{DefaultMaxAge: defaultMaxAge}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating schema: %v", err)
+	}
+	return schema
+}
+
+func TestCacheControlExtension_ComputesThePolicyOfResponses(t *testing.T) {
+	schema := cacheControlTestSchema(t, 0)
+	tests := []struct {
+		query     string
+		cacheable bool
+		expected  graphql.CachePolicy
+	}{
+		{
+			query:     `{ books { title } }`,
+			cacheable: true,
+			expected:  graphql.CachePolicy{MaxAge: 60, Scope: graphql.CacheScopePublic},
+		},
+		{
+			query:     `{ books { title author { name } } }`,
+			cacheable: false,
+		},
+		{
+			query:     `{ books { inheritedAuthor { name } } }`,
+			cacheable: true,
+			expected:  graphql.CachePolicy{MaxAge: 60, Scope: graphql.CacheScopePublic},
+		},
+		{
+			query:     `{ books { title price } }`,
+			cacheable: true,
+			expected:  graphql.CachePolicy{MaxAge: 60, Scope: graphql.CacheScopePrivate},
+		},
+		{
+			query:     `{ books { title stock } }`,
+			cacheable: true,
+			expected:  graphql.CachePolicy{MaxAge: 5, Scope: graphql.CacheScopePublic},
+		},
+		{
+			query:     `{ books { title reviews } }`,
+			cacheable: true,
+			expected:  graphql.CachePolicy{MaxAge: 7, Scope: graphql.CacheScopePrivate},
+		},
+		{
+			query:     `{ books { title } version }`,
+			cacheable: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			ctx, policy := graphql.WithResponseCachePolicy(context.Background())
+			result := graphql.Do(graphql.Params{Schema: schema, RequestString: test.query, Context: ctx})
+			if len(result.Errors) > 0 {
+				t.Fatalf("Unexpected errors: %v", result.Errors)
+			}
+			if !policy.Enabled() {
+				t.Fatalf("Expected the policy to be computed")
+			}
+			got, cacheable := policy.PolicyIfCacheable()
+			if cacheable != test.cacheable || got != test.expected {
+				t.Fatalf("Expected policy %v (cacheable %v), got %v (cacheable %v)", test.expected, test.cacheable, got, cacheable)
+			}
+		})
+	}
+}
+
+func TestCacheControlExtension_UsesTheDefaultMaxAge(t *testing.T) {
+	schema := cacheControlTestSchema(t, 30)
+	ctx, policy := graphql.WithResponseCachePolicy(context.Background())
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ version books { author { name } } }`, Context: ctx})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	if got, _ := policy.PolicyIfCacheable(); got != (graphql.CachePolicy{MaxAge: 30, Scope: graphql.CacheScopePublic}) {
+		t.Fatalf("Unexpected policy %v", got)
+	}
+}
+
+func TestCacheControlExtension_IsDisabledWithoutTheExtension(t *testing.T) {
+	ctx, policy := graphql.WithResponseCachePolicy(context.Background())
+	graphql.Do(graphql.Params{Schema: tinit(t), RequestString: `{ a }`, Context: ctx})
+	if policy.Enabled() {
+		t.Fatalf("Expected the policy not to be computed")
+	}
+}
+
+func TestCacheControl_ReadsHintsFromSDL(t *testing.T) {
+	schema, err := graphql.ParseSDL(`
+		enum CacheControlScope { PUBLIC PRIVATE }
+		directive @cacheControl(maxAge: Int, scope: CacheControlScope, inheritMaxAge: Boolean) on FIELD_DEFINITION | OBJECT | INTERFACE | UNION
+
+		interface Node @cacheControl(maxAge: 30) { id: ID }
+		union Result @cacheControl(maxAge: 20, scope: PRIVATE) = Page
+		type Page implements Node { id: ID }
+		type Query { node: Node result: Result }
+	`, func(typeName, fieldName string) graphql.FieldResolveFn { return nil })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	node := graphql.CacheHintFromType(schema.Type("Node"))
+	if node.MaxAge == nil || *node.MaxAge != 30 || node.Scope != "" {
+		t.Fatalf("Unexpected hint of Node %v", node)
+	}
+	result := graphql.CacheHintFromType(graphql.NewNonNull(schema.Type("Result")))
+	if result.MaxAge == nil || *result.MaxAge != 20 || result.Scope != graphql.CacheScopePrivate {
+		t.Fatalf("Unexpected hint of Result %v", result)
+	}
+}
+
+func TestCachePolicy_HTTPHeader(t *testing.T) {
+	policy := graphql.CachePolicy{MaxAge: 60, Scope: graphql.CacheScopePrivate}
+	if header := policy.HTTPHeader(); header != "max-age=60, private" {
+		t.Fatalf("Unexpected header %v", header)
+	}
+}
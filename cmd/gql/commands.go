@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// runValidate validates the documents against the schema of -schema. The documents are
+// validated together, so that fragments may be defined in other files.
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlagSet("validate", stderr)
+	schemaPath := flags.String("schema", "", "the schema to validate against")
+	if !parseArgs(flags, args, 1, -1) {
+		return exitError
+	}
+	if *schemaPath == "" {
+		fmt.Fprintf(stderr, "gql validate: -schema is required\n")
+		return exitError
+	}
+	schema, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gql validate: %v\n", err)
+		return exitError
+	}
+
+	status := exitOK
+	doc := &ast.Document{}
+	for _, path := range flags.Args() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "gql validate: %v\n", err)
+			return exitError
+		}
+		fileDoc, errs := parseFile(path, b)
+		if len(errs) > 0 {
+			printErrors(stderr, path, errs)
+			status = exitFailed
+			continue
+		}
+		doc.Definitions = append(doc.Definitions, fileDoc.Definitions...)
+	}
+	if status != exitOK {
+		return status
+	}
+	result := graphql.ValidateDocument(&schema, ast.NewDocument(doc), nil)
+	if !result.IsValid {
+		printErrors(stderr, "", result.Errors)
+		return exitFailed
+	}
+	return exitOK
+}
+
+// runPrint prints the canonical SDL of a schema.
+func runPrint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlagSet("print", stderr)
+	if !parseArgs(flags, args, 1, 1) {
+		return exitError
+	}
+	schema, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "gql print: %v\n", err)
+		return exitError
+	}
+	fmt.Fprint(stdout, graphql.PrintSchema(schema))
+	return exitOK
+}
+
+// runDiff prints the breaking changes from a schema to another, failing when there are
+// any.
+func runDiff(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlagSet("diff", stderr)
+	if !parseArgs(flags, args, 2, 2) {
+		return exitError
+	}
+	var schemas [2]graphql.Schema
+	for i := range schemas {
+		schema, err := loadSchema(flags.Arg(i))
+		if err != nil {
+			fmt.Fprintf(stderr, "gql diff: %v\n", err)
+			return exitError
+		}
+		schemas[i] = schema
+	}
+	changes := graphql.FindBreakingChanges(schemas[0], schemas[1])
+	for _, change := range changes {
+		fmt.Fprintf(stdout, "%s: %s\n", change.Type, change.Description)
+	}
+	if len(changes) > 0 {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// runFmt formats documents in place, or with -l lists those which aren't formatted
+// and fails when there are any. Without files, stdin is formatted to stdout.
+func runFmt(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlagSet("fmt", stderr)
+	list := flags.Bool("l", false, "list the files which aren't formatted instead of rewriting them")
+	if !parseArgs(flags, args, 0, -1) {
+		return exitError
+	}
+	if flags.NArg() == 0 {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gql fmt: %v\n", err)
+			return exitError
+		}
+		formatted, errs := formatDocument("<stdin>", b)
+		if len(errs) > 0 {
+			printErrors(stderr, "<stdin>", errs)
+			return exitFailed
+		}
+		stdout.Write(formatted)
+		return exitOK
+	}
+
+	status := exitOK
+	for _, path := range flags.Args() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "gql fmt: %v\n", err)
+			return exitError
+		}
+		formatted, errs := formatDocument(path, b)
+		switch {
+		case len(errs) > 0:
+			printErrors(stderr, path, errs)
+			status = exitFailed
+		case bytes.Equal(formatted, b):
+		case *list:
+			fmt.Fprintln(stdout, path)
+			status = exitFailed
+		default:
+			if err := os.WriteFile(path, formatted, 0644); err != nil {
+				fmt.Fprintf(stderr, "gql fmt: %v\n", err)
+				return exitError
+			}
+		}
+	}
+	return status
+}
+
+// formatDocument returns the document body of the file name printed with its comments.
+func formatDocument(name string, body []byte) ([]byte, []gqlerrors.FormattedError) {
+	doc, errs := parseFile(name, body)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, doc, printer.PrintOptions{}); err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// runIntrospect prints the introspection of a schema.
+func runIntrospect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlagSet("introspect", stderr)
+	if !parseArgs(flags, args, 1, 1) {
+		return exitError
+	}
+	schema, err := loadSchema(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "gql introspect: %v\n", err)
+		return exitError
+	}
+	// The introspection is printed as the result of an introspection query would be.
+	result := map[string]interface{}{"data": graphql.IntrospectionFromSchema(schema)}
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "gql introspect: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(stdout, "%s\n", b)
+	return exitOK
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/language/source"
+)
+
+// loadSchema builds the schema of the file at path, which holds SDL, or the JSON result
+// of an introspection query when its name ends in ".json".
+func loadSchema(path string) (graphql.Schema, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	if strings.HasSuffix(path, ".json") {
+		var introspection map[string]interface{}
+		if err := json.Unmarshal(b, &introspection); err != nil {
+			return graphql.Schema{}, fmt.Errorf("%s: %v", path, err)
+		}
+		if data, ok := introspection["data"].(map[string]interface{}); ok {
+			introspection = data
+		}
+		schema, err := graphql.BuildClientSchema(introspection)
+		if err != nil {
+			return graphql.Schema{}, fmt.Errorf("%s: %v", path, err)
+		}
+		return schema, nil
+	}
+	schema, err := graphql.ParseSDL(string(b), func(string, string) graphql.FieldResolveFn { return nil })
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return *schema, nil
+}
+
+// parseFile parses the document body read from the file name, keeping its comments.
+// Every syntax error is returned.
+func parseFile(name string, body []byte) (*ast.Document, []gqlerrors.FormattedError) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.NewSource(&source.Source{Body: body, Name: name}),
+		Options: parser.ParseOptions{Tolerant: true, KeepComments: true},
+	})
+	if err == nil {
+		return doc, nil
+	}
+	if errs, ok := err.(parser.SyntaxErrors); ok {
+		formatted := make([]gqlerrors.FormattedError, len(errs))
+		for i, err := range errs {
+			formatted[i] = gqlerrors.FormatError(err)
+		}
+		return doc, formatted
+	}
+	return doc, gqlerrors.FormatErrors(err)
+}
+
+// printErrors prints errs as "file:line:column: message", the file being that of the
+// source of each error, or name when unknown.
+func printErrors(w io.Writer, name string, errs []gqlerrors.FormattedError) {
+	for _, err := range errs {
+		file := name
+		if original, ok := err.OriginalError().(*gqlerrors.Error); ok {
+			switch {
+			case original.Source != nil:
+				file = original.Source.Name
+			case len(original.Nodes) > 0 && original.Nodes[0].GetLoc() != nil && original.Nodes[0].GetLoc().Source != nil:
+				file = original.Nodes[0].GetLoc().Source.Name
+			}
+		}
+		// Syntax errors repeat their location, and highlight it on the next lines.
+		message := strings.SplitN(err.Message, "\n", 2)[0]
+		if len(err.Locations) == 0 {
+			fmt.Fprintf(w, "%s: %s\n", file, message)
+			continue
+		}
+		l := err.Locations[0]
+		message = strings.Replace(message, fmt.Sprintf("Syntax Error %s (%d:%d) ", file, l.Line, l.Column), "Syntax Error: ", 1)
+		fmt.Fprintf(w, "%s:%d:%d: %s\n", file, l.Line, l.Column, message)
+	}
+}
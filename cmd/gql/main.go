@@ -0,0 +1,92 @@
+// Command gql checks and rewrites GraphQL schemas and documents.
+//
+// Usage:
+//
+//	gql validate -schema schema.graphql query.graphql...
+//	gql print schema.graphql
+//	gql diff old.graphql new.graphql
+//	gql fmt [-l] [file.graphql...]
+//	gql introspect schema.graphql
+//
+// Schemas are read from SDL, or from the JSON result of an introspection query when
+// the file name ends in ".json". Errors are printed as "file:line:column: message".
+//
+// gql exits with status 1 when documents are invalid, when a schema has breaking
+// changes, or when files aren't formatted, and with status 2 when it can't run, as for
+// unreadable files or invalid schemas.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK = iota
+	exitFailed
+	exitError
+)
+
+const usage = `usage: gql <command> [arguments]
+
+commands:
+  validate -schema <schema> <document>...  validate documents against a schema
+  print <schema>                          print the canonical SDL of a schema
+  diff <old schema> <new schema>          list the breaking changes of a schema
+  fmt [-l] [<document>...]                format documents in place, or stdin
+  introspect <schema>                     print the introspection of a schema as JSON
+`
+
+// command runs a subcommand with its arguments, returning the exit status.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"validate":   runValidate,
+	"print":      runPrint,
+	"diff":       runDiff,
+	"fmt":        runFmt,
+	"introspect": runIntrospect,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "gql: unknown command %q\n\n%s", args[0], usage)
+		return exitError
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
+
+// newFlagSet returns the flags of the command name, which print their errors to
+// stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet("gql "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+	}
+	return flags
+}
+
+// parseArgs parses the flags of args, and checks that the count of the other arguments
+// is between min and max, max being unbounded when negative.
+func parseArgs(flags *flag.FlagSet, args []string, min, max int) bool {
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if n := flags.NArg(); n < min || max >= 0 && n > max {
+		fmt.Fprintf(flags.Output(), "%s: wrong number of arguments\n\n%s", flags.Name(), usage)
+		return false
+	}
+	return true
+}
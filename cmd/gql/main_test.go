@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+const testSchema = `type Query {
+  user(id: ID!): User
+}
+
+type User {
+  id: ID!
+  name: String
+}
+`
+
+// writeFiles writes files, mapping names to contents, into a temporary directory and
+// returns its path.
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// runIn runs gql in dir with args, returning its exit status, stdout and stderr.
+func runIn(t *testing.T, dir string, stdin string, args ...string) (int, string, string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestValidate_ReportsLocatedErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"schema.graphql":   testSchema,
+		"valid.graphql":    "query User($id: ID!) {\n  user(id: $id) {\n    ...UserFields\n  }\n}\n",
+		"fragment.graphql": "fragment UserFields on User {\n  id\n  name\n}\n",
+		"invalid.graphql":  "{\n  user(id: 1) {\n    email\n  }\n}\n",
+		"syntax.graphql":   "{\n  user(id: ) {\n}\n",
+	})
+
+	status, _, stderr := runIn(t, dir, "", "validate", "-schema", "schema.graphql", "valid.graphql", "fragment.graphql")
+	if status != exitOK || stderr != "" {
+		t.Fatalf("expected valid documents, got %d: %s", status, stderr)
+	}
+
+	status, _, stderr = runIn(t, dir, "", "validate", "-schema", "schema.graphql", "invalid.graphql")
+	expected := "invalid.graphql:3:5: Cannot query field \"email\" on type \"User\".\n"
+	if status != exitFailed || stderr != expected {
+		t.Fatalf("Unexpected result %d, Diff: %v", status, testutil.Diff(expected, stderr))
+	}
+
+	status, _, stderr = runIn(t, dir, "", "validate", "-schema", "schema.graphql", "syntax.graphql")
+	expected = "syntax.graphql:2:12: Syntax Error: Unexpected )\n" +
+		"syntax.graphql:4:1: Syntax Error: Expected Name, found EOF\n"
+	if status != exitFailed || stderr != expected {
+		t.Fatalf("Unexpected result %d, Diff: %v", status, testutil.Diff(expected, stderr))
+	}
+
+	if status, _, _ := runIn(t, dir, "", "validate", "valid.graphql"); status != exitError {
+		t.Fatalf("expected a usage error without a schema, got %d", status)
+	}
+}
+
+func TestPrintAndIntrospect_ReadSDLAndIntrospection(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"schema.graphql": testSchema})
+
+	status, introspection, stderr := runIn(t, dir, "", "introspect", "schema.graphql")
+	if status != exitOK || !strings.Contains(introspection, `"__schema"`) {
+		t.Fatalf("expected the introspection, got %d: %s", status, stderr)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "schema.json"), []byte(introspection), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{"schema.graphql", "schema.json"} {
+		status, stdout, stderr := runIn(t, dir, "", "print", file)
+		if status != exitOK || stdout != testSchema {
+			t.Fatalf("Unexpected result for %s %d: %s, Diff: %v", file, status, stderr, testutil.Diff(testSchema, stdout))
+		}
+	}
+}
+
+func TestDiff_FailsOnBreakingChanges(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"old.graphql": testSchema,
+		"new.graphql": "type Query {\n  user(id: ID!, active: Boolean!): User\n}\n\ntype User {\n  id: ID!\n}\n",
+	})
+
+	status, stdout, _ := runIn(t, dir, "", "diff", "old.graphql", "new.graphql")
+	expected := "REQUIRED_ARG_ADDED: A required arg active on Query.user was added.\n" +
+		"FIELD_REMOVED: User.name was removed.\n"
+	if status != exitFailed || stdout != expected {
+		t.Fatalf("Unexpected result %d, Diff: %v", status, testutil.Diff(expected, stdout))
+	}
+
+	if status, stdout, _ := runIn(t, dir, "", "diff", "old.graphql", "old.graphql"); status != exitOK || stdout != "" {
+		t.Fatalf("expected no breaking changes, got %d: %s", status, stdout)
+	}
+}
+
+func TestFmt_FormatsDocumentsInPlace(t *testing.T) {
+	unformatted := "# Users\nquery   User($id: ID!){user(id:$id){ id # the id\nname}}"
+	formatted := "# Users\nquery User($id: ID!) {\n  user(id: $id) {\n    id # the id\n    name\n  }\n}\n"
+	dir := writeFiles(t, map[string]string{"query.graphql": unformatted})
+
+	status, stdout, _ := runIn(t, dir, "", "fmt", "-l", "query.graphql")
+	if status != exitFailed || stdout != "query.graphql\n" {
+		t.Fatalf("expected the file to be listed, got %d: %s", status, stdout)
+	}
+
+	if status, _, stderr := runIn(t, dir, "", "fmt", "query.graphql"); status != exitOK {
+		t.Fatalf("unexpected failure %d: %s", status, stderr)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "query.graphql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != formatted {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(formatted, string(b)))
+	}
+
+	if status, stdout, _ := runIn(t, dir, "", "fmt", "-l", "query.graphql"); status != exitOK || stdout != "" {
+		t.Fatalf("expected the file to be formatted, got %d: %s", status, stdout)
+	}
+	if status, stdout, _ := runIn(t, dir, unformatted, "fmt"); status != exitOK || stdout != formatted {
+		t.Fatalf("Unexpected result %d, Diff: %v", status, testutil.Diff(formatted, stdout))
+	}
+}
@@ -219,11 +219,14 @@ type ParseLiteralFn func(valueAST ast.Value) interface{}
 
 // ScalarConfig options for creating a new GraphQLScalar
 type ScalarConfig struct {
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Serialize    SerializeFn
-	ParseValue   ParseValueFn
-	ParseLiteral ParseLiteralFn
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	SpecifiedByURL string `json:"specifiedByURL"`
+	Serialize      SerializeFn
+	ParseValue     ParseValueFn
+	ParseLiteral   ParseLiteralFn
+	// Directives are the directives applied to the scalar, other than @specifiedBy.
+	Directives []*ObjectDirective `json:"directives"`
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -293,6 +296,14 @@ func (st *Scalar) Description() string {
 	return st.PrivateDescription
 
 }
+
+// SpecifiedByURL returns the URL of the specification describing the scalar's behaviour, if any.
+func (st *Scalar) SpecifiedByURL() string {
+	return st.scalarConfig.SpecifiedByURL
+}
+func (st *Scalar) Directives() []*ObjectDirective {
+	return st.scalarConfig.Directives
+}
 func (st *Scalar) String() string {
 	return st.PrivateName
 }
@@ -492,7 +503,7 @@ func (gt *Object) Error() error {
 	return gt.err
 }
 
-func defineInterfaces(ttype *Object, interfaces []*Interface) ([]*Interface, error) {
+func defineInterfaces(ttype Named, interfaces []*Interface) ([]*Interface, error) {
 	ifaces := []*Interface{}
 
 	if len(interfaces) == 0 {
@@ -579,11 +590,17 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 			); err != nil {
 				return resultFieldMap, err
 			}
+			if err = assertNotRequiredDeprecated(arg.Type, arg.DefaultValue, arg.DeprecationReason,
+				"Required argument %v.%v(%v:) cannot be deprecated.", ttype, fieldName, argName,
+			); err != nil {
+				return resultFieldMap, err
+			}
 			fieldArg := &Argument{
 				PrivateName:        argName,
 				PrivateDescription: arg.Description,
 				Type:               arg.Type,
 				DefaultValue:       arg.DefaultValue,
+				DeprecationReason:  arg.DeprecationReason,
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
@@ -622,6 +639,8 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+	// CacheControl sets the cache hint of the field, see CacheControlExtension.
+	CacheControl *CacheControl
 }
 
 type Fields map[string]*Field
@@ -641,9 +660,10 @@ type FieldConfigArgument map[string]*ArgumentConfig
 type FieldDirectives []*ObjectDirective
 
 type ArgumentConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
@@ -670,6 +690,7 @@ type Argument struct {
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+	DeprecationReason  string      `json:"deprecationReason"`
 }
 
 func (st *Argument) Name() string {
@@ -708,16 +729,21 @@ type Interface struct {
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
 
-	typeConfig        InterfaceConfig
-	initialisedFields bool
-	fields            FieldDefinitionMap
-	err               error
+	typeConfig            InterfaceConfig
+	initialisedFields     bool
+	fields                FieldDefinitionMap
+	initialisedInterfaces bool
+	interfaces            []*Interface
+	directives            []*ObjectDirective
+	err                   error
 }
 type InterfaceConfig struct {
 	Name        string      `json:"name"`
+	Interfaces  interface{} `json:"interfaces"`
 	Fields      interface{} `json:"fields"`
 	ResolveType ResolveTypeFn
-	Description string `json:"description"`
+	Description string             `json:"description"`
+	Directives  []*ObjectDirective `json:"directive"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -749,6 +775,7 @@ func NewInterface(config InterfaceConfig) *Interface {
 	it.PrivateName = config.Name
 	it.PrivateDescription = config.Description
 	it.ResolveType = config.ResolveType
+	it.directives = config.Directives
 	it.typeConfig = config
 
 	return it
@@ -790,6 +817,34 @@ func (it *Interface) Fields() (fields FieldDefinitionMap) {
 	return it.fields
 }
 
+// Interfaces returns the interfaces implemented by this interface.
+func (it *Interface) Interfaces() []*Interface {
+	if it.initialisedInterfaces {
+		return it.interfaces
+	}
+
+	var configInterfaces []*Interface
+	switch iface := it.typeConfig.Interfaces.(type) {
+	case InterfacesThunk:
+		configInterfaces = iface()
+	case []*Interface:
+		configInterfaces = iface
+	case nil:
+	default:
+		it.err = fmt.Errorf("Unknown Interface.Interfaces type: %T", it.typeConfig.Interfaces)
+		it.initialisedInterfaces = true
+		return nil
+	}
+
+	it.interfaces, it.err = defineInterfaces(it, configInterfaces)
+	it.initialisedInterfaces = true
+	return it.interfaces
+}
+
+func (it *Interface) Directives() []*ObjectDirective {
+	return it.directives
+}
+
 func (it *Interface) String() string {
 	return it.PrivateName
 }
@@ -827,6 +882,7 @@ type Union struct {
 	initalizedTypes bool
 	types           []*Object
 	possibleTypes   map[string]bool
+	directives      []*ObjectDirective
 
 	err error
 }
@@ -837,7 +893,8 @@ type UnionConfig struct {
 	Name        string      `json:"name"`
 	Types       interface{} `json:"types"`
 	ResolveType ResolveTypeFn
-	Description string `json:"description"`
+	Description string             `json:"description"`
+	Directives  []*ObjectDirective `json:"directive"`
 }
 
 func NewUnion(config UnionConfig) *Union {
@@ -852,6 +909,7 @@ func NewUnion(config UnionConfig) *Union {
 	objectType.PrivateName = config.Name
 	objectType.PrivateDescription = config.Description
 	objectType.ResolveType = config.ResolveType
+	objectType.directives = config.Directives
 
 	objectType.typeConfig = config
 
@@ -927,6 +985,10 @@ func (ut *Union) Description() string {
 	return ut.PrivateDescription
 }
 
+func (ut *Union) Directives() []*ObjectDirective {
+	return ut.directives
+}
+
 func (ut *Union) Error() error {
 	return ut.err
 }
@@ -972,6 +1034,7 @@ type EnumConfig struct {
 	Name        string             `json:"name"`
 	Values      EnumValueConfigMap `json:"values"`
 	Description string             `json:"description"`
+	Directives  []*ObjectDirective `json:"directives"`
 }
 type EnumValueDefinition struct {
 	Name              string      `json:"name"`
@@ -993,6 +1056,10 @@ func NewEnum(config EnumConfig) *Enum {
 	if gt.values, gt.err = gt.defineEnumValues(config.Values); gt.err != nil {
 		return gt
 	}
+	// The lookups are built up front so that schemas sharing the enum can
+	// serialize and parse its values concurrently.
+	gt.getValueLookup()
+	gt.getNameLookup()
 
 	return gt
 }
@@ -1034,6 +1101,9 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 func (gt *Enum) Values() []*EnumValueDefinition {
 	return gt.values
 }
+func (gt *Enum) Directives() []*ObjectDirective {
+	return gt.enumConfig.Directives
+}
 func (gt *Enum) Serialize(value interface{}) interface{} {
 	v := value
 	rv := reflect.ValueOf(v)
@@ -1089,6 +1159,9 @@ func (gt *Enum) getValueLookup() map[interface{}]*EnumValueDefinition {
 	}
 	valuesLookup := map[interface{}]*EnumValueDefinition{}
 	for _, value := range gt.Values() {
+		if value.Value != nil && !reflect.TypeOf(value.Value).Comparable() {
+			continue
+		}
 		valuesLookup[value.Value] = value
 	}
 	gt.valuesLookup = valuesLookup
@@ -1134,15 +1207,17 @@ type InputObject struct {
 	err        error
 }
 type InputObjectFieldConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
 }
 type InputObjectField struct {
 	PrivateName        string      `json:"name"`
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+	DeprecationReason  string      `json:"deprecationReason"`
 }
 
 func (st *InputObjectField) Name() string {
@@ -1165,6 +1240,11 @@ type InputObjectConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	Description string      `json:"description"`
+	// IsOneOf marks the input object as a OneOf input object, which requires exactly
+	// one of its fields to be provided with a non-null value.
+	IsOneOf bool `json:"isOneOf"`
+	// Directives are the directives applied to the input object, other than @oneOf.
+	Directives []*ObjectDirective `json:"directives"`
 }
 
 func NewInputObject(config InputObjectConfig) *InputObject {
@@ -1212,11 +1292,32 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 		); gt.err != nil {
 			return resultFieldMap
 		}
+		if gt.err = assertNotRequiredDeprecated(fieldConfig.Type, fieldConfig.DefaultValue, fieldConfig.DeprecationReason,
+			"Required input field %v.%v cannot be deprecated.", gt, fieldName,
+		); gt.err != nil {
+			return resultFieldMap
+		}
+		if gt.typeConfig.IsOneOf {
+			_, nonNull := fieldConfig.Type.(*NonNull)
+			if gt.err = invariantf(
+				!nonNull,
+				`OneOf input field %v.%v must be nullable.`, gt, fieldName,
+			); gt.err != nil {
+				return resultFieldMap
+			}
+			if gt.err = invariantf(
+				fieldConfig.DefaultValue == nil,
+				`OneOf input field %v.%v cannot have a default value.`, gt, fieldName,
+			); gt.err != nil {
+				return resultFieldMap
+			}
+		}
 		field := &InputObjectField{}
 		field.PrivateName = fieldName
 		field.Type = fieldConfig.Type
 		field.PrivateDescription = fieldConfig.Description
 		field.DefaultValue = fieldConfig.DefaultValue
+		field.DeprecationReason = fieldConfig.DeprecationReason
 		resultFieldMap[fieldName] = field
 	}
 	gt.init = true
@@ -1250,6 +1351,13 @@ func (gt *InputObject) Description() string {
 func (gt *InputObject) String() string {
 	return gt.PrivateName
 }
+// IsOneOf reports whether exactly one field of the input object must be provided.
+func (gt *InputObject) IsOneOf() bool {
+	return gt.typeConfig.IsOneOf
+}
+func (gt *InputObject) Directives() []*ObjectDirective {
+	return gt.typeConfig.Directives
+}
 func (gt *InputObject) Error() error {
 	return gt.err
 }
@@ -1363,6 +1471,16 @@ func assertValidName(name string) error {
 
 }
 
+// assertNotRequiredDeprecated reports an error for a deprecated argument or input field which
+// is non-null and has no default value, since clients could not stop sending it.
+func assertNotRequiredDeprecated(ttype Input, defaultValue interface{}, deprecationReason string, format string, a ...interface{}) error {
+	if deprecationReason == "" || defaultValue != nil {
+		return nil
+	}
+	_, required := ttype.(*NonNull)
+	return invariantf(!required, format, a...)
+}
+
 type ResponsePath struct {
 	Prev *ResponsePath
 	Key  interface{}
@@ -32,25 +32,29 @@ var SpecifiedDirectives = []*Directive{
 	IncludeDirective,
 	SkipDirective,
 	DeprecatedDirective,
+	SpecifiedByDirective,
+	OneOfDirective,
 }
 
 // Directive structs are used by the GraphQL runtime as a way of modifying execution
 // behavior. Type system creators will usually not create these directly.
 type Directive struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Locations   []string    `json:"locations"`
-	Args        []*Argument `json:"args"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Locations    []string    `json:"locations"`
+	Args         []*Argument `json:"args"`
+	IsRepeatable bool        `json:"isRepeatable"`
 
 	err error
 }
 
 // DirectiveConfig options for creating a new GraphQLDirective
 type DirectiveConfig struct {
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	Locations   []string            `json:"locations"`
-	Args        FieldConfigArgument `json:"args"`
+	Name         string              `json:"name"`
+	Description  string              `json:"description"`
+	Locations    []string            `json:"locations"`
+	Args         FieldConfigArgument `json:"args"`
+	IsRepeatable bool                `json:"isRepeatable"`
 }
 
 func NewDirective(config DirectiveConfig) *Directive {
@@ -77,11 +81,17 @@ func NewDirective(config DirectiveConfig) *Directive {
 		if dir.err = assertValidName(argName); dir.err != nil {
 			return dir
 		}
+		if dir.err = assertNotRequiredDeprecated(argConfig.Type, argConfig.DefaultValue, argConfig.DeprecationReason,
+			"Required argument @%v(%v:) cannot be deprecated.", config.Name, argName,
+		); dir.err != nil {
+			return dir
+		}
 		args = append(args, &Argument{
 			PrivateName:        argName,
 			PrivateDescription: argConfig.Description,
 			Type:               argConfig.Type,
 			DefaultValue:       argConfig.DefaultValue,
+			DeprecationReason:  argConfig.DeprecationReason,
 		})
 	}
 
@@ -89,6 +99,7 @@ func NewDirective(config DirectiveConfig) *Directive {
 	dir.Description = config.Description
 	dir.Locations = config.Locations
 	dir.Args = args
+	dir.IsRepeatable = config.IsRepeatable
 	return dir
 }
 
@@ -143,10 +154,36 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 	},
 	Locations: []string{
 		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
 		DirectiveLocationEnumValue,
 	},
 })
 
+// SpecifiedByDirective Used to provide a URL for specifying the behaviour of custom scalar definitions.
+var SpecifiedByDirective = NewDirective(DirectiveConfig{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Args: FieldConfigArgument{
+		"url": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The URL that specifies the behaviour of this scalar.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationScalar,
+	},
+})
+
+// OneOfDirective Used to indicate that exactly one field of an input object must be provided.
+var OneOfDirective = NewDirective(DirectiveConfig{
+	Name:        "oneOf",
+	Description: "Indicates exactly one field must be supplied and this field must not be `null`.",
+	Locations: []string{
+		DirectiveLocationInputObject,
+	},
+})
+
 // ExternalDirective
 // directive @external on FIELD_DEFINITION
 var ExternalDirective = NewDirective(DirectiveConfig{
@@ -154,7 +154,7 @@ func TestDirectivesWorksWithoutDirectives(t *testing.T) {
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -168,7 +168,7 @@ func TestDirectivesWorksOnScalarsIfTrueIncludesScalar(t *testing.T) {
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -181,7 +181,7 @@ func TestDirectivesWorksOnScalarsIfFalseOmitsOnScalar(t *testing.T) {
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -195,7 +195,7 @@ func TestDirectivesWorksOnScalarsUnlessFalseIncludesScalar(t *testing.T) {
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -208,7 +208,7 @@ func TestDirectivesWorksOnScalarsUnlessTrueOmitsScalar(t *testing.T) {
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -229,7 +229,7 @@ func TestDirectivesWorksOnFragmentSpreadsIfFalseOmitsFragmentSpread(t *testing.T
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -251,7 +251,7 @@ func TestDirectivesWorksOnFragmentSpreadsIfTrueIncludesFragmentSpread(t *testing
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -273,7 +273,7 @@ func TestDirectivesWorksOnFragmentSpreadsUnlessFalseIncludesFragmentSpread(t *te
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -294,7 +294,7 @@ func TestDirectivesWorksOnFragmentSpreadsUnlessTrueOmitsFragmentSpread(t *testin
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -314,7 +314,7 @@ func TestDirectivesWorksOnInlineFragmentIfFalseOmitsInlineFragment(t *testing.T)
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -335,7 +335,7 @@ func TestDirectivesWorksOnInlineFragmentIfTrueIncludesInlineFragment(t *testing.
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -356,7 +356,7 @@ func TestDirectivesWorksOnInlineFragmentUnlessFalseIncludesInlineFragment(t *tes
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -376,7 +376,7 @@ func TestDirectivesWorksOnInlineFragmentUnlessTrueIncludesInlineFragment(t *test
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -396,7 +396,7 @@ func TestDirectivesWorksOnAnonymousInlineFragmentIfFalseOmitsAnonymousInlineFrag
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -417,7 +417,7 @@ func TestDirectivesWorksOnAnonymousInlineFragmentIfTrueIncludesAnonymousInlineFr
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -438,7 +438,7 @@ func TestDirectivesWorksOnAnonymousInlineFragmentUnlessFalseIncludesAnonymousInl
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -458,7 +458,7 @@ func TestDirectivesWorksOnAnonymousInlineFragmentUnlessTrueIncludesAnonymousInli
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -472,7 +472,7 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_IncludeAndNoSkip(t *testing
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -485,7 +485,7 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_IncludeAndSkip(t *testing.T
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -498,7 +498,7 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_NoIncludeAndSkip(t *testing
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -511,6 +511,6 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_NoIncludeOrSkip(t *testing.
 	}
 	result := executeDirectivesTestQuery(t, query)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
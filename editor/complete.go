@@ -0,0 +1,223 @@
+package editor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// CompletionKind is the kind of a completion candidate.
+type CompletionKind string
+
+const (
+	CompletionField     CompletionKind = "Field"
+	CompletionArgument  CompletionKind = "Argument"
+	CompletionEnumValue CompletionKind = "EnumValue"
+	CompletionVariable  CompletionKind = "Variable"
+	CompletionFragment  CompletionKind = "Fragment"
+	CompletionDirective CompletionKind = "Directive"
+)
+
+// Completion is a candidate for the word at the cursor.
+type Completion struct {
+	// Label is the text of the candidate, the name replacing the word at the cursor.
+	Label string
+	Kind  CompletionKind
+	// Detail is the type of the candidate, such as "[String!]" for a field or an
+	// argument, or "on User" for a fragment.
+	Detail            string
+	Description       string
+	DeprecationReason string
+}
+
+// placeholder is the name standing for the word at the cursor when there is none, so
+// that the document parses.
+const placeholder = "_"
+
+// CompleteAt returns the candidates for the word at offset in the GraphQL document
+// text: the fields of the parent type, the arguments of a field or directive, the
+// values of an enum, the variables in scope, the fragments, or the directives allowed
+// there. Only the candidates starting with the part of the word before the cursor,
+// whatever their case, are returned, and nil when there is nothing to complete.
+func CompleteAt(schema *graphql.Schema, text string, offset int) []Completion {
+	if offset < 0 || offset > len(text) {
+		return nil
+	}
+	start, end := wordAt(text, offset)
+	prefix := text[start:offset]
+	if start == end {
+		text = text[:start] + placeholder + text[end:]
+		end += len(placeholder)
+	}
+	// Arguments and object fields need a value to parse.
+	for _, suffix := range []string{"", ": " + placeholder} {
+		patched := text[:end] + suffix + text[end:]
+		if c := find(schema, parse(patched), start); c != nil {
+			return c.complete(prefix)
+		}
+	}
+	return nil
+}
+
+func (c *cursor) complete(prefix string) []Completion {
+	completions := completions{prefix: strings.ToLower(prefix)}
+	if _, ok := c.node.(*ast.EnumValue); ok {
+		if enum, ok := graphql.GetNamed(c.inputType).(*graphql.Enum); ok {
+			values := append([]*graphql.EnumValueDefinition(nil), enum.Values()...)
+			sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+			for _, value := range values {
+				completions.add(Completion{
+					Label:             value.Name,
+					Kind:              CompletionEnumValue,
+					Detail:            enum.Name(),
+					Description:       value.Description,
+					DeprecationReason: value.DeprecationReason,
+				})
+			}
+		}
+		return completions.list
+	}
+
+	switch parent := c.parent(1).(type) {
+	case *ast.Field:
+		if parent.Name != c.node {
+			break
+		}
+		fields := fieldsOf(c.parentType)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			completions.field(fields[name])
+		}
+		if c.parentType != nil {
+			completions.field(graphql.TypeNameMetaFieldDef)
+		}
+	case *ast.Argument:
+		var args []*graphql.Argument
+		var present []*ast.Argument
+		switch owner := c.parent(2).(type) {
+		case *ast.Field:
+			if c.fieldDef != nil {
+				args = c.fieldDef.Args
+			}
+			present = owner.Arguments
+		case *ast.Directive:
+			if c.directive != nil {
+				args = c.directive.Args
+			}
+			present = owner.Arguments
+		}
+	args:
+		for _, arg := range args {
+			for _, argument := range present {
+				if argument != parent && nameOf(argument.Name) == arg.Name() {
+					continue args
+				}
+			}
+			completions.add(Completion{
+				Label:             arg.Name(),
+				Kind:              CompletionArgument,
+				Detail:            arg.Type.String(),
+				Description:       arg.Description(),
+				DeprecationReason: arg.DeprecationReason,
+			})
+		}
+	case *ast.Variable:
+		if _, ok := c.parent(2).(*ast.VariableDefinition); ok {
+			break
+		}
+		for _, definition := range c.variables() {
+			if definition.Variable == nil {
+				continue
+			}
+			detail, _ := printer.Print(definition.Type).(string)
+			completions.add(Completion{
+				Label:  nameOf(definition.Variable.Name),
+				Kind:   CompletionVariable,
+				Detail: detail,
+			})
+		}
+	case *ast.FragmentSpread:
+		for _, definition := range c.doc.Definitions {
+			fragment, ok := definition.(*ast.FragmentDefinition)
+			if !ok || nameOf(fragment.Name) == "" {
+				continue
+			}
+			detail := ""
+			if fragment.TypeCondition != nil {
+				detail = "on " + nameOf(fragment.TypeCondition.Name)
+			}
+			completions.add(Completion{
+				Label:  nameOf(fragment.Name),
+				Kind:   CompletionFragment,
+				Detail: detail,
+			})
+		}
+	case *ast.Directive:
+		location := directiveLocation(c.parent(2))
+		for _, directive := range c.schema.Directives() {
+			for _, allowed := range directive.Locations {
+				if allowed == location {
+					completions.add(Completion{
+						Label:       directive.Name,
+						Kind:        CompletionDirective,
+						Description: directive.Description,
+					})
+					break
+				}
+			}
+		}
+	}
+	return completions.list
+}
+
+// completions collects the candidates starting with prefix, which is lower case.
+type completions struct {
+	prefix string
+	list   []Completion
+}
+
+func (cs *completions) add(completion Completion) {
+	if strings.HasPrefix(strings.ToLower(completion.Label), cs.prefix) {
+		cs.list = append(cs.list, completion)
+	}
+}
+
+func (cs *completions) field(field *graphql.FieldDefinition) {
+	cs.add(Completion{
+		Label:             field.Name,
+		Kind:              CompletionField,
+		Detail:            field.Type.String(),
+		Description:       field.Description,
+		DeprecationReason: field.DeprecationReason,
+	})
+}
+
+// directiveLocation returns the location of a directive applied to node.
+func directiveLocation(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.OperationDefinition:
+		switch node.Operation {
+		case ast.OperationTypeMutation:
+			return graphql.DirectiveLocationMutation
+		case ast.OperationTypeSubscription:
+			return graphql.DirectiveLocationSubscription
+		}
+		return graphql.DirectiveLocationQuery
+	case *ast.Field:
+		return graphql.DirectiveLocationField
+	case *ast.FragmentSpread:
+		return graphql.DirectiveLocationFragmentSpread
+	case *ast.InlineFragment:
+		return graphql.DirectiveLocationInlineFragment
+	case *ast.FragmentDefinition:
+		return graphql.DirectiveLocationFragmentDefinition
+	}
+	return ""
+}
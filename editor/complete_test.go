@@ -0,0 +1,70 @@
+package editor_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql/editor"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+// cursorAt returns text without the "|" marking the cursor, and the offset of the
+// cursor.
+func cursorAt(text string) (string, int) {
+	offset := strings.Index(text, "|")
+	return strings.Replace(text, "|", "", 1), offset
+}
+
+func labels(completions []editor.Completion) []string {
+	labels := []string{}
+	for _, completion := range completions {
+		labels = append(labels, completion.Label)
+	}
+	return labels
+}
+
+func TestCompleteAt_CompletesTheWordAtTheCursor(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{`{ | }`, []string{"droid", "hero", "human", "__typename"}},
+		{`{ h| }`, []string{"hero", "human"}},
+		{`{ hero { id, NA|me } }`, []string{"name"}},
+		{"{ hero {\n  id\n  |\n} }", []string{"appearsIn", "friends", "id", "name", "__typename"}},
+		{`{ human(|) }`, []string{"id"}},
+		{`{ hero(e|) { id } }`, []string{"episode"}},
+		{`{ hero(episode: |) { id } }`, []string{"EMPIRE", "JEDI", "NEWHOPE"}},
+		{`{ hero(episode: J|) { id } }`, []string{"JEDI"}},
+		{`query Q($episode: Episode, $id: String!) { hero(episode: $|) { id } }`, []string{"episode", "id"}},
+		{`{ hero { ...| } } fragment HumanFields on Human { id } fragment DroidFields on Droid { id }`, []string{"HumanFields", "DroidFields"}},
+		{`{ hero @| { id } }`, []string{"include", "skip"}},
+		{`{ hero @include(|) { id } }`, []string{"if"}},
+		{`{ hero { id } } { droid(id: "1") { | `, []string{"appearsIn", "friends", "id", "name", "primaryFunction", "__typename"}},
+		{`{ hero { unknown { | } } }`, []string{}},
+		{`query Q($|) { hero { id } }`, []string{}},
+	}
+	for _, test := range tests {
+		text, offset := cursorAt(test.text)
+		completions := editor.CompleteAt(&testutil.StarWarsSchema, text, offset)
+		if got := labels(completions); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.text, test.expected, got)
+		}
+	}
+}
+
+func TestCompleteAt_DescribesTheCandidates(t *testing.T) {
+	text, offset := cursorAt(`{ hero(episode: NEW|) { id } }`)
+	completions := editor.CompleteAt(&testutil.StarWarsSchema, text, offset)
+
+	expected := []editor.Completion{{
+		Label:       "NEWHOPE",
+		Kind:        editor.CompletionEnumValue,
+		Detail:      "Episode",
+		Description: "Released in 1977.",
+	}}
+	if !reflect.DeepEqual(completions, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, completions))
+	}
+}
@@ -0,0 +1,211 @@
+// Package editor provides the editor support of GraphQL documents written against a
+// graphql.Schema: completion candidates for the word at the cursor, hover information
+// and the location of definitions.
+//
+// The functions of this package take the text of the document and a cursor offset,
+// the byte index of the cursor in the text. Documents being edited are seldom valid,
+// so they are parsed tolerantly: the definitions and selections holding syntax errors
+// are set aside, and the rest of the document still helps.
+package editor
+
+import (
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/lexer"
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/language/source"
+	"github.com/tailor-inc/graphql/language/visitor"
+)
+
+// parse parses text tolerantly, returning nil when even that fails. The brackets left
+// open at the end of text, as while typing, are closed first.
+func parse(text string) *ast.Document {
+	doc, _ := parser.Parse(parser.ParseParams{
+		Source:  text + closers(text),
+		Options: parser.ParseOptions{Tolerant: true},
+	})
+	return doc
+}
+
+// closers returns the brackets closing those left open in text, as far as it lexes.
+func closers(text string) string {
+	closing := map[lexer.TokenKind]byte{lexer.BRACE_L: '}', lexer.PAREN_L: ')', lexer.BRACKET_L: ']'}
+	open := []byte{}
+	lex := lexer.Lex(source.NewSource(&source.Source{Body: []byte(text)}))
+	for {
+		token, err := lex(0)
+		if err != nil || token.Kind == lexer.EOF {
+			break
+		}
+		switch token.Kind {
+		case lexer.BRACE_L, lexer.PAREN_L, lexer.BRACKET_L:
+			open = append(open, closing[token.Kind])
+		case lexer.BRACE_R, lexer.PAREN_R, lexer.BRACKET_R:
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		}
+	}
+	for i, j := 0, len(open)-1; i < j; i, j = i+1, j-1 {
+		open[i], open[j] = open[j], open[i]
+	}
+	return string(open)
+}
+
+// wordAt returns the bounds of the name around offset, which are both offset when
+// there is none.
+func wordAt(text string, offset int) (start, end int) {
+	start, end = offset, offset
+	for start > 0 && isNameByte(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isNameByte(text[end]) {
+		end++
+	}
+	return start, end
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// cursor is the state of the walk of a document at the word under the cursor, which
+// is a name or an enum value.
+type cursor struct {
+	schema *graphql.Schema
+	doc    *ast.Document
+	node   ast.Node
+	// ancestors are the nodes holding node, starting with the document and ending
+	// with its parent.
+	ancestors  []ast.Node
+	parentType graphql.Composite
+	inputType  graphql.Input
+	fieldDef   *graphql.FieldDefinition
+	directive  *graphql.Directive
+	argument   *graphql.Argument
+}
+
+// find returns the cursor at the word of doc starting at start, or nil when there is
+// no such word.
+func find(schema *graphql.Schema, doc *ast.Document, start int) *cursor {
+	if schema == nil || doc == nil {
+		return nil
+	}
+	var found *cursor
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: schema})
+	visitor.Walk(doc, visitor.WalkWithTypeInfo(typeInfo, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			loc := c.Node().GetLoc()
+			if loc == nil {
+				return visitor.ActionNoChange
+			}
+			if start < loc.Start || start > loc.End {
+				return visitor.ActionSkip
+			}
+			switch c.Node().(type) {
+			case *ast.Name, *ast.EnumValue:
+				if loc.Start != start {
+					return visitor.ActionNoChange
+				}
+			default:
+				return visitor.ActionNoChange
+			}
+			found = &cursor{
+				schema:     schema,
+				doc:        doc,
+				node:       c.Node(),
+				ancestors:  append(append([]ast.Node{}, c.Ancestors()...), c.Parent()),
+				parentType: compositeOf(typeInfo.ParentType()),
+				inputType:  typeInfo.InputType(),
+				fieldDef:   typeInfo.FieldDef(),
+				directive:  typeInfo.Directive(),
+				argument:   typeInfo.Argument(),
+			}
+			return visitor.ActionBreak
+		},
+	}))
+	return found
+}
+
+// parent returns the ancestor of the node n levels up, 1 being its parent.
+func (c *cursor) parent(n int) ast.Node {
+	if n > len(c.ancestors) {
+		return nil
+	}
+	return c.ancestors[len(c.ancestors)-n]
+}
+
+// variables returns the variable definitions in scope at the cursor: those of its
+// operation, or of every operation within a fragment.
+func (c *cursor) variables() []*ast.VariableDefinition {
+	for _, ancestor := range c.ancestors {
+		if operation, ok := ancestor.(*ast.OperationDefinition); ok {
+			return operation.VariableDefinitions
+		}
+	}
+	definitions := []*ast.VariableDefinition{}
+	for _, definition := range c.doc.Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			definitions = append(definitions, operation.VariableDefinitions...)
+		}
+	}
+	return definitions
+}
+
+// variable returns the definition of the variable named name in scope at the cursor.
+func (c *cursor) variable(name string) *ast.VariableDefinition {
+	for _, definition := range c.variables() {
+		if definition.Variable != nil && nameOf(definition.Variable.Name) == name {
+			return definition
+		}
+	}
+	return nil
+}
+
+// fragment returns the definition of the fragment named name.
+func (c *cursor) fragment(name string) *ast.FragmentDefinition {
+	for _, definition := range c.doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && nameOf(fragment.Name) == name {
+			return fragment
+		}
+	}
+	return nil
+}
+
+func nameOf(name *ast.Name) string {
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+// compositeOf returns t, or nil when t holds a nil type, as the root type of an
+// operation the schema lacks.
+func compositeOf(t graphql.Composite) graphql.Composite {
+	switch t := t.(type) {
+	case *graphql.Object:
+		if t == nil {
+			return nil
+		}
+	case *graphql.Interface:
+		if t == nil {
+			return nil
+		}
+	case *graphql.Union:
+		if t == nil {
+			return nil
+		}
+	}
+	return t
+}
+
+// fieldsOf returns the fields of t, which unions have none of.
+func fieldsOf(t graphql.Composite) graphql.FieldDefinitionMap {
+	switch t := compositeOf(t).(type) {
+	case *graphql.Object:
+		return t.Fields()
+	case *graphql.Interface:
+		return t.Fields()
+	}
+	return nil
+}
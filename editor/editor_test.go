@@ -0,0 +1,57 @@
+package editor_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tailor-inc/graphql/editor"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+// kitchenSink returns the kitchen sink query, which uses most of the syntax of
+// executable documents.
+func kitchenSink(t testing.TB) string {
+	b, err := os.ReadFile("../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql: %v", err)
+	}
+	return string(b)
+}
+
+// atEveryOffset calls the functions of the package at every offset of text, which
+// must not panic whatever the document.
+func atEveryOffset(text string) {
+	for offset := 0; offset <= len(text); offset++ {
+		editor.CompleteAt(&testutil.StarWarsSchema, text, offset)
+		editor.HoverAt(&testutil.StarWarsSchema, text, offset)
+		editor.DefinitionAt(&testutil.StarWarsSchema, text, offset)
+	}
+}
+
+func TestEditor_ToleratesPartialDocuments(t *testing.T) {
+	atEveryOffset(`query Q($bar: = 1) { hero { name } }`)
+	atEveryOffset(`query Q($bar: [ = 1) { hero { ...frag name } }`)
+	atEveryOffset(`fragment F on { name } { hero { ...F } }`)
+	atEveryOffset(kitchenSink(t))
+
+	// The document is typed up to the cursor, which is at its end.
+	text := kitchenSink(t)
+	for end := 0; end <= len(text); end++ {
+		editor.CompleteAt(&testutil.StarWarsSchema, text[:end], end)
+		editor.HoverAt(&testutil.StarWarsSchema, text[:end], end)
+		editor.DefinitionAt(&testutil.StarWarsSchema, text[:end], end)
+	}
+}
+
+func FuzzEditor(f *testing.F) {
+	text := kitchenSink(f)
+	for end := 0; end <= len(text); end += 37 {
+		f.Add(text[:end], end)
+	}
+	f.Add(`query Q($bar: = 1) { hero { name } }`, 14)
+	f.Fuzz(func(t *testing.T, text string, offset int) {
+		editor.CompleteAt(&testutil.StarWarsSchema, text, offset)
+		editor.HoverAt(&testutil.StarWarsSchema, text, offset)
+		editor.DefinitionAt(&testutil.StarWarsSchema, text, offset)
+	})
+}
@@ -0,0 +1,166 @@
+package editor
+
+import (
+	"strings"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// Hover is the information shown for the word under the cursor.
+type Hover struct {
+	// Signature is the declaration of what the word refers to, such as
+	// "User.friends(first: Int): [User]" for a field, "Episode.JEDI" for an enum value
+	// or "type User" for a type.
+	Signature         string
+	Description       string
+	DeprecationReason string
+	// Loc is the location of the word.
+	Loc *ast.Location
+}
+
+// HoverAt returns the information on the field, argument, enum value, type,
+// directive, fragment or variable at offset in the GraphQL document text, or nil when
+// there is none.
+func HoverAt(schema *graphql.Schema, text string, offset int) *Hover {
+	c := at(schema, text, offset)
+	if c == nil {
+		return nil
+	}
+	hover := c.hover()
+	if hover != nil {
+		hover.Loc = c.node.GetLoc()
+	}
+	return hover
+}
+
+// DefinitionAt returns the location of the definition of the fragment or variable at
+// offset in the GraphQL document text, or nil when there is none.
+func DefinitionAt(schema *graphql.Schema, text string, offset int) *ast.Location {
+	c := at(schema, text, offset)
+	if c == nil {
+		return nil
+	}
+	switch parent := c.parent(1).(type) {
+	case *ast.FragmentSpread:
+		if fragment := c.fragment(nameOf(parent.Name)); fragment != nil {
+			return fragment.Loc
+		}
+	case *ast.Variable:
+		if definition := c.variable(nameOf(parent.Name)); definition != nil {
+			return definition.Loc
+		}
+	}
+	return nil
+}
+
+// at returns the cursor at the word around offset in text.
+func at(schema *graphql.Schema, text string, offset int) *cursor {
+	if offset < 0 || offset > len(text) {
+		return nil
+	}
+	start, end := wordAt(text, offset)
+	if start == end {
+		return nil
+	}
+	return find(schema, parse(text), start)
+}
+
+func (c *cursor) hover() *Hover {
+	if node, ok := c.node.(*ast.EnumValue); ok {
+		enum, ok := graphql.GetNamed(c.inputType).(*graphql.Enum)
+		if !ok {
+			return nil
+		}
+		for _, value := range enum.Values() {
+			if value.Name == node.Value {
+				return &Hover{
+					Signature:         enum.Name() + "." + value.Name,
+					Description:       value.Description,
+					DeprecationReason: value.DeprecationReason,
+				}
+			}
+		}
+		return nil
+	}
+
+	switch parent := c.parent(1).(type) {
+	case *ast.Field:
+		if c.fieldDef == nil || c.parentType == nil {
+			return nil
+		}
+		return &Hover{
+			Signature:         c.parentType.Name() + "." + c.fieldDef.Name + arguments(c.fieldDef.Args) + ": " + c.fieldDef.Type.String(),
+			Description:       c.fieldDef.Description,
+			DeprecationReason: c.fieldDef.DeprecationReason,
+		}
+	case *ast.Argument:
+		if c.argument == nil {
+			return nil
+		}
+		return &Hover{
+			Signature:         c.argument.Name() + ": " + c.argument.Type.String(),
+			Description:       c.argument.Description(),
+			DeprecationReason: c.argument.DeprecationReason,
+		}
+	case *ast.Named:
+		t := c.schema.Type(nameOf(parent.Name))
+		if t == nil {
+			return nil
+		}
+		return &Hover{Signature: keyword(t) + " " + t.Name(), Description: t.Description()}
+	case *ast.Directive:
+		if c.directive == nil {
+			return nil
+		}
+		return &Hover{
+			Signature:   "@" + c.directive.Name + arguments(c.directive.Args),
+			Description: c.directive.Description,
+		}
+	case *ast.FragmentSpread, *ast.FragmentDefinition:
+		fragment := c.fragment(nameOf(c.node.(*ast.Name)))
+		if fragment == nil || fragment.TypeCondition == nil {
+			return nil
+		}
+		return &Hover{Signature: "fragment " + nameOf(fragment.Name) + " on " + nameOf(fragment.TypeCondition.Name)}
+	case *ast.Variable:
+		definition := c.variable(nameOf(parent.Name))
+		if definition == nil {
+			return nil
+		}
+		typ, _ := printer.Print(definition.Type).(string)
+		return &Hover{Signature: "$" + nameOf(parent.Name) + ": " + typ}
+	}
+	return nil
+}
+
+// arguments returns the declaration of args between parentheses, which is empty when
+// there are none.
+func arguments(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	declarations := make([]string, len(args))
+	for i, arg := range args {
+		declarations[i] = arg.Name() + ": " + arg.Type.String()
+	}
+	return "(" + strings.Join(declarations, ", ") + ")"
+}
+
+// keyword returns the keyword declaring a type like t.
+func keyword(t graphql.Type) string {
+	switch t.(type) {
+	case *graphql.Object:
+		return "type"
+	case *graphql.Interface:
+		return "interface"
+	case *graphql.Union:
+		return "union"
+	case *graphql.Enum:
+		return "enum"
+	case *graphql.InputObject:
+		return "input"
+	}
+	return "scalar"
+}
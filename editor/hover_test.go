@@ -0,0 +1,78 @@
+package editor_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql/editor"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestHoverAt_DescribesTheWordAtTheCursor(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected *editor.Hover
+	}{
+		{`{ he|ro { id } }`, &editor.Hover{
+			Signature: "Query.hero(episode: Episode): Character",
+		}},
+		{`{ hero { na|me } }`, &editor.Hover{
+			Signature:   "Character.name: String",
+			Description: "The name of the character.",
+		}},
+		{`{ human(i|d: "1") { id } }`, &editor.Hover{
+			Signature:   "id: String!",
+			Description: "id of the human",
+		}},
+		{`{ hero(episode: |JEDI) { id } }`, &editor.Hover{
+			Signature:   "Episode.JEDI",
+			Description: "Released in 1983.",
+		}},
+		{`{ hero { ... on Droid| { id } } }`, &editor.Hover{
+			Signature:   "type Droid",
+			Description: "A mechanical creature in the Star Wars universe.",
+		}},
+		{`{ hero @sk|ip(if: true) { id } }`, &editor.Hover{
+			Signature:   "@skip(if: Boolean!)",
+			Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		}},
+		{`query Q($id: String!) { human(id: $i|d) { ...F } } fragment F on Human { id }`, &editor.Hover{
+			Signature: "$id: String!",
+		}},
+		{`{ human(id: "1") { ...F| } } fragment F on Human { id }`, &editor.Hover{
+			Signature: "fragment F on Human",
+		}},
+		{`{ hero { unknown| } }`, nil},
+		{`{ hero { id } } | `, nil},
+	}
+	for _, test := range tests {
+		text, offset := cursorAt(test.text)
+		hover := editor.HoverAt(&testutil.StarWarsSchema, text, offset)
+		if hover != nil {
+			if hover.Loc == nil || hover.Loc.Start > offset || hover.Loc.End < offset {
+				t.Errorf("%s: expected the location of the word, got %v", test.text, hover.Loc)
+			}
+			hover.Loc = nil
+		}
+		if !reflect.DeepEqual(hover, test.expected) {
+			t.Errorf("%s: Unexpected result, Diff: %v", test.text, testutil.Diff(test.expected, hover))
+		}
+	}
+}
+
+func TestDefinitionAt_LocatesFragmentsAndVariables(t *testing.T) {
+	text := `query Q($id: String!) { human(id: $id) { ...HumanFields } } fragment HumanFields on Human { id }`
+
+	loc := editor.DefinitionAt(&testutil.StarWarsSchema, text, strings.Index(text, "...HumanFields")+5)
+	if loc == nil || !strings.HasPrefix(text[loc.Start:], "fragment HumanFields") {
+		t.Fatalf("expected the location of the fragment, got %v", loc)
+	}
+	loc = editor.DefinitionAt(&testutil.StarWarsSchema, text, strings.Index(text, "$id)")+1)
+	if loc == nil || text[loc.Start:loc.End] != "$id: String!" {
+		t.Fatalf("expected the location of the variable, got %v", loc)
+	}
+	if loc := editor.DefinitionAt(&testutil.StarWarsSchema, text, strings.Index(text, "human")); loc != nil {
+		t.Fatalf("expected no definition, got %v", loc)
+	}
+}
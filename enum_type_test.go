@@ -144,8 +144,8 @@ func TestTypeSystem_EnumValues_AcceptsEnumLiteralsAsInput(t *testing.T) {
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -157,8 +157,8 @@ func TestTypeSystem_EnumValues_EnumMayBeOutputType(t *testing.T) {
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_EnumMayBeBothInputAndOutputType(t *testing.T) {
@@ -169,8 +169,8 @@ func TestTypeSystem_EnumValues_EnumMayBeBothInputAndOutputType(t *testing.T) {
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptStringLiterals(t *testing.T) {
@@ -188,7 +188,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptStringLiterals(t *testing.T) {
 	}
 	result := executeEnumTypeTest(t, query)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptIncorrectInternalValue(t *testing.T) {
@@ -199,8 +199,8 @@ func TestTypeSystem_EnumValues_DoesNotAcceptIncorrectInternalValue(t *testing.T)
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueInPlaceOfEnumLiteral(t *testing.T) {
@@ -218,7 +218,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueInPlaceOfEnumLiteral(t
 	}
 	result := executeEnumTypeTest(t, query)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -237,7 +237,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptEnumLiteralInPlaceOfInt(t *testing.T
 	}
 	result := executeEnumTypeTest(t, query)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -252,8 +252,8 @@ func TestTypeSystem_EnumValues_AcceptsJSONStringAsEnumVariable(t *testing.T) {
 		},
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -268,8 +268,8 @@ func TestTypeSystem_EnumValues_AcceptsEnumLiteralsAsInputArgumentsToMutations(t
 		},
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -284,8 +284,8 @@ func TestTypeSystem_EnumValues_AcceptsEnumLiteralsAsInputArgumentsToSubscription
 		},
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueAsEnumVariable(t *testing.T) {
@@ -306,7 +306,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueAsEnumVariable(t *testi
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptStringVariablesAsEnumInput(t *testing.T) {
@@ -324,7 +324,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptStringVariablesAsEnumInput(t *testin
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueVariableAsEnumInput(t *testing.T) {
@@ -342,7 +342,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueVariableAsEnumInput(t *
 	}
 	result := executeEnumTypeTestWithParams(t, query, params)
 	if !testutil.EqualErrorMessage(expected, result, 0) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_EnumValueMayHaveAnInternalValueOfZero(t *testing.T) {
@@ -357,8 +357,8 @@ func TestTypeSystem_EnumValues_EnumValueMayHaveAnInternalValueOfZero(t *testing.
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestTypeSystem_EnumValues_EnumValueMayBeNullable(t *testing.T) {
@@ -373,8 +373,8 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNullable(t *testing.T) {
 		},
 	}
 	result := executeEnumTypeTest(t, query)
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -417,8 +417,8 @@ func TestTypeSystem_EnumValues_EnumValueMayBePointer(t *testing.T) {
 		Schema:        enumTypeTestSchema,
 		RequestString: query,
 	})
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -456,7 +456,7 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNilPointer(t *testing.T) {
 		Schema:        enumTypeTestSchema,
 		RequestString: query,
 	})
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"sort"
 	"strings"
 
 	"github.com/tailor-inc/graphql/gqlerrors"
@@ -144,7 +143,7 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 		return nil, fmt.Errorf(`Must provide an operation.`)
 	}
 
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
+	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args, p.Schema.inputVisibility(p.Context))
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +240,7 @@ type executeFieldsParams struct {
 	ExecutionContext *executionContext
 	ParentType       *Object
 	Source           interface{}
-	Fields           map[string][]*ast.Field
+	Fields           *collectedFields
 	Path             *ResponsePath
 }
 
@@ -251,11 +250,11 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 		p.Source = map[string]interface{}{}
 	}
 	if p.Fields == nil {
-		p.Fields = map[string][]*ast.Field{}
+		p.Fields = newCollectedFields()
 	}
 
-	finalResults := make(map[string]interface{}, len(p.Fields))
-	for _, orderedField := range orderedFields(p.Fields) {
+	finalResults := NewOrderedMap()
+	for _, orderedField := range p.Fields.ordered {
 		responseName := orderedField.responseName
 		fieldASTs := orderedField.fieldASTs
 		fieldPath := p.Path.WithKey(responseName)
@@ -263,7 +262,7 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 		if state.hasNoFieldDefs {
 			continue
 		}
-		finalResults[responseName] = resolved
+		finalResults.Set(responseName, resolved)
 	}
 	dethunkMapDepthFirst(finalResults)
 
@@ -285,23 +284,24 @@ func executeFields(p executeFieldsParams) *Result {
 	}
 }
 
-func executeSubFields(p executeFieldsParams) map[string]interface{} {
+func executeSubFields(p executeFieldsParams) *OrderedMap {
 
 	if p.Source == nil {
 		p.Source = map[string]interface{}{}
 	}
 	if p.Fields == nil {
-		p.Fields = map[string][]*ast.Field{}
+		p.Fields = newCollectedFields()
 	}
 
-	finalResults := make(map[string]interface{}, len(p.Fields))
-	for responseName, fieldASTs := range p.Fields {
+	finalResults := NewOrderedMap()
+	for _, orderedField := range p.Fields.ordered {
+		responseName := orderedField.responseName
 		fieldPath := p.Path.WithKey(responseName)
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, fieldPath)
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, orderedField.fieldASTs, fieldPath)
 		if state.hasNoFieldDefs {
 			continue
 		}
-		finalResults[responseName] = resolved
+		finalResults.Set(responseName, resolved)
 	}
 
 	return finalResults
@@ -326,7 +326,7 @@ func (d *dethunkQueue) shift() func() {
 // in the map values and replacing each thunk with that thunk's return value. This parallels
 // the reference graphql-js implementation, which calls Promise.all on thunks at each depth (which
 // is an implicit parallel descent).
-func dethunkMapWithBreadthFirstTraversal(finalResults map[string]interface{}) {
+func dethunkMapWithBreadthFirstTraversal(finalResults *OrderedMap) {
 	dethunkQueue := &dethunkQueue{DethunkFuncs: []func(){}}
 	dethunkMapBreadthFirst(finalResults, dethunkQueue)
 	for len(dethunkQueue.DethunkFuncs) > 0 {
@@ -335,13 +335,13 @@ func dethunkMapWithBreadthFirstTraversal(finalResults map[string]interface{}) {
 	}
 }
 
-func dethunkMapBreadthFirst(m map[string]interface{}, dethunkQueue *dethunkQueue) {
-	for k, v := range m {
-		if f, ok := v.(func() interface{}); ok {
-			m[k] = f()
+func dethunkMapBreadthFirst(m *OrderedMap, dethunkQueue *dethunkQueue) {
+	for _, k := range m.keys {
+		if f, ok := m.values[k].(func() interface{}); ok {
+			m.values[k] = f()
 		}
-		switch val := m[k].(type) {
-		case map[string]interface{}:
+		switch val := m.values[k].(type) {
+		case *OrderedMap:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
 		case []interface{}:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
@@ -355,7 +355,7 @@ func dethunkListBreadthFirst(list []interface{}, dethunkQueue *dethunkQueue) {
 			list[i] = f()
 		}
 		switch val := list[i].(type) {
-		case map[string]interface{}:
+		case *OrderedMap:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
 		case []interface{}:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
@@ -367,13 +367,13 @@ func dethunkListBreadthFirst(list []interface{}, dethunkQueue *dethunkQueue) {
 // in the map values and replacing each thunk with that thunk's return value. This is needed
 // to conform to the graphql-js reference implementation, which requires serial (depth-first)
 // implementations for mutation selects.
-func dethunkMapDepthFirst(m map[string]interface{}) {
-	for k, v := range m {
-		if f, ok := v.(func() interface{}); ok {
-			m[k] = f()
+func dethunkMapDepthFirst(m *OrderedMap) {
+	for _, k := range m.keys {
+		if f, ok := m.values[k].(func() interface{}); ok {
+			m.values[k] = f()
 		}
-		switch val := m[k].(type) {
-		case map[string]interface{}:
+		switch val := m.values[k].(type) {
+		case *OrderedMap:
 			dethunkMapDepthFirst(val)
 		case []interface{}:
 			dethunkListDepthFirst(val)
@@ -387,7 +387,7 @@ func dethunkListDepthFirst(list []interface{}) {
 			list[i] = f()
 		}
 		switch val := list[i].(type) {
-		case map[string]interface{}:
+		case *OrderedMap:
 			dethunkMapDepthFirst(val)
 		case []interface{}:
 			dethunkListDepthFirst(val)
@@ -399,23 +399,23 @@ type collectFieldsParams struct {
 	ExeContext           *executionContext
 	RuntimeType          *Object // previously known as OperationType
 	SelectionSet         *ast.SelectionSet
-	Fields               map[string][]*ast.Field
+	Fields               *collectedFields
 	VisitedFragmentNames map[string]bool
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
-// the passed in map of fields, and returns it at the end.
+// the passed in fields, and returns them at the end.
 // CollectFields requires the "runtime type" of an object. For a field which
 // returns and Interface or Union type, the "runtime type" will be the actual
 // Object type returned by that field.
-func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
+func collectFields(p collectFieldsParams) (fields *collectedFields) {
 	// overlying SelectionSet & Fields to fields
 	if p.SelectionSet == nil {
 		return p.Fields
 	}
 	fields = p.Fields
 	if fields == nil {
-		fields = map[string][]*ast.Field{}
+		fields = newCollectedFields()
 	}
 	if p.VisitedFragmentNames == nil {
 		p.VisitedFragmentNames = map[string]bool{}
@@ -426,11 +426,7 @@ func collectFields(p collectFieldsParams) (fields map[string][]*ast.Field) {
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) {
 				continue
 			}
-			name := getFieldEntryKey(selection)
-			if _, ok := fields[name]; !ok {
-				fields[name] = []*ast.Field{}
-			}
-			fields[name] = append(fields[name], selection)
+			fields.add(getFieldEntryKey(selection), selection)
 		case *ast.InlineFragment:
 
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) ||
@@ -611,7 +607,7 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	}
 
 	fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
-	if fieldDef == nil {
+	if fieldDef == nil || !eCtx.Schema.isFieldVisible(eCtx.Context, parentType, fieldDef) {
 		resultState.hasNoFieldDefs = true
 		return nil, resultState
 	}
@@ -624,7 +620,8 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args := getArgumentValues(eCtx.Schema.visibleArguments(eCtx.Context, parentType, fieldDef.Args),
+		fieldAST.Arguments, eCtx.VariableValues)
 
 	info := ResolveInfo{
 		FieldName:      fieldName,
@@ -767,7 +764,7 @@ func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, fi
 	}
 	fnResult, err := propertyFn()
 	if err != nil {
-		panic(gqlerrors.FormatError(err))
+		panic(err)
 	}
 
 	result = fnResult
@@ -813,6 +810,13 @@ func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldAST
 				`for "%v".`, runtimeType, returnType),
 		))
 	}
+	// A hidden runtime type is unknown to the request, so its name is not reported.
+	if !eCtx.Schema.IsVisible(eCtx.Context, runtimeType) {
+		panic(gqlerrors.NewFormattedError(
+			fmt.Sprintf(`Abstract type "%v" must resolve to a visible Object type at runtime `+
+				`for field %v.%v.`, returnType, info.ParentType, info.FieldName),
+		))
+	}
 
 	return completeObjectValue(eCtx, runtimeType, fieldASTs, info, path, result)
 }
@@ -837,7 +841,7 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 	}
 
 	// Collect sub-fields to execute to complete this value.
-	subFieldASTs := map[string][]*ast.Field{}
+	subFieldASTs := newCollectedFields()
 	visitedFragmentNames := map[string]bool{}
 	for _, fieldAST := range fieldASTs {
 		if fieldAST == nil {
@@ -1046,32 +1050,23 @@ type orderedField struct {
 	fieldASTs    []*ast.Field
 }
 
-// orders fields from a fields map by location in the source
-func orderedFields(fields map[string][]*ast.Field) []*orderedField {
-	orderedFields := []*orderedField{}
-	fieldMap := map[int]*orderedField{}
-	startLocs := []int{}
-
-	for responseName, fieldASTs := range fields {
-		// find the lowest location in the current fieldASTs
-		lowest := -1
-		for _, fieldAST := range fieldASTs {
-			loc := fieldAST.GetLoc().Start
-			if lowest == -1 || loc < lowest {
-				lowest = loc
-			}
-		}
-		startLocs = append(startLocs, lowest)
-		fieldMap[lowest] = &orderedField{
-			responseName: responseName,
-			fieldASTs:    fieldASTs,
-		}
-	}
+// collectedFields are the fields of a selection set grouped by response name, in the
+// order the response names first occur, which is the order of the response.
+type collectedFields struct {
+	ordered []*orderedField
+	byName  map[string]*orderedField
+}
 
-	sort.Ints(startLocs)
-	for _, startLoc := range startLocs {
-		orderedFields = append(orderedFields, fieldMap[startLoc])
-	}
+func newCollectedFields() *collectedFields {
+	return &collectedFields{byName: map[string]*orderedField{}}
+}
 
-	return orderedFields
+func (c *collectedFields) add(responseName string, fieldAST *ast.Field) {
+	field, ok := c.byName[responseName]
+	if !ok {
+		field = &orderedField{responseName: responseName}
+		c.byName[responseName] = field
+		c.ordered = append(c.ordered, field)
+	}
+	field.fieldASTs = append(field.fieldASTs, fieldAST)
 }
@@ -40,8 +40,8 @@ func TestExecutesResolveFunction_DefaultFunctionAccessesProperties(t *testing.T)
 		RequestString: `{ test }`,
 		RootObject:    source,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 }
 
@@ -63,8 +63,8 @@ func TestExecutesResolveFunction_DefaultFunctionCallsMethods(t *testing.T) {
 		RequestString: `{ test }`,
 		RootObject:    source,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 }
 
@@ -88,8 +88,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction(t *testing.T) {
 		Schema:        schema,
 		RequestString: `{ test }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -99,8 +99,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction(t *testing.T) {
 		Schema:        schema,
 		RequestString: `{ test(aStr: "String!") }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -110,8 +110,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction(t *testing.T) {
 		Schema:        schema,
 		RequestString: `{ test(aInt: -123, aStr: "String!") }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 }
 
@@ -157,8 +157,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		RequestString: `{ test { Str, Int } }`,
 	})
 
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -171,8 +171,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		Schema:        schema,
 		RequestString: `{ test(aStr: "String!") { Str, Int } }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -185,8 +185,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		Schema:        schema,
 		RequestString: `{ test(aInt: -123, aStr: "String!") { Str, Int } }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 }
 
@@ -233,8 +233,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		RequestString: `{ test { str, int } }`,
 	})
 
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -247,8 +247,8 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		Schema:        schema,
 		RequestString: `{ test(aStr: "String!") { str, int } }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 
 	expected = map[string]interface{}{
@@ -261,7 +261,7 @@ func TestExecutesResolveFunction_UsesProvidedResolveFunction_SourceIsStruct_With
 		Schema:        schema,
 		RequestString: `{ test(aInt: -123, aStr: "String!") { str, int } }`,
 	})
-	if !reflect.DeepEqual(expected, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.Data))
+	if !reflect.DeepEqual(expected, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result.DataMap()))
 	}
 }
@@ -205,8 +205,8 @@ func TestExecutesArbitraryCode(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -292,8 +292,8 @@ func TestMergesParallelFragments(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -348,7 +348,7 @@ func TestCustomMapType(t *testing.T) {
 			"a": "1",
 		},
 	}
-	if !reflect.DeepEqual(result.Data, expected) {
+	if !reflect.DeepEqual(result.DataMap(), expected) {
 		t.Fatalf("Expected context.key to equal %v, got %v", expected, result.Data)
 	}
 }
@@ -507,8 +507,8 @@ func TestThreadsRootValueContextCorrectly(t *testing.T) {
 			"a": "stringValue",
 		},
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -554,8 +554,8 @@ func TestThreadsContextCorrectly(t *testing.T) {
 			"a": "bar",
 		},
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -619,8 +619,8 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 		Root:   data,
 	}
 	result := testutil.TestExecute(t, ep)
-	if !reflect.DeepEqual(expectedData, result.Data) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedData, result.Data))
+	if !reflect.DeepEqual(expectedData, result.DataMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedData, result.DataMap()))
 	}
 	if !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
@@ -667,8 +667,8 @@ func TestUsesTheInlineOperationIfNoOperationNameIsProvided(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -712,8 +712,8 @@ func TestUsesTheOnlyOperationIfNoOperationNameIsProvided(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -757,8 +757,8 @@ func TestUsesTheNamedOperationIfOperationNameIsProvided(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1008,8 +1008,8 @@ func TestUsesTheQuerySchemaForQueries(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1063,8 +1063,8 @@ func TestUsesTheMutationSchemaForMutations(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1118,8 +1118,8 @@ func TestUsesTheSubscriptionSchemaForSubscriptions(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1191,19 +1191,82 @@ func TestCorrectFieldOrderingDespiteExecutionOrder(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 
-	// TODO: test to ensure key ordering
-	// The following does not work
-	// - iterating over result.Data map
-	//   Note that golang's map iteration order is randomized
-	//   So, iterating over result.Data won't do it for a test
-	// - Marshal the result.Data to json string and assert it
-	//   json.Marshal seems to re-sort the keys automatically
-	//
-	t.Skipf("TODO: Ensure key ordering")
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"data":{"b":"b","a":"a","c":"c","d":"d","e":"e"}}`; string(b) != expected {
+		t.Fatalf("Expected %v, got %v", expected, string(b))
+	}
+}
+
+func TestFieldOrderingFollowsTheFirstOccurrenceInFragments(t *testing.T) {
+	doc := `
+	{
+      ...Later
+      a
+      nested { d c ... on Nested { c b } }
+    }
+
+	fragment Later on Type { b c }
+	`
+	nestedType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Nested",
+		Fields: graphql.Fields{
+			"b": &graphql.Field{Type: graphql.String},
+			"c": &graphql.Field{Type: graphql.String},
+			"d": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Type",
+			Fields: graphql.Fields{
+				"a":      &graphql.Field{Type: graphql.String},
+				"b":      &graphql.Field{Type: graphql.String},
+				"c":      &graphql.Field{Type: graphql.String},
+				"nested": &graphql.Field{Type: nestedType},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	data := map[string]interface{}{
+		"a": "a", "b": "b", "c": "c",
+		"nested": map[string]interface{}{"b": "b", "c": "c", "d": "d"},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, doc),
+		Root:   data,
+	})
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"b":"b","c":"c","a":"a","nested":{"d":"d","c":"c","b":"b"}}`; string(b) != expected {
+		t.Fatalf("Expected %v, got %v", expected, string(b))
+	}
+
+	// Unmarshaled ordered maps keep the order too.
+	decoded := graphql.NewOrderedMap()
+	if err := json.Unmarshal(b, decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if keys := decoded.Keys(); !reflect.DeepEqual(keys, []string{"b", "c", "a", "nested"}) {
+		t.Fatalf("Unexpected keys %v", keys)
+	}
+	if again, _ := json.Marshal(decoded); string(again) != string(b) {
+		t.Fatalf("Expected %v, got %v", string(b), string(again))
+	}
+	if !reflect.DeepEqual(decoded.Map(), data) {
+		t.Fatalf("Expected %v, got %v", data, decoded.Map())
+	}
 }
 
 func TestAvoidsRecursion(t *testing.T) {
@@ -1258,8 +1321,8 @@ func TestAvoidsRecursion(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 
 }
@@ -1308,8 +1371,8 @@ func TestDoesNotIncludeIllegalFieldsInOutput(t *testing.T) {
 	if len(result.Errors) != 0 {
 		t.Fatalf("wrong result, expected len(%v) errors, got len(%v)", len(expected.Errors), len(result.Errors))
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1370,8 +1433,8 @@ func TestDoesNotIncludeArgumentsThatWereNotSet(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !reflect.DeepEqual(expected, testutil.PlainResult(result)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1463,7 +1526,7 @@ func TestFailsWhenAnIsTypeOfCheckIsNotMet(t *testing.T) {
 	}
 	result := testutil.TestExecute(t, ep)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1508,7 +1571,7 @@ func TestFailsToExecuteQueryContainingATypeDefinition(t *testing.T) {
 	}
 	result := testutil.TestExecute(t, ep)
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1788,7 +1851,7 @@ func TestGraphqlTag(t *testing.T) {
 			"fooBar": "foo bar value",
 		},
 	}
-	if !reflect.DeepEqual(result.Data, expectedData) {
+	if !reflect.DeepEqual(result.DataMap(), expectedData) {
 		t.Fatalf("unexpected result, got: %+v, expected: %+v", expectedData, result.Data)
 	}
 }
@@ -1843,7 +1906,7 @@ func TestFieldResolver(t *testing.T) {
 			"fooBar": "foo bar value",
 		},
 	}
-	if !reflect.DeepEqual(result.Data, expectedData) {
+	if !reflect.DeepEqual(result.DataMap(), expectedData) {
 		t.Fatalf("unexpected result, got: %+v, expected: %+v", result.Data, expectedData)
 	}
 }
@@ -1976,7 +2039,7 @@ func TestThunkResultsProcessedCorrectly(t *testing.T) {
 		t.Fatalf("expected no errors, got %v", result.Errors)
 	}
 
-	foo := result.Data.(map[string]interface{})["foo"].(map[string]interface{})
+	foo := result.DataMap()["foo"].(map[string]interface{})
 	bar, ok := foo["bar"].(map[string]interface{})
 
 	if !ok {
@@ -2069,7 +2132,7 @@ func TestThunkErrorsAreHandledCorrectly(t *testing.T) {
 		RequestString: query,
 	})
 
-	foo := result.Data.(map[string]interface{})["foo"].(map[string]interface{})
+	foo := result.DataMap()["foo"].(map[string]interface{})
 	bar, ok := foo["bar"].(map[string]interface{})
 
 	if !ok {
@@ -0,0 +1,326 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BreakingChangeType is the kind of a BreakingChange.
+type BreakingChangeType string
+
+const (
+	BreakingChangeTypeRemoved                 BreakingChangeType = "TYPE_REMOVED"
+	BreakingChangeTypeChangedKind             BreakingChangeType = "TYPE_CHANGED_KIND"
+	BreakingChangeTypeRemovedFromUnion        BreakingChangeType = "TYPE_REMOVED_FROM_UNION"
+	BreakingChangeValueRemovedFromEnum        BreakingChangeType = "VALUE_REMOVED_FROM_ENUM"
+	BreakingChangeRequiredInputFieldAdded     BreakingChangeType = "REQUIRED_INPUT_FIELD_ADDED"
+	BreakingChangeImplementedInterfaceRemoved BreakingChangeType = "IMPLEMENTED_INTERFACE_REMOVED"
+	BreakingChangeFieldRemoved                BreakingChangeType = "FIELD_REMOVED"
+	BreakingChangeFieldChangedKind            BreakingChangeType = "FIELD_CHANGED_KIND"
+	BreakingChangeRequiredArgAdded            BreakingChangeType = "REQUIRED_ARG_ADDED"
+	BreakingChangeArgRemoved                  BreakingChangeType = "ARG_REMOVED"
+	BreakingChangeArgChangedKind              BreakingChangeType = "ARG_CHANGED_KIND"
+	BreakingChangeDirectiveRemoved            BreakingChangeType = "DIRECTIVE_REMOVED"
+	BreakingChangeDirectiveArgRemoved         BreakingChangeType = "DIRECTIVE_ARG_REMOVED"
+	BreakingChangeRequiredDirectiveArgAdded   BreakingChangeType = "REQUIRED_DIRECTIVE_ARG_ADDED"
+	BreakingChangeDirectiveRepeatableRemoved  BreakingChangeType = "DIRECTIVE_REPEATABLE_REMOVED"
+	BreakingChangeDirectiveLocationRemoved    BreakingChangeType = "DIRECTIVE_LOCATION_REMOVED"
+	BreakingChangeRootOperationTypeChanged    BreakingChangeType = "ROOT_OPERATION_TYPE_CHANGED"
+)
+
+// BreakingChange is a change of a schema which may break the documents or the
+// responses of its clients.
+type BreakingChange struct {
+	Type        BreakingChangeType
+	Description string
+}
+
+// FindBreakingChanges returns the changes from oldSchema to newSchema which may break
+// their clients: removed types, fields, arguments, enum values and directives, types
+// changed in an incompatible way, and arguments and input fields newly required. The
+// changes of root operation types come first, then those of types and directives by
+// name.
+func FindBreakingChanges(oldSchema, newSchema Schema) []BreakingChange {
+	c := &breakingChanges{}
+	c.rootTypes(oldSchema, newSchema)
+	c.types(oldSchema.TypeMap(), newSchema.TypeMap())
+	c.directives(oldSchema.Directives(), newSchema.Directives())
+	return c.list
+}
+
+type breakingChanges struct {
+	list []BreakingChange
+}
+
+func (c *breakingChanges) add(t BreakingChangeType, format string, a ...interface{}) {
+	c.list = append(c.list, BreakingChange{Type: t, Description: fmt.Sprintf(format, a...)})
+}
+
+func (c *breakingChanges) rootTypes(oldSchema, newSchema Schema) {
+	roots := []struct {
+		operation      string
+		oldType, ttype *Object
+	}{
+		{"query", oldSchema.QueryType(), newSchema.QueryType()},
+		{"mutation", oldSchema.MutationType(), newSchema.MutationType()},
+		{"subscription", oldSchema.SubscriptionType(), newSchema.SubscriptionType()},
+	}
+	for _, root := range roots {
+		switch {
+		case root.oldType == nil:
+		case root.ttype == nil:
+			c.add(BreakingChangeRootOperationTypeChanged, "The %s root type was removed.", root.operation)
+		case root.oldType.Name() != root.ttype.Name():
+			c.add(BreakingChangeRootOperationTypeChanged, "The %s root type changed from %s to %s.",
+				root.operation, root.oldType.Name(), root.ttype.Name())
+		}
+	}
+}
+
+func (c *breakingChanges) types(oldTypes, newTypes TypeMap) {
+	names := make([]string, 0, len(oldTypes))
+	for name := range oldTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		oldType, newType := oldTypes[name], newTypes[name]
+		if newType == nil {
+			c.add(BreakingChangeTypeRemoved, "%s was removed.", name)
+			continue
+		}
+		if kindOf(oldType) != kindOf(newType) {
+			c.add(BreakingChangeTypeChangedKind, "%s changed from %s to %s.", name, kindOf(oldType), kindOf(newType))
+			continue
+		}
+		switch oldType := oldType.(type) {
+		case *Object:
+			newType := newType.(*Object)
+			c.interfaces(name, oldType.Interfaces(), newType.Interfaces())
+			c.fields(name, oldType.Fields(), newType.Fields())
+		case *Interface:
+			newType := newType.(*Interface)
+			c.interfaces(name, oldType.Interfaces(), newType.Interfaces())
+			c.fields(name, oldType.Fields(), newType.Fields())
+		case *Union:
+			members := map[string]bool{}
+			for _, member := range newType.(*Union).Types() {
+				members[member.Name()] = true
+			}
+			for _, member := range oldType.Types() {
+				if !members[member.Name()] {
+					c.add(BreakingChangeTypeRemovedFromUnion, "%s was removed from union type %s.", member.Name(), name)
+				}
+			}
+		case *Enum:
+			values := map[string]bool{}
+			for _, value := range newType.(*Enum).Values() {
+				values[value.Name] = true
+			}
+			for _, value := range oldType.Values() {
+				if !values[value.Name] {
+					c.add(BreakingChangeValueRemovedFromEnum, "%s was removed from enum type %s.", value.Name, name)
+				}
+			}
+		case *InputObject:
+			c.inputFields(name, oldType.Fields(), newType.(*InputObject).Fields())
+		}
+	}
+}
+
+// kindOf returns the kind of a named type, as written in the descriptions of changes.
+func kindOf(ttype Type) string {
+	switch ttype.(type) {
+	case *Object:
+		return "an Object type"
+	case *Interface:
+		return "an Interface type"
+	case *Union:
+		return "a Union type"
+	case *Enum:
+		return "an Enum type"
+	case *InputObject:
+		return "an Input type"
+	}
+	return "a Scalar type"
+}
+
+func (c *breakingChanges) interfaces(name string, oldInterfaces, newInterfaces []*Interface) {
+	implemented := map[string]bool{}
+	for _, iface := range newInterfaces {
+		implemented[iface.Name()] = true
+	}
+	for _, iface := range oldInterfaces {
+		if !implemented[iface.Name()] {
+			c.add(BreakingChangeImplementedInterfaceRemoved, "%s no longer implements interface %s.", name, iface.Name())
+		}
+	}
+}
+
+func (c *breakingChanges) fields(name string, oldFields, newFields FieldDefinitionMap) {
+	fieldNames := make([]string, 0, len(oldFields))
+	for fieldName := range oldFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		oldField, newField := oldFields[fieldName], newFields[fieldName]
+		if newField == nil {
+			c.add(BreakingChangeFieldRemoved, "%s.%s was removed.", name, fieldName)
+			continue
+		}
+		if !isSafeOutputTypeChange(oldField.Type, newField.Type) {
+			c.add(BreakingChangeFieldChangedKind, "%s.%s changed type from %s to %s.",
+				name, fieldName, oldField.Type, newField.Type)
+		}
+		c.args(name+"."+fieldName, oldField.Args, newField.Args,
+			BreakingChangeArgRemoved, BreakingChangeRequiredArgAdded)
+	}
+}
+
+// args reports the changes of the arguments of owner, which is a field or a directive.
+func (c *breakingChanges) args(owner string, oldArgs, newArgs []*Argument, removed, requiredAdded BreakingChangeType) {
+	oldArgs, newArgs = sortArguments(oldArgs), sortArguments(newArgs)
+	for _, oldArg := range oldArgs {
+		newArg := findArgument(newArgs, oldArg.Name())
+		if newArg == nil {
+			c.add(removed, "%s arg %s was removed.", owner, oldArg.Name())
+			continue
+		}
+		if !isSafeInputTypeChange(oldArg.Type, newArg.Type) {
+			c.add(BreakingChangeArgChangedKind, "%s arg %s has changed type from %s to %s.",
+				owner, oldArg.Name(), oldArg.Type, newArg.Type)
+		}
+	}
+	for _, newArg := range newArgs {
+		if findArgument(oldArgs, newArg.Name()) == nil && isRequired(newArg.Type, newArg.DefaultValue) {
+			c.add(requiredAdded, "A required arg %s on %s was added.", newArg.Name(), owner)
+		}
+	}
+}
+
+func sortArguments(args []*Argument) []*Argument {
+	sorted := append([]*Argument{}, args...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	return sorted
+}
+
+func findArgument(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg.Name() == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+func (c *breakingChanges) inputFields(name string, oldFields, newFields InputObjectFieldMap) {
+	fieldNames := make([]string, 0, len(oldFields))
+	for fieldName := range oldFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		oldField, newField := oldFields[fieldName], newFields[fieldName]
+		if newField == nil {
+			c.add(BreakingChangeFieldRemoved, "%s.%s was removed.", name, fieldName)
+			continue
+		}
+		if !isSafeInputTypeChange(oldField.Type, newField.Type) {
+			c.add(BreakingChangeFieldChangedKind, "%s.%s changed type from %s to %s.",
+				name, fieldName, oldField.Type, newField.Type)
+		}
+	}
+	fieldNames = fieldNames[:0]
+	for fieldName, newField := range newFields {
+		if oldFields[fieldName] == nil && isRequired(newField.Type, newField.DefaultValue) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		c.add(BreakingChangeRequiredInputFieldAdded, "A required field %s on input type %s was added.", fieldName, name)
+	}
+}
+
+// isRequired reports whether an argument or input field of type ttype must be given.
+func isRequired(ttype Type, defaultValue interface{}) bool {
+	_, nonNull := ttype.(*NonNull)
+	return nonNull && defaultValue == nil
+}
+
+// isSafeOutputTypeChange reports whether the values of a field of type newType are
+// still valid for clients expecting oldType: the type may only become non-null.
+func isSafeOutputTypeChange(oldType, newType Type) bool {
+	if newNonNull, ok := newType.(*NonNull); ok {
+		if oldNonNull, ok := oldType.(*NonNull); ok {
+			return isSafeOutputTypeChange(oldNonNull.OfType, newNonNull.OfType)
+		}
+		return isSafeOutputTypeChange(oldType, newNonNull.OfType)
+	}
+	switch oldType := oldType.(type) {
+	case *NonNull:
+		return false
+	case *List:
+		newList, ok := newType.(*List)
+		return ok && isSafeOutputTypeChange(oldType.OfType, newList.OfType)
+	}
+	_, isList := newType.(*List)
+	return !isList && oldType.Name() == newType.Name()
+}
+
+// isSafeInputTypeChange reports whether the values given for oldType are still valid
+// for newType: the type may only become nullable.
+func isSafeInputTypeChange(oldType, newType Type) bool {
+	if oldNonNull, ok := oldType.(*NonNull); ok {
+		if newNonNull, ok := newType.(*NonNull); ok {
+			return isSafeInputTypeChange(oldNonNull.OfType, newNonNull.OfType)
+		}
+		return isSafeInputTypeChange(oldNonNull.OfType, newType)
+	}
+	switch newType := newType.(type) {
+	case *NonNull:
+		return false
+	case *List:
+		oldList, ok := oldType.(*List)
+		return ok && isSafeInputTypeChange(oldList.OfType, newType.OfType)
+	}
+	_, isList := oldType.(*List)
+	return !isList && oldType.Name() == newType.Name()
+}
+
+func (c *breakingChanges) directives(oldDirectives, newDirectives []*Directive) {
+	sorted := append([]*Directive{}, oldDirectives...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	for _, oldDirective := range sorted {
+		var newDirective *Directive
+		for _, directive := range newDirectives {
+			if directive.Name == oldDirective.Name {
+				newDirective = directive
+			}
+		}
+		name := "@" + oldDirective.Name
+		if newDirective == nil {
+			c.add(BreakingChangeDirectiveRemoved, "%s was removed.", name)
+			continue
+		}
+		c.args(name, oldDirective.Args, newDirective.Args,
+			BreakingChangeDirectiveArgRemoved, BreakingChangeRequiredDirectiveArgAdded)
+		if oldDirective.IsRepeatable && !newDirective.IsRepeatable {
+			c.add(BreakingChangeDirectiveRepeatableRemoved, "Repeatable flag was removed from %s.", name)
+		}
+		locations := map[string]bool{}
+		for _, location := range newDirective.Locations {
+			locations[location] = true
+		}
+		for _, location := range oldDirective.Locations {
+			if !locations[location] {
+				c.add(BreakingChangeDirectiveLocationRemoved, "%s was removed from %s.", location, name)
+			}
+		}
+	}
+}
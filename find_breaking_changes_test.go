@@ -0,0 +1,67 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestFindBreakingChanges_FindsChangesBreakingClients(t *testing.T) {
+	oldSchema := parseSDL(t, `
+type Query {
+  user(id: ID!, name: String): User
+  users(first: Int): [User]
+  removed: String
+}
+interface Node { id: ID! }
+type User implements Node { id: ID! name: String friends: [User!] }
+union Search = User | Query
+enum Role { ADMIN USER }
+input Filter { name: String, role: Role }
+scalar Date
+directive @auth(role: Role, scopes: [String]) repeatable on FIELD_DEFINITION | OBJECT
+directive @removed on FIELD
+`)
+	newSchema := parseSDL(t, `
+type Query {
+  user(id: ID, name: Int, active: Boolean!, limit: Int! = 10): User!
+  users(first: Int): [User]!
+}
+interface Node { id: ID! }
+type User { id: ID! name: String! friends: [User] }
+union Search = User
+enum Role { ADMIN }
+input Filter { name: String!, role: Role, required: Int!, optional: Int }
+enum Date { TODAY }
+directive @auth(role: Role!, required: String!) on FIELD_DEFINITION
+`)
+
+	changes := graphql.FindBreakingChanges(oldSchema, newSchema)
+	expected := []graphql.BreakingChange{
+		{graphql.BreakingChangeTypeChangedKind, "Date changed from a Scalar type to an Enum type."},
+		{graphql.BreakingChangeFieldChangedKind, "Filter.name changed type from String to String!."},
+		{graphql.BreakingChangeRequiredInputFieldAdded, "A required field required on input type Filter was added."},
+		{graphql.BreakingChangeFieldRemoved, "Query.removed was removed."},
+		{graphql.BreakingChangeArgChangedKind, "Query.user arg name has changed type from String to Int."},
+		{graphql.BreakingChangeRequiredArgAdded, "A required arg active on Query.user was added."},
+		{graphql.BreakingChangeValueRemovedFromEnum, "USER was removed from enum type Role."},
+		{graphql.BreakingChangeTypeRemovedFromUnion, "Query was removed from union type Search."},
+		{graphql.BreakingChangeImplementedInterfaceRemoved, "User no longer implements interface Node."},
+		{graphql.BreakingChangeFieldChangedKind, "User.friends changed type from [User!] to [User]."},
+		{graphql.BreakingChangeArgChangedKind, "@auth arg role has changed type from Role to Role!."},
+		{graphql.BreakingChangeDirectiveArgRemoved, "@auth arg scopes was removed."},
+		{graphql.BreakingChangeRequiredDirectiveArgAdded, "A required arg required on @auth was added."},
+		{graphql.BreakingChangeDirectiveRepeatableRemoved, "Repeatable flag was removed from @auth."},
+		{graphql.BreakingChangeDirectiveLocationRemoved, "OBJECT was removed from @auth."},
+		{graphql.BreakingChangeDirectiveRemoved, "@removed was removed."},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, changes))
+	}
+
+	if changes := graphql.FindBreakingChanges(newSchema, newSchema); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
@@ -31,6 +31,10 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// ParseOptions limit the size and nesting of the parsed requestString, see
+	// parser.ParseOptions.
+	ParseOptions parser.ParseOptions
 }
 
 func Do(p Params) *Result {
@@ -55,13 +59,13 @@ func Do(p Params) *Result {
 	}
 
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	AST, err := parser.Parse(parser.ParseParams{Source: source, Options: p.ParseOptions})
 	if err != nil {
 		// run parseFinishFuncs for extensions
 		extErrs = parseFinishFn(err)
 
 		// merge the errors from extensions and the original error from parser
-		extErrs = append(extErrs, gqlerrors.FormatErrors(err)...)
+		extErrs = append(extErrs, formatParseErrors(err)...)
 		return &Result{
 			Errors: extErrs,
 		}
@@ -84,7 +88,7 @@ func Do(p Params) *Result {
 	}
 
 	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	validationResult := ValidateDocumentWithContext(p.Context, &p.Schema, AST, nil)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
@@ -114,3 +118,16 @@ func Do(p Params) *Result {
 		Context:       p.Context,
 	})
 }
+
+// formatParseErrors formats the error returned by parser.Parse, which holds every
+// syntax error of the request when it is parsed tolerantly.
+func formatParseErrors(err error) []gqlerrors.FormattedError {
+	if errs, ok := err.(parser.SyntaxErrors); ok {
+		formatted := make([]gqlerrors.FormattedError, len(errs))
+		for i, err := range errs {
+			formatted[i] = gqlerrors.FormatError(err)
+		}
+		return formatted
+	}
+	return gqlerrors.FormatErrors(err)
+}
@@ -5,6 +5,10 @@ import (
 
 	"github.com/tailor-inc/graphql"
 	"github.com/tailor-inc/graphql/benchutil"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/language/visitor"
+	"github.com/tailor-inc/graphql/testutil"
 )
 
 type B struct {
@@ -122,3 +126,83 @@ func nFieldsyItemsQueryBenchmark(x int, y int) func(b *testing.B) {
 		}
 	}
 }
+
+// validationBenchmarkQuery exercises most of the specified rules on the Star Wars schema.
+const validationBenchmarkQuery = `
+	query HeroQuery($episode: Episode, $withFriends: Boolean!, $id: String!) {
+		hero(episode: $episode) {
+			...CharacterFields
+			friends @include(if: $withFriends) {
+				...CharacterFields
+				... on Human {
+					homePlanet
+				}
+				... on Droid {
+					primaryFunction
+				}
+			}
+		}
+		human(id: $id) {
+			name
+			appearsIn
+			friends {
+				name
+				friends {
+					name
+				}
+			}
+		}
+		droid(id: "2001") {
+			...CharacterFields
+			primaryFunction
+		}
+	}
+
+	fragment CharacterFields on Character {
+		id
+		name
+		appearsIn
+	}
+`
+
+// BenchmarkValidate validates a query with the specified rules, which are walked.
+func BenchmarkValidate(b *testing.B) {
+	astDoc := parseBenchmarkQuery(b, validationBenchmarkQuery)
+	schema := testutil.StarWarsSchema
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if result := graphql.ValidateDocument(&schema, astDoc, nil); !result.IsValid {
+			b.Fatalf("unexpected errors: %v", result.Errors)
+		}
+	}
+}
+
+// BenchmarkValidate_Visit validates the query of BenchmarkValidate by visiting the
+// specified rules with reflection, as validation did before Walk.
+func BenchmarkValidate_Visit(b *testing.B) {
+	astDoc := parseBenchmarkQuery(b, validationBenchmarkQuery)
+	schema := testutil.StarWarsSchema
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: &schema})
+		context := graphql.NewValidationContext(&schema, astDoc, typeInfo)
+		visitors := []*visitor.VisitorOptions{}
+		for _, rule := range graphql.SpecifiedRules {
+			visitors = append(visitors, rule(context).VisitorOpts)
+		}
+		visitor.Visit(astDoc, visitor.VisitWithTypeInfo(typeInfo, visitor.VisitInParallel(visitors...)), nil)
+		if errs := context.Errors(); len(errs) > 0 {
+			b.Fatalf("unexpected errors: %v", errs)
+		}
+	}
+}
+
+func parseBenchmarkQuery(b *testing.B, query string) *ast.Document {
+	astDoc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return astDoc
+}
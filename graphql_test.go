@@ -3,9 +3,12 @@ package graphql_test
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/location"
+	"github.com/tailor-inc/graphql/language/parser"
 	"github.com/tailor-inc/graphql/testutil"
 )
 
@@ -109,8 +112,40 @@ func testGraphql(test T, p graphql.Params, t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(result, test.Expected) {
-		t.Fatalf("wrong result, query: %v, graphql result diff: %v", test.Query, testutil.Diff(test.Expected, result))
+	if !reflect.DeepEqual(testutil.PlainResult(result), test.Expected) {
+		t.Fatalf("wrong result, query: %v, graphql result diff: %v", test.Query, testutil.Diff(test.Expected, testutil.PlainResult(result)))
+	}
+}
+
+func TestParseOptionsLimitTheRequest(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero { friends { friends { name } } } }`,
+		ParseOptions:  parser.ParseOptions{MaxSelectionDepth: 3},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	expected := "Syntax Error GraphQL request (1:28) Selection sets are nested deeper than 3 levels."
+	if err := result.Errors[0]; !strings.HasPrefix(err.Message, expected) ||
+		!reflect.DeepEqual(err.Locations, []location.SourceLocation{{Line: 1, Column: 28}}) {
+		t.Fatalf("unexpected error: %v at %v", err.Message, err.Locations)
+	}
+}
+
+func TestTolerantParseReportsEverySyntaxError(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero { name( } } query { human(id: ) }`,
+		ParseOptions:  parser.ParseOptions{Tolerant: true},
+	})
+	locations := []location.SourceLocation{}
+	for _, err := range result.Errors {
+		locations = append(locations, err.Locations...)
+	}
+	expected := []location.SourceLocation{{Line: 1, Column: 16}, {Line: 1, Column: 38}}
+	if result.Data != nil || !reflect.DeepEqual(locations, expected) {
+		t.Fatalf("unexpected result: %v", result)
 	}
 }
 
@@ -149,8 +184,8 @@ func TestBasicGraphQLExample(t *testing.T) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
-	if !reflect.DeepEqual(result.Data, expected) {
-		t.Fatalf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
+	if !reflect.DeepEqual(result.DataMap(), expected) {
+		t.Fatalf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 
 }
@@ -188,8 +223,8 @@ func TestThreadsContextFromParamsThrough(t *testing.T) {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
 	expected := map[string]interface{}{"value": "xyz"}
-	if !reflect.DeepEqual(result.Data, expected) {
-		t.Fatalf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
+	if !reflect.DeepEqual(result.DataMap(), expected) {
+		t.Fatalf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 
 }
@@ -264,7 +299,7 @@ func TestEmptyStringIsNotNull(t *testing.T) {
 		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
 	}
 	expected := map[string]interface{}{"checkEmptyArg": "yay", "checkEmptyResult": ""}
-	if !reflect.DeepEqual(result.Data, expected) {
-		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
+	if !reflect.DeepEqual(result.DataMap(), expected) {
+		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
@@ -0,0 +1,211 @@
+// Package handler serves a graphql.Schema over HTTP. It accepts GET requests, JSON,
+// application/graphql and form encoded POST bodies, and graphql multipart requests
+// carrying file uploads (https://github.com/jaydenseric/graphql-multipart-request-spec).
+// It can be restricted to an allow-list of trusted documents referenced by id, and sets
+// the Cache-Control header and caches whole responses following @cacheControl hints.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+)
+
+const (
+	ContentTypeJSON           = "application/json"
+	ContentTypeGraphQL        = "application/graphql"
+	ContentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	ContentTypeMultipart      = "multipart/form-data"
+)
+
+// RootObjectFn returns the root value for the execution of a request.
+type RootObjectFn func(ctx context.Context, r *http.Request) map[string]interface{}
+
+// Config options for creating a new Handler
+type Config struct {
+	Schema       *graphql.Schema
+	Pretty       bool
+	RootObjectFn RootObjectFn
+	Upload       UploadConfig
+	// TrustedDocuments restricts the handler to the documents of the allow-list, which
+	// requests reference by documentId.
+	TrustedDocuments *TrustedDocuments
+	// AllowUntrustedFn lets requests send a query despite TrustedDocuments when it
+	// returns true.
+	AllowUntrustedFn AllowUntrustedFn
+	// ResponseCache caches whole responses when its Store is set.
+	ResponseCache ResponseCacheConfig
+}
+
+// Handler is an http.Handler executing GraphQL requests against a schema.
+type Handler struct {
+	Schema *graphql.Schema
+
+	pretty           bool
+	rootObjectFn     RootObjectFn
+	upload           UploadConfig
+	trustedDocuments *TrustedDocuments
+	allowUntrustedFn AllowUntrustedFn
+	responseCache    ResponseCacheConfig
+}
+
+// New creates a new Handler, it panics if no schema is configured.
+func New(p *Config) *Handler {
+	if p == nil || p.Schema == nil {
+		panic("undefined GraphQL schema")
+	}
+	return &Handler{
+		Schema:           p.Schema,
+		pretty:           p.Pretty,
+		rootObjectFn:     p.RootObjectFn,
+		upload:           p.Upload,
+		trustedDocuments: p.TrustedDocuments,
+		allowUntrustedFn: p.AllowUntrustedFn,
+		responseCache:    p.ResponseCache,
+	}
+}
+
+// RequestOptions is a single GraphQL request as sent by a client.
+type RequestOptions struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	// DocumentID references a trusted document instead of sending the query.
+	DocumentID string `json:"documentId"`
+}
+
+// requestError is an error caused by a malformed or oversized request body.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(err error) error {
+	return &requestError{status: http.StatusBadRequest, err: err}
+}
+
+func getFromForm(values url.Values) (*RequestOptions, error) {
+	opts := &RequestOptions{
+		Query:         values.Get("query"),
+		OperationName: values.Get("operationName"),
+		DocumentID:    values.Get("documentId"),
+	}
+	if variables := values.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &opts.Variables); err != nil {
+			return nil, badRequest(err)
+		}
+	}
+	return opts, nil
+}
+
+// NewRequestOptions parses the GraphQL request carried by r. It does not handle multipart
+// requests, which need the upload configuration of a Handler.
+func NewRequestOptions(r *http.Request) (*RequestOptions, error) {
+	if r.Method == http.MethodGet {
+		return getFromForm(r.URL.Query())
+	}
+	if r.Method != http.MethodPost {
+		return nil, &requestError{status: http.StatusMethodNotAllowed, err: errors.New("GraphQL only supports GET and POST requests")}
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch contentType {
+	case ContentTypeGraphQL:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, badRequest(err)
+		}
+		return &RequestOptions{Query: string(body)}, nil
+	case ContentTypeFormURLEncoded:
+		if err := r.ParseForm(); err != nil {
+			return nil, badRequest(err)
+		}
+		return getFromForm(r.PostForm)
+	default:
+		var opts RequestOptions
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			return nil, badRequest(err)
+		}
+		return &opts, nil
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var (
+		opts  []*RequestOptions
+		batch bool
+		err   error
+	)
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method == http.MethodPost && contentType == ContentTypeMultipart {
+		var files *uploadedFiles
+		opts, batch, files, err = parseMultipart(r, h.upload, func(opts []*RequestOptions) error {
+			for _, opt := range opts {
+				if _, err := h.resolveQuery(ctx, r, opt); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		defer files.cleanup()
+	} else {
+		var opt *RequestOptions
+		opt, err = NewRequestOptions(r)
+		opts = []*RequestOptions{opt}
+	}
+	if err != nil {
+		status := http.StatusBadRequest
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			status = reqErr.status
+		}
+		h.writeJSON(w, status, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	results := make([]*graphql.Result, len(opts))
+	policies := make([]responsePolicy, len(opts))
+	for i, opt := range opts {
+		query, err := h.resolveQuery(ctx, r, opt)
+		if err != nil {
+			results[i] = &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+			continue
+		}
+		results[i], policies[i] = h.execute(ctx, r, opt, query)
+	}
+	setCacheControl(w, results, policies)
+	if batch {
+		h.writeJSON(w, http.StatusOK, results)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, results[0])
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buff []byte
+	if h.pretty {
+		buff, _ = json.MarshalIndent(v, "", "\t")
+	} else {
+		buff, _ = json.Marshal(v)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buff)
+}
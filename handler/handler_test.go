@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/handler"
+)
+
+func helloSchema(t *testing.T) *graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "world"},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "hello " + p.Args["name"].(string), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func decodeResult(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	var result map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+	}
+	return result
+}
+
+func TestHandler(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: helloSchema(t)})
+	expected := map[string]interface{}{"hello": "hello gopher"}
+
+	tests := map[string]*http.Request{
+		"GET": httptest.NewRequest(http.MethodGet, "/graphql?"+url.Values{
+			"query":     {"query Q($name: String) { hello(name: $name) }"},
+			"variables": {`{"name": "gopher"}`},
+		}.Encode(), nil),
+		"POST json": func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(
+				`{"query": "query Q($name: String) { hello(name: $name) }", "variables": {"name": "gopher"}, "operationName": "Q"}`,
+			))
+			r.Header.Set("Content-Type", handler.ContentTypeJSON)
+			return r
+		}(),
+		"POST graphql": func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{ hello(name: "gopher") }`))
+			r.Header.Set("Content-Type", handler.ContentTypeGraphQL)
+			return r
+		}(),
+		"POST form": func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(url.Values{
+				"query": {`{ hello(name: "gopher") }`},
+			}.Encode()))
+			r.Header.Set("Content-Type", handler.ContentTypeFormURLEncoded)
+			return r
+		}(),
+	}
+	for name, r := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.Equal(t, expected, decodeResult(t, rec)["data"])
+		})
+	}
+}
+
+func TestHandler_BadRequest(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: helloSchema(t)})
+
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":`))
+	r.Header.Set("Content-Type", handler.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Len(t, decodeResult(t, rec)["errors"], 1)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/graphql", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestNew_PanicsWithoutSchema(t *testing.T) {
+	assert.Panics(t, func() {
+		handler.New(&handler.Config{})
+	})
+}
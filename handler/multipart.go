@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tailor-inc/graphql/scalars"
+)
+
+const (
+	// DefaultMaxFileSize is the default size limit of a single uploaded file.
+	DefaultMaxFileSize = 32 << 20
+	// DefaultMaxFiles is the default limit of files in one multipart request.
+	DefaultMaxFiles = 10
+	// DefaultMaxMemory is the default amount of file contents kept in memory per request.
+	DefaultMaxMemory = 10 << 20
+	// maxFieldSize limits the size of the operations and map fields.
+	maxFieldSize = 10 << 20
+)
+
+var (
+	// ErrFileTooLarge is returned when an uploaded file exceeds UploadConfig.MaxFileSize.
+	ErrFileTooLarge = errors.New("uploaded file exceeds the maximum file size")
+	// ErrTooManyFiles is returned when a request carries more than UploadConfig.MaxFiles files.
+	ErrTooManyFiles = errors.New("request exceeds the maximum number of files")
+)
+
+// UploadConfig limits the files accepted in graphql multipart requests. Zero values
+// fall back to the package defaults.
+type UploadConfig struct {
+	// MaxFileSize is the size limit of a single file in bytes.
+	MaxFileSize int64
+	// MaxFiles is the maximum number of files in a request.
+	MaxFiles int
+	// MaxMemory is the amount of file contents in bytes kept in memory for a request;
+	// files which do not fit are spilled to temporary files.
+	MaxMemory int64
+	// TempDir is the directory for spilled files, os.TempDir() when empty.
+	TempDir string
+}
+
+func (c UploadConfig) maxFileSize() int64 {
+	if c.MaxFileSize > 0 {
+		return c.MaxFileSize
+	}
+	return DefaultMaxFileSize
+}
+
+func (c UploadConfig) maxFiles() int {
+	if c.MaxFiles > 0 {
+		return c.MaxFiles
+	}
+	return DefaultMaxFiles
+}
+
+func (c UploadConfig) maxMemory() int64 {
+	if c.MaxMemory > 0 {
+		return c.MaxMemory
+	}
+	return DefaultMaxMemory
+}
+
+// uploadedFiles tracks the temporary files of a request so they can be removed once
+// the request has been executed.
+type uploadedFiles struct {
+	temps []*os.File
+}
+
+func (u *uploadedFiles) cleanup() {
+	if u == nil {
+		return
+	}
+	for _, f := range u.temps {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	u.temps = nil
+}
+
+func readField(part *multipart.Part, name string, v interface{}) error {
+	if part.FormName() != name {
+		return badRequest(fmt.Errorf("multipart field %q must precede the files, got %q", name, part.FormName()))
+	}
+	body, err := io.ReadAll(io.LimitReader(part, maxFieldSize+1))
+	if err != nil {
+		return badRequest(err)
+	}
+	if len(body) > maxFieldSize {
+		return &requestError{status: http.StatusRequestEntityTooLarge, err: fmt.Errorf("multipart field %q is too large", name)}
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return badRequest(fmt.Errorf("invalid multipart field %q: %v", name, err))
+	}
+	return nil
+}
+
+// parseMultipart parses a graphql multipart request, reading the "operations" and "map"
+// fields followed by the files and injecting each file at its mapped paths. The
+// operations are passed to check before any file is read, so that the files of rejected
+// requests are neither read nor stored.
+func parseMultipart(r *http.Request, config UploadConfig, check func([]*RequestOptions) error) ([]*RequestOptions, bool, *uploadedFiles, error) {
+	files := &uploadedFiles{}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, false, files, badRequest(err)
+	}
+
+	var operations interface{}
+	part, err := reader.NextPart()
+	if err != nil {
+		return nil, false, files, badRequest(fmt.Errorf("missing multipart field %q", "operations"))
+	}
+	if err := readField(part, "operations", &operations); err != nil {
+		return nil, false, files, err
+	}
+	opts, _, err := multipartOperations(operations)
+	if err != nil {
+		return nil, false, files, err
+	}
+	if err := check(opts); err != nil {
+		return nil, false, files, badRequest(err)
+	}
+	var pathMap map[string][]string
+	part, err = reader.NextPart()
+	if err != nil {
+		return nil, false, files, badRequest(fmt.Errorf("missing multipart field %q", "map"))
+	}
+	if err := readField(part, "map", &pathMap); err != nil {
+		return nil, false, files, err
+	}
+	if len(pathMap) > config.maxFiles() {
+		return nil, false, files, &requestError{status: http.StatusRequestEntityTooLarge, err: ErrTooManyFiles}
+	}
+
+	memory := config.maxMemory()
+	received := map[string]bool{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, files, badRequest(err)
+		}
+		paths, ok := pathMap[part.FormName()]
+		if !ok || received[part.FormName()] {
+			continue
+		}
+		received[part.FormName()] = true
+		file, err := files.read(part, config, &memory)
+		if err != nil {
+			return nil, false, files, err
+		}
+		for _, path := range paths {
+			if err := setPath(operations, strings.Split(path, "."), file); err != nil {
+				return nil, false, files, badRequest(err)
+			}
+		}
+	}
+	for key := range pathMap {
+		if !received[key] {
+			return nil, false, files, badRequest(fmt.Errorf("missing file for map entry %q", key))
+		}
+	}
+
+	opts, batch, err := multipartOperations(operations)
+	if err != nil {
+		return nil, false, files, err
+	}
+	return opts, batch, files, nil
+}
+
+// multipartOperations returns the request options of the "operations" field, and
+// whether they are a batch.
+func multipartOperations(operations interface{}) ([]*RequestOptions, bool, error) {
+	switch ops := operations.(type) {
+	case map[string]interface{}:
+		opt, err := asRequestOptions(ops)
+		if err != nil {
+			return nil, false, err
+		}
+		return []*RequestOptions{opt}, false, nil
+	case []interface{}:
+		opts := make([]*RequestOptions, 0, len(ops))
+		for _, op := range ops {
+			m, ok := op.(map[string]interface{})
+			if !ok {
+				return nil, false, badRequest(errors.New("invalid multipart field \"operations\": batched operation must be an object"))
+			}
+			opt, err := asRequestOptions(m)
+			if err != nil {
+				return nil, false, err
+			}
+			opts = append(opts, opt)
+		}
+		return opts, true, nil
+	default:
+		return nil, false, badRequest(errors.New("invalid multipart field \"operations\": must be an object or a list"))
+	}
+}
+
+// read stores the contents of part in memory while the request's memory budget allows
+// it and spills to a temporary file otherwise.
+func (u *uploadedFiles) read(part *multipart.Part, config UploadConfig, memory *int64) (*scalars.File, error) {
+	tooLarge := &requestError{status: http.StatusRequestEntityTooLarge, err: ErrFileTooLarge}
+	limit := config.maxFileSize()
+
+	var buff bytes.Buffer
+	n, err := io.CopyN(&buff, part, *memory+1)
+	if err != nil && err != io.EOF {
+		return nil, badRequest(err)
+	}
+	if n > limit {
+		return nil, tooLarge
+	}
+	file := &scalars.File{
+		Filename:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+	}
+	if n <= *memory {
+		*memory -= n
+		file.Size = n
+		file.Reader = bytes.NewReader(buff.Bytes())
+		return file, nil
+	}
+
+	tmp, err := os.CreateTemp(config.TempDir, "graphql-upload-")
+	if err != nil {
+		return nil, err
+	}
+	u.temps = append(u.temps, tmp)
+	if _, err := tmp.Write(buff.Bytes()); err != nil {
+		return nil, err
+	}
+	m, err := io.CopyN(tmp, part, limit-n+1)
+	if err != nil && err != io.EOF {
+		return nil, badRequest(err)
+	}
+	if n+m > limit {
+		return nil, tooLarge
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	file.Size = n + m
+	file.Reader = tmp
+	return file, nil
+}
+
+// setPath sets value at the dot separated object path inside operations, e.g.
+// "variables.files.0" or "1.variables.file" for batched operations.
+func setPath(operations interface{}, path []string, value interface{}) error {
+	current := operations
+	for i, key := range path {
+		last := i == len(path)-1
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if last {
+				node[key] = value
+				return nil
+			}
+			current = node[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return fmt.Errorf("invalid map path %q", strings.Join(path, "."))
+			}
+			if last {
+				node[idx] = value
+				return nil
+			}
+			current = node[idx]
+		default:
+			return fmt.Errorf("invalid map path %q", strings.Join(path, "."))
+		}
+	}
+	return fmt.Errorf("invalid map path %q", strings.Join(path, "."))
+}
+
+func asRequestOptions(op map[string]interface{}) (*RequestOptions, error) {
+	opt := &RequestOptions{}
+	if query, ok := op["query"].(string); ok {
+		opt.Query = query
+	}
+	if name, ok := op["operationName"].(string); ok {
+		opt.OperationName = name
+	}
+	if id, ok := op["documentId"].(string); ok {
+		opt.DocumentID = id
+	}
+	switch variables := op["variables"].(type) {
+	case map[string]interface{}:
+		opt.Variables = variables
+	case nil:
+	default:
+		return nil, badRequest(errors.New("invalid multipart field \"operations\": variables must be an object"))
+	}
+	return opt, nil
+}
@@ -0,0 +1,188 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/handler"
+	"github.com/tailor-inc/graphql/scalars"
+)
+
+type uploadFile struct {
+	field, name, content string
+}
+
+func multipartRequest(t *testing.T, operations, pathMap string, files ...uploadFile) *http.Request {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	assert.NoError(t, w.WriteField("operations", operations))
+	assert.NoError(t, w.WriteField("map", pathMap))
+	for _, f := range files {
+		fw, err := w.CreateFormFile(f.field, f.name)
+		assert.NoError(t, err)
+		_, err = fw.Write([]byte(f.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	r := httptest.NewRequest(http.MethodPost, "/graphql", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+// uploadSchema describes every received file as "name:content:storage".
+func uploadSchema(t *testing.T) *graphql.Schema {
+	describe := func(f *scalars.File) string {
+		_, err := f.Seek(0, io.SeekStart)
+		assert.NoError(t, err)
+		content, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		_, spilled := f.Reader.(*os.File)
+		return f.Filename + ":" + string(content) + ":" + map[bool]string{true: "disk", false: "memory"}[spilled]
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"ok": &graphql.Field{Type: graphql.Boolean}},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"single": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"file": &graphql.ArgumentConfig{Type: graphql.NewNonNull(scalars.Upload)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return describe(p.Args["file"].(*scalars.File)), nil
+					},
+				},
+				"multiple": &graphql.Field{
+					Type: graphql.NewList(graphql.String),
+					Args: graphql.FieldConfigArgument{
+						"files": &graphql.ArgumentConfig{Type: graphql.NewList(scalars.Upload)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						var out []string
+						for _, f := range p.Args["files"].([]interface{}) {
+							out = append(out, describe(f.(*scalars.File)))
+						}
+						return out, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+func TestHandler_Multipart(t *testing.T) {
+	tempDir := t.TempDir()
+	h := handler.New(&handler.Config{
+		Schema: uploadSchema(t),
+		Upload: handler.UploadConfig{MaxMemory: 8, TempDir: tempDir},
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, multipartRequest(t,
+			`{"query": "mutation ($file: Upload!) { single(file: $file) }", "variables": {"file": null}}`,
+			`{"0": ["variables.file"]}`,
+			uploadFile{"0", "a.txt", "alpha"},
+		))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]interface{}{"single": "a.txt:alpha:memory"}, decodeResult(t, rec)["data"])
+	})
+
+	t.Run("file list spilled to disk", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, multipartRequest(t,
+			`{"query": "mutation ($files: [Upload]) { multiple(files: $files) }", "variables": {"files": [null, null]}}`,
+			`{"0": ["variables.files.0"], "1": ["variables.files.1"]}`,
+			uploadFile{"0", "a.txt", "alpha"},
+			uploadFile{"1", "b.txt", "bravo"},
+		))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]interface{}{
+			"multiple": []interface{}{"a.txt:alpha:memory", "b.txt:bravo:disk"},
+		}, decodeResult(t, rec)["data"])
+
+		entries, err := os.ReadDir(tempDir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries, "temporary files must be removed after the request")
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, multipartRequest(t,
+			`[{"query": "mutation ($file: Upload!) { single(file: $file) }", "variables": {"file": null}},
+			  {"query": "mutation ($file: Upload!) { single(file: $file) }", "variables": {"file": null}}]`,
+			`{"0": ["0.variables.file", "1.variables.file"]}`,
+			uploadFile{"0", "a.txt", "alpha"},
+		))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `[{"data":{"single":"a.txt:alpha:memory"}},{"data":{"single":"a.txt:alpha:memory"}}]`)
+	})
+}
+
+func TestHandler_MultipartLimits(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: uploadSchema(t),
+		Upload: handler.UploadConfig{MaxFileSize: 4, MaxFiles: 1, MaxMemory: 2, TempDir: t.TempDir()},
+	})
+	query := `{"query": "mutation ($files: [Upload]) { multiple(files: $files) }", "variables": {"files": [null, null]}}`
+
+	tests := map[string]struct {
+		req    *http.Request
+		status int
+	}{
+		"file too large": {
+			req:    multipartRequest(t, query, `{"0": ["variables.files.0"]}`, uploadFile{"0", "a.txt", "alpha"}),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		"too many files": {
+			req: multipartRequest(t, query, `{"0": ["variables.files.0"], "1": ["variables.files.1"]}`,
+				uploadFile{"0", "a.txt", "a"}, uploadFile{"1", "b.txt", "b"}),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		"missing file": {
+			req:    multipartRequest(t, query, `{"0": ["variables.files.0"]}`),
+			status: http.StatusBadRequest,
+		},
+		"invalid path": {
+			req:    multipartRequest(t, query, `{"0": ["variables.files.5"]}`, uploadFile{"0", "a.txt", "a"}),
+			status: http.StatusBadRequest,
+		},
+		"map before operations": {
+			req: func() *http.Request {
+				var body bytes.Buffer
+				w := multipart.NewWriter(&body)
+				w.WriteField("map", `{}`)
+				w.WriteField("operations", query)
+				w.Close()
+				r := httptest.NewRequest(http.MethodPost, "/graphql", &body)
+				r.Header.Set("Content-Type", w.FormDataContentType())
+				return r
+			}(),
+			status: http.StatusBadRequest,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, test.req)
+			assert.Equal(t, test.status, rec.Code)
+			assert.Len(t, decodeResult(t, rec)["errors"], 1)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// ResponseCacheStore stores the cached responses of a Handler.
+type ResponseCacheStore interface {
+	// Get returns the value stored with key, unless it expired.
+	Get(ctx context.Context, key string) ([]byte, bool)
+	// Set stores value with key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+}
+
+// SessionIDFn returns the session of the user sending r, or an empty string for an
+// anonymous request. Private responses are only cached for requests with a session.
+type SessionIDFn func(ctx context.Context, r *http.Request) string
+
+// VaryFn returns what the response to r depends on besides its operation and variables,
+// as the roles deciding which members of the schema are visible or the root object.
+// Requests with different values never share cached responses.
+type VaryFn func(ctx context.Context, r *http.Request) string
+
+// ResponseCacheConfig configures the cache of whole responses of a Handler. Responses
+// are cached by query operation and variables, for the max age computed by the
+// graphql.CacheControlExtension of the schema.
+//
+// The responses of a schema with a graphql.VisibilityFn, or of a Handler with a
+// RootObjectFn, depend on the request. They are only cached when VaryFn is set, and
+// then separately for each of its values.
+type ResponseCacheConfig struct {
+	Store       ResponseCacheStore
+	SessionIDFn SessionIDFn
+	VaryFn      VaryFn
+}
+
+// MemoryResponseCacheStore is a ResponseCacheStore keeping the responses in memory.
+type MemoryResponseCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryResponseCacheStore creates an empty in-memory store.
+func NewMemoryResponseCacheStore() *MemoryResponseCacheStore {
+	return &MemoryResponseCacheStore{entries: map[string]memoryCacheEntry{}}
+}
+
+// Get implements ResponseCacheStore.
+func (s *MemoryResponseCacheStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(s.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set implements ResponseCacheStore.
+func (s *MemoryResponseCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryCacheEntry{value: value, expires: time.Now().Add(ttl)}
+}
+
+// cachedResponse is the value stored in a ResponseCacheStore. The data is decoded as
+// ordered maps, so that its fields stay in selection order.
+type cachedResponse struct {
+	Data       *graphql.OrderedMap    `json:"data"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+	Scope      graphql.CacheScope     `json:"scope"`
+	Expires    time.Time              `json:"expires"`
+}
+
+// responsePolicy is the cache policy of the response to a single operation.
+type responsePolicy struct {
+	enabled   bool
+	cacheable bool
+	policy    graphql.CachePolicy
+}
+
+// execute runs the operation of opts, through the response cache when it is configured.
+func (h *Handler) execute(ctx context.Context, r *http.Request, opts *RequestOptions, query string) (*graphql.Result, responsePolicy) {
+	ctx, policy := graphql.WithResponseCachePolicy(ctx)
+
+	var key, sessionID string
+	if h.cachesResponses() {
+		document, err := parser.Parse(parser.ParseParams{Source: query})
+		if err == nil {
+			key = responseCacheKey(document, opts)
+		}
+		if key != "" && h.responseCache.VaryFn != nil {
+			key = varyCacheKey(key, h.responseCache.VaryFn(ctx, r))
+		}
+		if h.responseCache.SessionIDFn != nil {
+			sessionID = h.responseCache.SessionIDFn(ctx, r)
+		}
+		// Cached responses are only served to valid documents, which the schema may
+		// have stopped accepting since they were stored.
+		if key != "" {
+			result, cached, ok := h.cachedResponse(ctx, key, sessionID)
+			if ok && graphql.ValidateDocumentWithContext(ctx, h.Schema, document, nil).IsValid {
+				return result, cached
+			}
+		}
+	}
+
+	params := graphql.Params{
+		Schema:         *h.Schema,
+		RequestString:  query,
+		VariableValues: opts.Variables,
+		OperationName:  opts.OperationName,
+		Context:        ctx,
+	}
+	if h.rootObjectFn != nil {
+		params.RootObject = h.rootObjectFn(ctx, r)
+	}
+	result := graphql.Do(params)
+
+	computed := responsePolicy{enabled: policy.Enabled()}
+	computed.policy, computed.cacheable = policy.PolicyIfCacheable()
+	if key != "" && computed.cacheable && !result.HasErrors() {
+		h.storeResponse(ctx, key, sessionID, result, computed.policy)
+	}
+	return result, computed
+}
+
+// cachesResponses reports whether responses are cached, which they are not when they
+// depend on the request in ways the cache key does not capture.
+func (h *Handler) cachesResponses() bool {
+	if h.responseCache.Store == nil {
+		return false
+	}
+	return h.responseCache.VaryFn != nil || (!h.Schema.HasVisibility() && h.rootObjectFn == nil)
+}
+
+// cachedResponse looks up the response stored with key, the private one of the session
+// first.
+func (h *Handler) cachedResponse(ctx context.Context, key, sessionID string) (*graphql.Result, responsePolicy, bool) {
+	keys := []string{publicCacheKey(key)}
+	if sessionID != "" {
+		keys = []string{privateCacheKey(key, sessionID), publicCacheKey(key)}
+	}
+	for _, key := range keys {
+		value, ok := h.responseCache.Store.Get(ctx, key)
+		if !ok {
+			continue
+		}
+		var cached cachedResponse
+		if err := json.Unmarshal(value, &cached); err != nil {
+			continue
+		}
+		maxAge := int(math.Ceil(time.Until(cached.Expires).Seconds()))
+		if maxAge <= 0 {
+			continue
+		}
+		result := &graphql.Result{Data: cached.Data, Extensions: cached.Extensions}
+		return result, responsePolicy{
+			enabled:   true,
+			cacheable: true,
+			policy:    graphql.CachePolicy{MaxAge: maxAge, Scope: cached.Scope},
+		}, true
+	}
+	return nil, responsePolicy{}, false
+}
+
+func (h *Handler) storeResponse(ctx context.Context, key, sessionID string, result *graphql.Result, policy graphql.CachePolicy) {
+	if policy.Scope == graphql.CacheScopePrivate {
+		if sessionID == "" {
+			return
+		}
+		key = privateCacheKey(key, sessionID)
+	} else {
+		key = publicCacheKey(key)
+	}
+	ttl := time.Duration(policy.MaxAge) * time.Second
+	data, ok := result.Data.(*graphql.OrderedMap)
+	if !ok {
+		return
+	}
+	value, err := json.Marshal(&cachedResponse{
+		Data:       data,
+		Extensions: result.Extensions,
+		Scope:      policy.Scope,
+		Expires:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return
+	}
+	h.responseCache.Store.Set(ctx, key, value, ttl)
+}
+
+func publicCacheKey(key string) string {
+	return "public:" + key
+}
+
+func privateCacheKey(key, sessionID string) string {
+	return fmt.Sprintf("private:%s:%s", sessionID, key)
+}
+
+func varyCacheKey(key, vary string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s", key, vary)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseCacheKey returns the key of the response to the operation of opts in
+// document, which is the same for operations differing only in formatting. It is empty
+// for mutations and subscriptions, whose responses are never cached.
+func responseCacheKey(document *ast.Document, opts *RequestOptions) string {
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		definition, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if opts.OperationName == "" || (definition.Name != nil && definition.Name.Value == opts.OperationName) {
+			if operation != nil {
+				return ""
+			}
+			operation = definition
+		}
+	}
+	if operation == nil || operation.Operation != ast.OperationTypeQuery {
+		return ""
+	}
+	variables, err := json.Marshal(opts.Variables)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s", printer.Print(document), opts.OperationName, variables)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// setCacheControl sets the Cache-Control header of the response to the most restrictive
+// policy of its operations, or to no-store when any of them is not cacheable. The header
+// is left unset unless the schema computes cache policies.
+func setCacheControl(w http.ResponseWriter, results []*graphql.Result, policies []responsePolicy) {
+	enabled := false
+	for _, policy := range policies {
+		enabled = enabled || policy.enabled
+	}
+	if !enabled {
+		return
+	}
+	var overall graphql.CachePolicy
+	for i, policy := range policies {
+		if !policy.cacheable || results[i].HasErrors() {
+			w.Header().Set("Cache-Control", "no-store")
+			return
+		}
+		if overall.MaxAge == 0 || policy.policy.MaxAge < overall.MaxAge {
+			overall.MaxAge = policy.policy.MaxAge
+		}
+		if overall.Scope != graphql.CacheScopePrivate {
+			overall.Scope = policy.policy.Scope
+		}
+	}
+	w.Header().Set("Cache-Control", overall.HTTPHeader())
+}
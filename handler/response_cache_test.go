@@ -0,0 +1,229 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/handler"
+)
+
+// cachedSchema counts the resolutions of its fields in calls. Query.counter is cached
+// publicly for a minute and Query.me privately for ten seconds. Query.secret is cached
+// publicly for a minute and described as internal, for visibility to hide it.
+func cachedSchema(t *testing.T, calls *int, visibility graphql.VisibilityFn) *graphql.Schema {
+	cacheControl := func(args ...graphql.ObjectDirectiveArg) graphql.FieldDirectives {
+		return graphql.FieldDirectives{{Directive: graphql.CacheControlDirective, Args: args}}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"counter": &graphql.Field{
+					Type:       graphql.Int,
+					Directives: cacheControl(graphql.ObjectDirectiveArg{Name: "maxAge", Value: 60}),
+					Args: graphql.FieldConfigArgument{
+						"step": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						*calls++
+						return *calls * p.Args["step"].(int), nil
+					},
+				},
+				"me": &graphql.Field{
+					Type: graphql.String,
+					Directives: cacheControl(
+						graphql.ObjectDirectiveArg{Name: "maxAge", Value: 10},
+						graphql.ObjectDirectiveArg{Name: "scope", Value: "PRIVATE"},
+					),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						*calls++
+						return "me", nil
+					},
+				},
+				"secret": &graphql.Field{
+					Type:        graphql.String,
+					Description: "internal",
+					Directives:  cacheControl(graphql.ObjectDirectiveArg{Name: "maxAge", Value: 60}),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						*calls++
+						return "secret", nil
+					},
+				},
+				"now": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						*calls++
+						return *calls, nil
+					},
+				},
+			},
+		}),
+		Types:      []graphql.Type{graphql.CacheControlScopeEnum},
+		Directives: append([]*graphql.Directive{graphql.CacheControlDirective}, graphql.SpecifiedDirectives...),
+		Extensions: []graphql.Extension{&graphql.CacheControlExtension{}},
+		Visibility: visibility,
+	})
+	require.NoError(t, err)
+	return &schema
+}
+
+func TestHandler_CacheControlHeader(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: cachedSchema(t, new(int), nil)})
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?query="+query, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+
+	assert.Equal(t, "max-age=60, public", get("{counter}").Header().Get("Cache-Control"))
+	assert.Equal(t, "max-age=10, private", get("{counter,me}").Header().Get("Cache-Control"))
+	assert.Equal(t, "no-store", get("{counter,now}").Header().Get("Cache-Control"))
+	assert.Equal(t, "no-store", get("{unknown}").Header().Get("Cache-Control"))
+
+	rec := httptest.NewRecorder()
+	handler.New(&handler.Config{Schema: helloSchema(t)}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?query={hello}", nil))
+	assert.Empty(t, rec.Header().Get("Cache-Control"))
+}
+
+func TestHandler_ResponseCache(t *testing.T) {
+	calls := 0
+	h := handler.New(&handler.Config{
+		Schema: cachedSchema(t, &calls, nil),
+		ResponseCache: handler.ResponseCacheConfig{
+			Store: handler.NewMemoryResponseCacheStore(),
+			SessionIDFn: func(ctx context.Context, r *http.Request) string {
+				return r.Header.Get("X-Session")
+			},
+		},
+	})
+	post := func(body, session string) (map[string]interface{}, string) {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		r.Header.Set("Content-Type", handler.ContentTypeJSON)
+		if session != "" {
+			r.Header.Set("X-Session", session)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return decodeResult(t, rec), rec.Header().Get("Cache-Control")
+	}
+
+	result, header := post(`{"query": "{ counter }"}`, "")
+	assert.Equal(t, map[string]interface{}{"counter": float64(1)}, result["data"])
+	assert.Equal(t, "max-age=60, public", header)
+
+	// The same operation, formatted differently, is served from the cache.
+	result, header = post(`{"query": "query {\n  counter\n}"}`, "alice")
+	assert.Equal(t, map[string]interface{}{"counter": float64(1)}, result["data"])
+	assert.Equal(t, "max-age=60, public", header)
+	assert.Equal(t, 1, calls)
+
+	// Other variables are another entry.
+	result, _ = post(`{"query": "query ($step: Int) { counter(step: $step) }", "variables": {"step": 10}}`, "")
+	assert.Equal(t, map[string]interface{}{"counter": float64(20)}, result["data"])
+	assert.Equal(t, 2, calls)
+
+	// Private responses are cached per session, and never without one.
+	post(`{"query": "{ me }"}`, "alice")
+	post(`{"query": "{ me }"}`, "alice")
+	assert.Equal(t, 3, calls)
+	post(`{"query": "{ me }"}`, "bob")
+	post(`{"query": "{ me }"}`, "")
+	post(`{"query": "{ me }"}`, "")
+	assert.Equal(t, 6, calls)
+
+	// Uncacheable responses are not stored.
+	post(`{"query": "{ now }"}`, "")
+	_, header = post(`{"query": "{ now }"}`, "")
+	assert.Equal(t, "no-store", header)
+	assert.Equal(t, 8, calls)
+
+	// Cached responses keep their fields in selection order.
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/graphql?query={b:counter,a:counter}", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		assert.Equal(t, `{"data":{"b":9,"a":10}}`, rec.Body.String())
+	}
+	assert.Equal(t, 10, calls)
+}
+
+type staffKey struct{}
+
+func TestHandler_ResponseCacheVaries(t *testing.T) {
+	calls := 0
+	schema := cachedSchema(t, &calls, func(ctx context.Context, member interface{}) bool {
+		field, ok := member.(*graphql.FieldDefinition)
+		return !ok || field.Description != "internal" || ctx.Value(staffKey{}) != nil
+	})
+	get := func(h *handler.Handler, query string, staff bool) string {
+		r := httptest.NewRequest(http.MethodGet, "/graphql?query="+query, nil)
+		if staff {
+			r = r.WithContext(context.WithValue(r.Context(), staffKey{}, true))
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+	staffOnly := func(ctx context.Context, r *http.Request) string {
+		if ctx.Value(staffKey{}) != nil {
+			return "staff"
+		}
+		return ""
+	}
+
+	// The responses of a schema with visibility are not cached without a VaryFn.
+	h := handler.New(&handler.Config{
+		Schema:        schema,
+		ResponseCache: handler.ResponseCacheConfig{Store: handler.NewMemoryResponseCacheStore()},
+	})
+	get(h, "{counter}", false)
+	get(h, "{counter}", false)
+	assert.Equal(t, 2, calls)
+
+	// With a VaryFn, they are cached for each of its values.
+	h = handler.New(&handler.Config{
+		Schema:        schema,
+		ResponseCache: handler.ResponseCacheConfig{Store: handler.NewMemoryResponseCacheStore(), VaryFn: staffOnly},
+	})
+	assert.Equal(t, `{"data":{"counter":3}}`, get(h, "{counter}", true))
+	assert.Equal(t, `{"data":{"counter":3}}`, get(h, "{counter}", true))
+	assert.Equal(t, `{"data":{"counter":4}}`, get(h, "{counter}", false))
+	assert.Equal(t, `{"data":{"counter":4}}`, get(h, "{counter}", false))
+	assert.Equal(t, 4, calls)
+
+	// Cached responses are only served to documents which are valid for the request.
+	h = handler.New(&handler.Config{
+		Schema: schema,
+		ResponseCache: handler.ResponseCacheConfig{
+			Store:  handler.NewMemoryResponseCacheStore(),
+			VaryFn: func(ctx context.Context, r *http.Request) string { return "" },
+		},
+	})
+	assert.Equal(t, `{"data":{"secret":"secret"}}`, get(h, "{secret}", true))
+	assert.Contains(t, get(h, "{secret}", false), `Cannot query field \"secret\" on type \"Query\".`)
+	assert.Equal(t, 5, calls)
+
+	// The responses of a Handler with a RootObjectFn are not cached without a VaryFn.
+	calls = 0
+	h = handler.New(&handler.Config{
+		Schema: cachedSchema(t, &calls, nil),
+		RootObjectFn: func(ctx context.Context, r *http.Request) map[string]interface{} {
+			return map[string]interface{}{}
+		},
+		ResponseCache: handler.ResponseCacheConfig{Store: handler.NewMemoryResponseCacheStore()},
+	})
+	get(h, "{counter}", false)
+	get(h, "{counter}", false)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tailor-inc/graphql/language/parser"
+	"github.com/tailor-inc/graphql/language/source"
+)
+
+var (
+	// ErrUntrustedDocument is returned for a request sending a query instead of the id
+	// of a trusted document.
+	ErrUntrustedDocument = errors.New("only trusted documents may be executed, send a documentId instead of a query")
+	// ErrUnknownDocument is returned for a request referencing an unregistered document id.
+	ErrUnknownDocument = errors.New("unknown trusted document")
+	// ErrDocumentIDNotSupported is returned for a request with a document id when the
+	// handler has no trusted documents.
+	ErrDocumentIDNotSupported = errors.New("documentId is not supported by this server")
+)
+
+// AllowUntrustedFn reports whether a request may execute a query which is not a
+// trusted document, for example because it comes from internal staff.
+type AllowUntrustedFn func(ctx context.Context, r *http.Request) bool
+
+// TrustedDocuments is an allow-list of GraphQL documents by id. With trusted documents
+// configured, a Handler only executes the documents referenced by the documentId of
+// requests.
+type TrustedDocuments struct {
+	documents map[string]string
+}
+
+// NewTrustedDocuments creates the allow-list of documents, keyed by id. Every document
+// must parse, so that mistakes in a manifest are found at startup.
+func NewTrustedDocuments(documents map[string]string) (*TrustedDocuments, error) {
+	trusted := &TrustedDocuments{documents: make(map[string]string, len(documents))}
+	for id, query := range documents {
+		if id == "" {
+			return nil, errors.New("trusted document without an id")
+		}
+		_, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+			Body: []byte(query),
+			Name: id,
+		})})
+		if err != nil {
+			return nil, fmt.Errorf("trusted document %q: %w", id, err)
+		}
+		trusted.documents[id] = query
+	}
+	return trusted, nil
+}
+
+// persistedQueryManifest is the manifest format of Apollo persisted queries.
+type persistedQueryManifest struct {
+	Operations []struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadTrustedDocumentsManifest reads a JSON manifest of trusted documents. It is either
+// an object mapping ids to query texts, or an Apollo persisted query manifest with an
+// "operations" list of ids and bodies.
+func LoadTrustedDocumentsManifest(r io.Reader) (*TrustedDocuments, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("invalid trusted documents manifest: %w", err)
+	}
+
+	documents := map[string]string{}
+	if _, ok := raw["operations"]; ok {
+		var manifest persistedQueryManifest
+		if err := json.Unmarshal(body, &manifest); err != nil {
+			return nil, fmt.Errorf("invalid trusted documents manifest: %w", err)
+		}
+		for _, operation := range manifest.Operations {
+			documents[operation.ID] = operation.Body
+		}
+		return NewTrustedDocuments(documents)
+	}
+	for id, value := range raw {
+		var query string
+		if err := json.Unmarshal(value, &query); err != nil {
+			return nil, fmt.Errorf("invalid trusted documents manifest, document %q: %w", id, err)
+		}
+		documents[id] = query
+	}
+	return NewTrustedDocuments(documents)
+}
+
+// LoadTrustedDocumentsDir reads the trusted documents from the .graphql files of dir.
+// The id of a document is the name of its file without the extension.
+func LoadTrustedDocumentsDir(dir string) (*TrustedDocuments, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	documents := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".graphql" {
+			continue
+		}
+		body, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		documents[strings.TrimSuffix(entry.Name(), ".graphql")] = string(body)
+	}
+	return NewTrustedDocuments(documents)
+}
+
+// Get returns the document registered with id.
+func (t *TrustedDocuments) Get(id string) (string, bool) {
+	query, ok := t.documents[id]
+	return query, ok
+}
+
+// Len returns the number of trusted documents.
+func (t *TrustedDocuments) Len() int {
+	return len(t.documents)
+}
+
+// resolveQuery returns the query to execute for opts, enforcing the trusted documents
+// of the handler.
+func (h *Handler) resolveQuery(ctx context.Context, r *http.Request, opts *RequestOptions) (string, error) {
+	if opts.DocumentID == "" {
+		if h.trustedDocuments != nil && (h.allowUntrustedFn == nil || !h.allowUntrustedFn(ctx, r)) {
+			return "", ErrUntrustedDocument
+		}
+		return opts.Query, nil
+	}
+	if h.trustedDocuments == nil {
+		return "", ErrDocumentIDNotSupported
+	}
+	query, ok := h.trustedDocuments.Get(opts.DocumentID)
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownDocument, opts.DocumentID)
+	}
+	if opts.Query != "" && opts.Query != query {
+		return "", fmt.Errorf("query does not match the trusted document %q", opts.DocumentID)
+	}
+	return query, nil
+}
@@ -0,0 +1,181 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tailor-inc/graphql/handler"
+)
+
+func TestHandler_TrustedDocuments(t *testing.T) {
+	trusted, err := handler.NewTrustedDocuments(map[string]string{
+		"hello": "query Hello($name: String) { hello(name: $name) }",
+	})
+	require.NoError(t, err)
+	h := handler.New(&handler.Config{
+		Schema:           helloSchema(t),
+		TrustedDocuments: trusted,
+		AllowUntrustedFn: func(ctx context.Context, r *http.Request) bool {
+			return r.Header.Get("X-Staff") == "true"
+		},
+	})
+	post := func(body string, staff bool) map[string]interface{} {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		r.Header.Set("Content-Type", handler.ContentTypeJSON)
+		if staff {
+			r.Header.Set("X-Staff", "true")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		return decodeResult(t, rec)
+	}
+
+	result := post(`{"documentId": "hello", "variables": {"name": "gopher"}}`, false)
+	assert.Equal(t, map[string]interface{}{"hello": "hello gopher"}, result["data"])
+
+	result = post(`{"query": "{ hello }"}`, false)
+	assert.Nil(t, result["data"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"message":   handler.ErrUntrustedDocument.Error(),
+		"locations": []interface{}{},
+	}}, result["errors"])
+
+	result = post(`{"query": "{ hello }"}`, true)
+	assert.Equal(t, map[string]interface{}{"hello": "hello world"}, result["data"])
+
+	result = post(`{"documentId": "missing"}`, true)
+	assert.Equal(t, `unknown trusted document "missing"`, result["errors"].([]interface{})[0].(map[string]interface{})["message"])
+
+	result = post(`{"documentId": "hello", "query": "{ hello }"}`, false)
+	assert.Equal(t, `query does not match the trusted document "hello"`, result["errors"].([]interface{})[0].(map[string]interface{})["message"])
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?"+url.Values{
+		"documentId": {"hello"},
+		"variables":  {`{"name": "GET"}`},
+	}.Encode(), nil))
+	assert.Equal(t, map[string]interface{}{"hello": "hello GET"}, decodeResult(t, rec)["data"])
+}
+
+func TestHandler_TrustedDocumentsMultipart(t *testing.T) {
+	trusted, err := handler.NewTrustedDocuments(map[string]string{
+		"upload": "mutation Upload($file: Upload!) { single(file: $file) }",
+	})
+	require.NoError(t, err)
+	h := handler.New(&handler.Config{
+		Schema:           uploadSchema(t),
+		TrustedDocuments: trusted,
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, multipartRequest(t,
+		`{"documentId": "upload", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		uploadFile{"0", "a.txt", "alpha"},
+	))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]interface{}{"single": "a.txt:alpha:memory"}, decodeResult(t, rec)["data"])
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, multipartRequest(t,
+		`{"query": "mutation ($file: Upload!) { single(file: $file) }", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		uploadFile{"0", "a.txt", "alpha"},
+	))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.ErrUntrustedDocument.Error(),
+		decodeResult(t, rec)["errors"].([]interface{})[0].(map[string]interface{})["message"])
+
+	// The files of untrusted documents are not read.
+	r := multipartRequest(t,
+		`{"query": "mutation ($file: Upload!) { single(file: $file) }", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		uploadFile{"0", "a.txt", strings.Repeat("a", 1<<20)},
+	)
+	body := &countingReader{r: r.Body}
+	r.Body = io.NopCloser(body)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Less(t, body.n, 1<<16)
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestHandler_DocumentIDWithoutTrustedDocuments(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: helloSchema(t)})
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"documentId": "hello"}`))
+	r.Header.Set("Content-Type", handler.ContentTypeJSON)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	assert.Equal(t, handler.ErrDocumentIDNotSupported.Error(),
+		decodeResult(t, rec)["errors"].([]interface{})[0].(map[string]interface{})["message"])
+}
+
+func TestLoadTrustedDocumentsManifest(t *testing.T) {
+	trusted, err := handler.LoadTrustedDocumentsManifest(strings.NewReader(`{
+		"a": "{ hello }",
+		"b": "query B { hello(name: \"b\") }"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 2, trusted.Len())
+	query, ok := trusted.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, `query B { hello(name: "b") }`, query)
+
+	trusted, err = handler.LoadTrustedDocumentsManifest(strings.NewReader(`{
+		"format": "apollo-persisted-query-manifest",
+		"version": 1,
+		"operations": [{"id": "abc", "name": "A", "type": "query", "body": "query A { hello }"}]
+	}`))
+	require.NoError(t, err)
+	query, ok = trusted.Get("abc")
+	assert.True(t, ok)
+	assert.Equal(t, "query A { hello }", query)
+
+	_, err = handler.LoadTrustedDocumentsManifest(strings.NewReader(`{"broken": "{ hello "}`))
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), `trusted document "broken": Syntax Error broken (1:9)`), err.Error())
+	}
+
+	_, err = handler.LoadTrustedDocumentsManifest(strings.NewReader(`[]`))
+	assert.Error(t, err)
+}
+
+func TestLoadTrustedDocumentsDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hello.graphql"), []byte("{ hello }"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a document"), 0o600))
+
+	trusted, err := handler.LoadTrustedDocumentsDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trusted.Len())
+	query, ok := trusted.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, "{ hello }", query)
+
+	_, err = handler.LoadTrustedDocumentsDir(filepath.Join(dir, "missing"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -6,7 +6,6 @@ import (
 	"sort"
 
 	"github.com/tailor-inc/graphql/language/ast"
-	"github.com/tailor-inc/graphql/language/printer"
 )
 
 const (
@@ -73,7 +72,7 @@ func init() {
 			"INTERFACE": &EnumValueConfig{
 				Value: TypeKindInterface,
 				Description: "Indicates this type is an interface. " +
-					"`fields` and `possibleTypes` are valid fields.",
+					"`fields`, `interfaces`, and `possibleTypes` are valid fields.",
 			},
 			"UNION": &EnumValueConfig{
 				Value: TypeKindUnion,
@@ -226,6 +225,15 @@ func init() {
 			"description": &Field{
 				Type: String,
 			},
+			"specifiedByURL": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if scalar, ok := p.Source.(*Scalar); ok && scalar.SpecifiedByURL() != "" {
+						return scalar.SpecifiedByURL(), nil
+					}
+					return nil, nil
+				},
+			},
 			"fields":        &Field{},
 			"interfaces":    &Field{},
 			"possibleTypes": &Field{},
@@ -256,21 +264,25 @@ func init() {
 					"input value.",
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if inputVal, ok := p.Source.(*Argument); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						if isNullish(inputVal.DefaultValue) {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+						return printDefaultValue(inputVal.DefaultValue, inputVal.Type), nil
 					}
 					if inputVal, ok := p.Source.(*InputObjectField); ok {
-						if inputVal.DefaultValue == nil {
-							return nil, nil
-						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
-						return printer.Print(astVal), nil
+						return printDefaultValue(inputVal.DefaultValue, inputVal.Type), nil
+					}
+					return nil, nil
+				},
+			},
+			"isDeprecated": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return inputValueDeprecationReason(p.Source) != "", nil
+				},
+			},
+			"deprecationReason": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if reason := inputValueDeprecationReason(p.Source); reason != "" {
+						return reason, nil
 					}
 					return nil, nil
 				},
@@ -291,9 +303,20 @@ func init() {
 			},
 			"args": &Field{
 				Type: NewNonNull(NewList(NewNonNull(InputValueType))),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if field, ok := p.Source.(*FieldDefinition); ok {
-						return field.Args, nil
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						args := field.Args
+						if !isIntrospectionField(field) {
+							args = p.Info.Schema.visibleArguments(p.Context, nil, args)
+						}
+						return filterDeprecatedArgs(args, includeDeprecated), nil
 					}
 					return []interface{}{}, nil
 				},
@@ -348,6 +371,29 @@ func init() {
 				Type: NewNonNull(NewList(
 					NewNonNull(InputValueType),
 				)),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if dir, ok := p.Source.(*Directive); ok {
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						args := p.Info.Schema.visibleArguments(p.Context, nil, dir.Args)
+						return filterDeprecatedArgs(args, includeDeprecated), nil
+					}
+					return []interface{}{}, nil
+				},
+			},
+			"isRepeatable": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if dir, ok := p.Source.(*Directive); ok {
+						return dir.IsRepeatable, nil
+					}
+					return false, nil
+				},
 			},
 			// NOTE: the following three fields are deprecated and are no longer part
 			// of the GraphQL specification.
@@ -424,7 +470,9 @@ func init() {
 					if schema, ok := p.Source.(Schema); ok {
 						results := []Type{}
 						for _, ttype := range schema.TypeMap() {
-							results = append(results, ttype)
+							if schema.IsVisible(p.Context, ttype) {
+								results = append(results, ttype)
+							}
 						}
 						return results, nil
 					}
@@ -447,7 +495,7 @@ func init() {
 				Type: TypeType,
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if schema, ok := p.Source.(Schema); ok {
-						if schema.MutationType() != nil {
+						if schema.MutationType() != nil && schema.IsVisible(p.Context, schema.MutationType()) {
 							return schema.MutationType(), nil
 						}
 					}
@@ -460,7 +508,7 @@ func init() {
 				Type: TypeType,
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if schema, ok := p.Source.(Schema); ok {
-						if schema.SubscriptionType() != nil {
+						if schema.SubscriptionType() != nil && schema.IsVisible(p.Context, schema.SubscriptionType()) {
 							return schema.SubscriptionType(), nil
 						}
 					}
@@ -534,9 +582,10 @@ func init() {
 				if ttype == nil {
 					return nil, nil
 				}
+				visibleFields := p.Info.Schema.visibleFields(p.Context, ttype)
 				fields := []*FieldDefinition{}
 				var fieldNames sort.StringSlice
-				for name, field := range ttype.Fields() {
+				for name, field := range visibleFields {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
@@ -544,7 +593,7 @@ func init() {
 				}
 				sort.Sort(fieldNames)
 				for _, name := range fieldNames {
-					fields = append(fields, ttype.Fields()[name])
+					fields = append(fields, visibleFields[name])
 				}
 				return fields, nil
 			case *Interface:
@@ -552,7 +601,7 @@ func init() {
 					return nil, nil
 				}
 				fields := []*FieldDefinition{}
-				for _, field := range ttype.Fields() {
+				for _, field := range p.Info.Schema.visibleFields(p.Context, ttype) {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
@@ -566,8 +615,11 @@ func init() {
 	TypeType.AddFieldConfig("interfaces", &Field{
 		Type: NewList(NewNonNull(TypeType)),
 		Resolve: func(p ResolveParams) (interface{}, error) {
-			if ttype, ok := p.Source.(*Object); ok {
-				return ttype.Interfaces(), nil
+			switch ttype := p.Source.(type) {
+			case *Object:
+				return p.Info.Schema.visibleInterfaces(p.Context, ttype.Interfaces()), nil
+			case *Interface:
+				return append([]*Interface{}, p.Info.Schema.visibleInterfaces(p.Context, ttype.Interfaces())...), nil
 			}
 			return nil, nil
 		},
@@ -577,9 +629,9 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			switch ttype := p.Source.(type) {
 			case *Interface:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return p.Info.Schema.visibleObjects(p.Context, p.Info.Schema.PossibleTypes(ttype)), nil
 			case *Union:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return p.Info.Schema.visibleObjects(p.Context, p.Info.Schema.PossibleTypes(ttype)), nil
 			}
 			return nil, nil
 		},
@@ -595,12 +647,13 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			if ttype, ok := p.Source.(*Enum); ok {
-				if includeDeprecated {
+				if includeDeprecated && p.Info.Schema.visibility == nil {
 					return ttype.Values(), nil
 				}
 				values := []*EnumValueDefinition{}
 				for _, value := range ttype.Values() {
-					if value.DeprecationReason != "" {
+					if !includeDeprecated && value.DeprecationReason != "" ||
+						!isIntrospectionType(ttype) && !p.Info.Schema.IsVisible(p.Context, value) {
 						continue
 					}
 					values = append(values, value)
@@ -612,10 +665,21 @@ func init() {
 	})
 	TypeType.AddFieldConfig("inputFields", &Field{
 		Type: NewList(NewNonNull(InputValueType)),
+		Args: FieldConfigArgument{
+			"includeDeprecated": &ArgumentConfig{
+				Type:         Boolean,
+				DefaultValue: false,
+			},
+		},
 		Resolve: func(p ResolveParams) (interface{}, error) {
+			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			if ttype, ok := p.Source.(*InputObject); ok {
 				fields := []*InputObjectField{}
 				for _, field := range ttype.Fields() {
+					if !includeDeprecated && field.DeprecationReason != "" ||
+						!p.Info.Schema.IsVisible(p.Context, field) {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				return fields, nil
@@ -623,6 +687,15 @@ func init() {
 			return nil, nil
 		},
 	})
+	TypeType.AddFieldConfig("isOneOf", &Field{
+		Type: Boolean,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			if ttype, ok := p.Source.(*InputObject); ok {
+				return ttype.IsOneOf(), nil
+			}
+			return nil, nil
+		},
+	})
 	TypeType.AddFieldConfig("ofType", &Field{
 		Type: TypeType,
 	})
@@ -660,7 +733,11 @@ func init() {
 			if !ok {
 				return nil, nil
 			}
-			return p.Info.Schema.Type(name), nil
+			ttype := p.Info.Schema.Type(name)
+			if ttype == nil || !p.Info.Schema.IsVisible(p.Context, ttype) {
+				return nil, nil
+			}
+			return ttype, nil
 		},
 	}
 
@@ -676,6 +753,39 @@ func init() {
 
 }
 
+// isIntrospectionField reports whether field is a field of an introspection type.
+func isIntrospectionField(field *FieldDefinition) bool {
+	for _, ttype := range []*Object{SchemaType, DirectiveType, TypeType, FieldType, InputValueType, EnumValueType} {
+		if ttype.Fields()[field.Name] == field {
+			return true
+		}
+	}
+	return false
+}
+
+func inputValueDeprecationReason(source interface{}) string {
+	switch inputVal := source.(type) {
+	case *Argument:
+		return inputVal.DeprecationReason
+	case *InputObjectField:
+		return inputVal.DeprecationReason
+	}
+	return ""
+}
+
+func filterDeprecatedArgs(args []*Argument, includeDeprecated bool) []*Argument {
+	if includeDeprecated {
+		return args
+	}
+	filtered := []*Argument{}
+	for _, arg := range args {
+		if arg.DeprecationReason == "" {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered
+}
+
 // Produces a GraphQL Value AST given a Golang value.
 //
 // Optionally, a GraphQL type may be provided, which will be used to
@@ -0,0 +1,274 @@
+package graphql
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// IntrospectionFromSchema returns the introspection of schema in the shape of the data of
+// a full introspection query, without executing one. Types, fields, arguments, enum
+// values and input fields are sorted by name. The result can be given to
+// BuildClientSchema or encoded as JSON.
+func IntrospectionFromSchema(schema Schema) map[string]interface{} {
+	typeNames := make([]string, 0, len(schema.TypeMap()))
+	for name := range schema.TypeMap() {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	types := make([]interface{}, 0, len(typeNames))
+	for _, name := range typeNames {
+		types = append(types, introspectType(&schema, schema.TypeMap()[name]))
+	}
+
+	directives := make([]interface{}, 0, len(schema.Directives()))
+	for _, directive := range schema.Directives() {
+		locations := make([]interface{}, 0, len(directive.Locations))
+		for _, location := range directive.Locations {
+			locations = append(locations, location)
+		}
+		directives = append(directives, map[string]interface{}{
+			"name":         directive.Name,
+			"description":  directive.Description,
+			"locations":    locations,
+			"args":         introspectArguments(directive.Args),
+			"isRepeatable": directive.IsRepeatable,
+		})
+	}
+
+	return map[string]interface{}{
+		"__schema": map[string]interface{}{
+			"queryType":        introspectTypeName(schema.QueryType()),
+			"mutationType":     introspectTypeName(schema.MutationType()),
+			"subscriptionType": introspectTypeName(schema.SubscriptionType()),
+			"types":            types,
+			"directives":       directives,
+		},
+	}
+}
+
+func introspectTypeName(ttype *Object) interface{} {
+	if ttype == nil {
+		return nil
+	}
+	return map[string]interface{}{"name": ttype.Name()}
+}
+
+func introspectType(schema *Schema, ttype Type) map[string]interface{} {
+	result := map[string]interface{}{
+		"name":           ttype.Name(),
+		"description":    ttype.Description(),
+		"specifiedByURL": nil,
+		"fields":         nil,
+		"inputFields":    nil,
+		"interfaces":     nil,
+		"enumValues":     nil,
+		"possibleTypes":  nil,
+		"isOneOf":        nil,
+	}
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		result["kind"] = TypeKindScalar
+		if ttype.SpecifiedByURL() != "" {
+			result["specifiedByURL"] = ttype.SpecifiedByURL()
+		}
+	case *Object:
+		result["kind"] = TypeKindObject
+		result["fields"] = introspectFields(ttype.Fields())
+		result["interfaces"] = introspectInterfaces(ttype.Interfaces())
+	case *Interface:
+		result["kind"] = TypeKindInterface
+		result["fields"] = introspectFields(ttype.Fields())
+		result["interfaces"] = introspectInterfaces(ttype.Interfaces())
+		// Implementations are collected from the type map, so they have no declared order.
+		implementations := append([]*Object{}, schema.PossibleTypes(ttype)...)
+		sort.Slice(implementations, func(i, j int) bool {
+			return implementations[i].Name() < implementations[j].Name()
+		})
+		result["possibleTypes"] = introspectPossibleTypes(implementations)
+	case *Union:
+		result["kind"] = TypeKindUnion
+		result["possibleTypes"] = introspectPossibleTypes(schema.PossibleTypes(ttype))
+	case *Enum:
+		result["kind"] = TypeKindEnum
+		enumValues := append([]*EnumValueDefinition{}, ttype.Values()...)
+		sort.Slice(enumValues, func(i, j int) bool {
+			return enumValues[i].Name < enumValues[j].Name
+		})
+		values := []interface{}{}
+		for _, value := range enumValues {
+			values = append(values, map[string]interface{}{
+				"name":              value.Name,
+				"description":       value.Description,
+				"isDeprecated":      value.DeprecationReason != "",
+				"deprecationReason": introspectDeprecationReason(value.DeprecationReason),
+			})
+		}
+		result["enumValues"] = values
+	case *InputObject:
+		result["kind"] = TypeKindInputObject
+		fields := ttype.Fields()
+		inputFields := []interface{}{}
+		for _, name := range sortedInputFieldNames(fields) {
+			field := fields[name]
+			inputFields = append(inputFields, introspectInputValue(
+				field.Name(), field.Description(), field.Type, field.DefaultValue, field.DeprecationReason,
+			))
+		}
+		result["inputFields"] = inputFields
+		result["isOneOf"] = ttype.IsOneOf()
+	}
+	return result
+}
+
+func introspectFields(fieldMap FieldDefinitionMap) []interface{} {
+	names := make([]string, 0, len(fieldMap))
+	for name := range fieldMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		field := fieldMap[name]
+		fields = append(fields, map[string]interface{}{
+			"name":              field.Name,
+			"description":       field.Description,
+			"args":              introspectArguments(field.Args),
+			"type":              introspectTypeRef(field.Type),
+			"isDeprecated":      field.DeprecationReason != "",
+			"deprecationReason": introspectDeprecationReason(field.DeprecationReason),
+		})
+	}
+	return fields
+}
+
+func introspectArguments(args []*Argument) []interface{} {
+	result := make([]interface{}, 0, len(args))
+	for _, arg := range sortedArguments(args) {
+		result = append(result, introspectInputValue(
+			arg.Name(), arg.Description(), arg.Type, arg.DefaultValue, arg.DeprecationReason,
+		))
+	}
+	return result
+}
+
+func introspectInputValue(name, description string, ttype Input, defaultValue interface{}, deprecationReason string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              name,
+		"description":       description,
+		"type":              introspectTypeRef(ttype),
+		"defaultValue":      printDefaultValue(defaultValue, ttype),
+		"isDeprecated":      deprecationReason != "",
+		"deprecationReason": introspectDeprecationReason(deprecationReason),
+	}
+}
+
+func introspectInterfaces(interfaces []*Interface) []interface{} {
+	result := make([]interface{}, 0, len(interfaces))
+	for _, iface := range interfaces {
+		result = append(result, introspectTypeRef(iface))
+	}
+	return result
+}
+
+func introspectPossibleTypes(types []*Object) []interface{} {
+	result := make([]interface{}, 0, len(types))
+	for _, ttype := range types {
+		result = append(result, introspectTypeRef(ttype))
+	}
+	return result
+}
+
+func introspectTypeRef(ttype Type) map[string]interface{} {
+	switch ttype := ttype.(type) {
+	case *List:
+		return map[string]interface{}{"kind": TypeKindList, "name": nil, "ofType": introspectTypeRef(ttype.OfType)}
+	case *NonNull:
+		return map[string]interface{}{"kind": TypeKindNonNull, "name": nil, "ofType": introspectTypeRef(ttype.OfType)}
+	}
+	kind := ""
+	switch ttype.(type) {
+	case *Scalar:
+		kind = TypeKindScalar
+	case *Object:
+		kind = TypeKindObject
+	case *Interface:
+		kind = TypeKindInterface
+	case *Union:
+		kind = TypeKindUnion
+	case *Enum:
+		kind = TypeKindEnum
+	case *InputObject:
+		kind = TypeKindInputObject
+	}
+	return map[string]interface{}{"kind": kind, "name": ttype.Name(), "ofType": nil}
+}
+
+func introspectDeprecationReason(reason string) interface{} {
+	if reason == "" {
+		return nil
+	}
+	return reason
+}
+
+// printDefaultValue prints a default value as GraphQL, or returns nil if there is none.
+func printDefaultValue(value interface{}, ttype Input) interface{} {
+	if isNullish(value) {
+		return nil
+	}
+	if valueAST := astFromInputValue(value, ttype); valueAST != nil {
+		return printer.Print(valueAST)
+	}
+	return nil
+}
+
+// astFromInputValue is astFromValue for values given in configs, where enums may be
+// given by their internal value and input objects by maps.
+func astFromInputValue(value interface{}, ttype Input) ast.Value {
+	if isNullish(value) {
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return astFromInputValue(value, ttype.OfType)
+	case *List:
+		valueVal := reflect.ValueOf(value)
+		if valueVal.Kind() != reflect.Slice {
+			return astFromInputValue(value, ttype.OfType)
+		}
+		values := []ast.Value{}
+		for i := 0; i < valueVal.Len(); i++ {
+			if itemAST := astFromInputValue(valueVal.Index(i).Interface(), ttype.OfType); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+		return ast.NewListValue(&ast.ListValue{Values: values})
+	case *InputObject:
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		fields := ttype.Fields()
+		objectFields := []*ast.ObjectField{}
+		for _, name := range sortedInputFieldNames(fields) {
+			fieldAST := astFromInputValue(valueMap[name], fields[name].Type)
+			if fieldAST == nil {
+				continue
+			}
+			objectFields = append(objectFields, ast.NewObjectField(&ast.ObjectField{
+				Name:  ast.NewName(&ast.Name{Value: name}),
+				Value: fieldAST,
+			}))
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: objectFields})
+	case *Enum:
+		if reflect.TypeOf(value).Comparable() {
+			if name, ok := ttype.Serialize(value).(string); ok {
+				return ast.NewEnumValue(&ast.EnumValue{Value: name})
+			}
+		}
+	}
+	return astFromValue(value, ttype)
+}
@@ -828,7 +828,7 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 		Schema:        emptySchema,
 		RequestString: testutil.IntrospectionQuery,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expectedDataSubSet) {
+	if !testutil.ContainSubset(result.DataMap(), expectedDataSubSet) {
 		t.Fatalf("unexpected, result does not contain subset of expected data")
 	}
 }
@@ -940,7 +940,7 @@ func TestIntrospection_ExecutesAnInputObject(t *testing.T) {
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expectedDataSubSet) {
+	if !testutil.ContainSubset(result.DataMap(), expectedDataSubSet) {
 		t.Fatalf("unexpected, result does not contain subset of expected data")
 	}
 }
@@ -980,7 +980,7 @@ func TestIntrospection_SupportsThe__TypeRootField(t *testing.T) {
 		RequestString: query,
 	})
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_IdentifiesDeprecatedFields(t *testing.T) {
@@ -1038,8 +1038,8 @@ func TestIntrospection_IdentifiesDeprecatedFields(t *testing.T) {
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_RespectsTheIncludeDeprecatedParameterForFields(t *testing.T) {
@@ -1107,8 +1107,8 @@ func TestIntrospection_RespectsTheIncludeDeprecatedParameterForFields(t *testing
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_IdentifiesDeprecatedEnumValues(t *testing.T) {
@@ -1182,8 +1182,8 @@ func TestIntrospection_IdentifiesDeprecatedEnumValues(t *testing.T) {
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_RespectsTheIncludeDeprecatedParameterForEnumValues(t *testing.T) {
@@ -1271,8 +1271,8 @@ func TestIntrospection_RespectsTheIncludeDeprecatedParameterForEnumValues(t *tes
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_FailsAsExpectedOnThe__TypeRootFieldWithoutAnArg(t *testing.T) {
@@ -1314,7 +1314,7 @@ func TestIntrospection_FailsAsExpectedOnThe__TypeRootFieldWithoutAnArg(t *testin
 		RequestString: query,
 	})
 	if !testutil.EqualResults(expected, result) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 
@@ -1386,8 +1386,8 @@ func TestIntrospection_ExposesDescriptionsOnTypesAndFields(t *testing.T) {
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
 func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
@@ -1434,7 +1434,7 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 					},
 					map[string]interface{}{
 						"name":        "INTERFACE",
-						"description": "Indicates this type is an interface. `fields` and `possibleTypes` are valid fields.",
+						"description": "Indicates this type is an interface. `fields`, `interfaces`, and `possibleTypes` are valid fields.",
 					},
 					map[string]interface{}{
 						"name":        "UNION",
@@ -1464,7 +1464,224 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 		Schema:        schema,
 		RequestString: query,
 	})
-	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
-		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	if !testutil.ContainSubset(result.DataMap(), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
+	}
+}
+func TestIntrospection_RespectsTheIncludeDeprecatedParameterForArgsAndInputFields(t *testing.T) {
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TestInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"deprecated": &graphql.InputObjectFieldConfig{
+				Type:              graphql.String,
+				DeprecationReason: "Use `other`.",
+			},
+		},
+	})
+	testType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"deprecated": &graphql.ArgumentConfig{
+						Type:              inputType,
+						DeprecationReason: "Removed in 1.0",
+					},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testType,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        testType: __type(name: "TestType") {
+          fields {
+            omittedArgs: args { name }
+            args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+          }
+        }
+        testInput: __type(name: "TestInput") {
+          omittedFields: inputFields { name }
+          inputFields(includeDeprecated: true) {
+            name
+            isDeprecated
+            deprecationReason
+          }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"testType": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"omittedArgs": []interface{}{},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":              "deprecated",
+								"isDeprecated":      true,
+								"deprecationReason": "Removed in 1.0",
+							},
+						},
+					},
+				},
+			},
+			"testInput": map[string]interface{}{
+				"omittedFields": []interface{}{},
+				"inputFields": []interface{}{
+					map[string]interface{}{
+						"name":              "deprecated",
+						"isDeprecated":      true,
+						"deprecationReason": "Use `other`.",
+					},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
+	}
+}
+
+func TestIntrospection_ExposesSpecifiedByURLAndIsRepeatable(t *testing.T) {
+	uuid := graphql.NewScalar(graphql.ScalarConfig{
+		Name:           "UUID",
+		SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc4122",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+	})
+	tagDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:         "tag",
+		Locations:    []string{graphql.DirectiveLocationField},
+		IsRepeatable: true,
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: uuid},
+			},
+		}),
+		Directives: []*graphql.Directive{graphql.SkipDirective, tagDirective},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        uuid: __type(name: "UUID") { specifiedByURL }
+        string: __type(name: "String") { specifiedByURL }
+        __schema {
+          directives { name isRepeatable }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"uuid":   map[string]interface{}{"specifiedByURL": "https://www.rfc-editor.org/rfc/rfc4122"},
+			"string": map[string]interface{}{"specifiedByURL": nil},
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					map[string]interface{}{"name": "skip", "isRepeatable": false},
+					map[string]interface{}{"name": "tag", "isRepeatable": true},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
+	}
+}
+
+func TestIntrospection_IdentifiesOneOfInputObjects(t *testing.T) {
+	query := `
+      {
+        oneOf: __type(name: "OneOfInput") { isOneOf }
+        object: __type(name: "Query") { isOneOf }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"oneOf":  map[string]interface{}{"isOneOf": true},
+			"object": map[string]interface{}{"isOneOf": nil},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        oneOfTestSchema(t),
+		RequestString: query,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
+	}
+}
+
+func TestIntrospection_ExposesInterfacesOfInterfaces(t *testing.T) {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	resourceInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Resource",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"resource": &graphql.Field{Type: resourceInterface},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        resource: __type(name: "Resource") { interfaces { name } }
+        node: __type(name: "Node") { interfaces { name } }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"resource": map[string]interface{}{
+				"interfaces": []interface{}{
+					map[string]interface{}{"name": "Node"},
+				},
+			},
+			"node": map[string]interface{}{
+				"interfaces": []interface{}{},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, testutil.PlainResult(result)))
 	}
 }
@@ -0,0 +1,22 @@
+package ast
+
+// Comment is a "#" comment of the source, kept by the parser on request.
+type Comment struct {
+	Loc *Location
+	// Value is the text of the comment following the "#".
+	Value string
+}
+
+// Trivia are the comments attached to a node.
+type Trivia struct {
+	// Leading are the comments on the lines before the node.
+	Leading []*Comment
+	// Inline is the comment ending the last line of the node.
+	Inline *Comment
+	// Trailing are the comments on the lines after the node, up to the end of the
+	// block or document holding it.
+	Trailing []*Comment
+}
+
+// CommentMap maps nodes to the comments attached to them.
+type CommentMap map[Node]*Trivia
@@ -9,6 +9,9 @@ type Document struct {
 	Kind        string
 	Loc         *Location
 	Definitions []Node
+	// Comments are the comments of the source, attached to the nodes of the document,
+	// when the parser keeps them.
+	Comments CommentMap
 }
 
 func NewDocument(d *Document) *Document {
@@ -19,6 +22,7 @@ func NewDocument(d *Document) *Document {
 		Kind:        kinds.Document,
 		Loc:         d.Loc,
 		Definitions: d.Definitions,
+		Comments:    d.Comments,
 	}
 }
 
@@ -12,6 +12,7 @@ type Selection interface {
 var _ Selection = (*Field)(nil)
 var _ Selection = (*FragmentSpread)(nil)
 var _ Selection = (*InlineFragment)(nil)
+var _ Selection = (*BadSelection)(nil)
 
 // Field implements Node, Selection
 type Field struct {
@@ -132,3 +133,32 @@ func (ss *SelectionSet) GetKind() string {
 func (ss *SelectionSet) GetLoc() *Location {
 	return ss.Loc
 }
+
+// BadSelection implements Node, Selection. It holds the place of a selection with
+// syntax errors in a document parsed tolerantly, spanning the source that was skipped.
+type BadSelection struct {
+	Kind string
+	Loc  *Location
+}
+
+func NewBadSelection(bs *BadSelection) *BadSelection {
+	if bs == nil {
+		bs = &BadSelection{}
+	}
+	return &BadSelection{
+		Kind: kinds.BadSelection,
+		Loc:  bs.Loc,
+	}
+}
+
+func (bs *BadSelection) GetKind() string {
+	return bs.Kind
+}
+
+func (bs *BadSelection) GetLoc() *Location {
+	return bs.Loc
+}
+
+func (bs *BadSelection) GetSelectionSet() *SelectionSet {
+	return nil
+}
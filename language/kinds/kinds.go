@@ -52,8 +52,18 @@ const (
 	InputObjectDefinition = "InputObjectDefinition" // previously InputObjectTypeDefinition
 
 	// Types Extensions
-	TypeExtensionDefinition = "TypeExtensionDefinition"
+	TypeExtensionDefinition        = "TypeExtensionDefinition" // object type extension
+	SchemaExtensionDefinition      = "SchemaExtensionDefinition"
+	ScalarExtensionDefinition      = "ScalarExtensionDefinition"
+	InterfaceExtensionDefinition   = "InterfaceExtensionDefinition"
+	UnionExtensionDefinition       = "UnionExtensionDefinition"
+	EnumExtensionDefinition        = "EnumExtensionDefinition"
+	InputObjectExtensionDefinition = "InputObjectExtensionDefinition"
 
 	// Directive Definitions
 	DirectiveDefinition = "DirectiveDefinition"
+
+	// Placeholders of tolerant parsing
+	BadDefinition = "BadDefinition"
+	BadSelection  = "BadSelection"
 )
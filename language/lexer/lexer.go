@@ -34,6 +34,7 @@ const (
 	STRING
 	BLOCK_STRING
 	AMP
+	COMMENT
 )
 
 var tokenDescription = map[TokenKind]string{
@@ -57,6 +58,7 @@ var tokenDescription = map[TokenKind]string{
 	STRING:       "String",
 	BLOCK_STRING: "BlockString",
 	AMP:          "&",
+	COMMENT:      "Comment",
 }
 
 func (kind TokenKind) String() string {
@@ -81,7 +83,7 @@ const (
 )
 
 // Token is a representation of a lexed Token. Value only appears for non-punctuation
-// tokens: NAME, INT, FLOAT, and STRING.
+// tokens: NAME, INT, FLOAT, STRING, and COMMENT, which is never returned by Lex.
 type Token struct {
 	Kind  TokenKind
 	Start int
@@ -642,6 +644,29 @@ func positionAfterWhitespace(body []byte, startPosition int) (position int, rune
 	return position, runePosition
 }
 
+// ReadComments returns the COMMENT tokens of the ignored characters between start and
+// end, such as those Lex skips before a token. Their value is the text following the
+// "#", up to the end of the line.
+func ReadComments(s *source.Source, start, end int) []Token {
+	body := s.Body
+	if end > len(body) {
+		end = len(body)
+	}
+	var comments []Token
+	for position := start; position < end; position++ {
+		if body[position] != '#' {
+			continue
+		}
+		commentEnd := position + 1
+		for commentEnd < end && body[commentEnd] != '\n' && body[commentEnd] != '\r' {
+			commentEnd++
+		}
+		comments = append(comments, makeToken(COMMENT, position, commentEnd, string(body[position+1:commentEnd])))
+		position = commentEnd
+	}
+	return comments
+}
+
 func GetTokenDesc(token Token) string {
 	if token.Value == "" {
 		return token.Kind.String()
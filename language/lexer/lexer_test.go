@@ -165,6 +165,29 @@ func TestLexer_SkipsWhiteSpace(t *testing.T) {
 	}
 }
 
+func TestLexer_ReadsCommentsOfTheSkippedCharacters(t *testing.T) {
+	s := &source.Source{Body: []byte("\n    #comment\r\n    foo# trailing\n#\nbar")}
+	token, err := Lex(s)(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Token{{Kind: COMMENT, Start: 5, End: 13, Value: "comment"}}
+	if comments := ReadComments(s, 0, token.Start); !reflect.DeepEqual(comments, expected) {
+		t.Fatalf("unexpected comments, expected: %v, got: %v", expected, comments)
+	}
+	next, err := Lex(s)(token.End)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []Token{
+		{Kind: COMMENT, Start: 22, End: 32, Value: " trailing"},
+		{Kind: COMMENT, Start: 33, End: 34, Value: ""},
+	}
+	if comments := ReadComments(s, token.End, next.Start); !reflect.DeepEqual(comments, expected) {
+		t.Fatalf("unexpected comments, expected: %v, got: %v", expected, comments)
+	}
+}
+
 func TestLexer_ErrorsRespectWhitespace(t *testing.T) {
 	body := `
 
@@ -1,12 +1,16 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/tailor-inc/graphql/gqlerrors"
 	"github.com/tailor-inc/graphql/language/ast"
 	"github.com/tailor-inc/graphql/language/lexer"
 	"github.com/tailor-inc/graphql/language/source"
+	"github.com/tailor-inc/graphql/language/visitor"
 )
 
 type parseFn func(parser *Parser) (interface{}, error)
@@ -31,7 +35,7 @@ func init() {
 		tokenDefinitionFn[lexer.UNION] = parseUnionTypeDefinition
 		tokenDefinitionFn[lexer.ENUM] = parseEnumTypeDefinition
 		tokenDefinitionFn[lexer.INPUT] = parseInputObjectTypeDefinition
-		tokenDefinitionFn[lexer.EXTEND] = parseObjectTypeDefinition
+		tokenDefinitionFn[lexer.EXTEND] = parseTypeExtension
 		tokenDefinitionFn[lexer.DIRECTIVE] = parseDirectiveDefinition
 	}
 }
@@ -39,6 +43,47 @@ func init() {
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
+
+	// The limits below protect against documents crafted to exhaust the parser. Each
+	// is disabled when zero, and parsing stops with a syntax error located where a
+	// limit is exceeded.
+
+	// MaxBytes limits the size of the source.
+	MaxBytes int
+	// MaxTokens limits the number of tokens, not counting the end of the source.
+	MaxTokens int
+	// MaxSelectionDepth limits the nesting of selection sets: a document holding a
+	// single field has a depth of 1.
+	MaxSelectionDepth int
+	// MaxValueDepth limits the nesting of list and object values, and of list types:
+	// a scalar has a depth of 0 and a list of scalars a depth of 1.
+	MaxValueDepth int
+
+	// Tolerant parses past syntax errors, for tools such as editors and linters.
+	// Characters which can't be lexed are skipped. A definition or selection with
+	// errors is skipped up to the next one, and replaced by an ast.BadDefinition or
+	// ast.BadSelection. Parse then returns the document along with SyntaxErrors
+	// holding every error, except when a limit is exceeded, which stops parsing.
+	Tolerant bool
+
+	// KeepComments keeps the comments of the source in Document.Comments, attached to
+	// the nearest nodes: a comment ending the line of a node is its inline comment,
+	// the comments on the lines before a node lead it, and those at the end of a block
+	// trail its last node. It needs the locations of the nodes, so it has no effect
+	// with NoLocation.
+	KeepComments bool
+}
+
+// SyntaxErrors holds every syntax error found by a tolerant parse, in the order of the
+// source.
+type SyntaxErrors []*gqlerrors.Error
+
+func (errs SyntaxErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
 }
 
 type ParseParams struct {
@@ -52,6 +97,20 @@ type Parser struct {
 	Options  ParseOptions
 	PrevEnd  int
 	Token    lexer.Token
+
+	tokens         int
+	selectionDepth int
+	valueDepth     int
+	// open are the brackets, braces and parentheses open before the current token,
+	// and prevKind the kind of the token before it.
+	open     []lexer.TokenKind
+	prevKind lexer.TokenKind
+	// errors are the syntax errors recovered from by a tolerant parse, and limited
+	// is set once a limit is exceeded.
+	errors  SyntaxErrors
+	limited bool
+	// comments are the comments kept before each token.
+	comments []tokenComments
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
@@ -68,14 +127,18 @@ func Parse(p ParseParams) (*ast.Document, error) {
 		return nil, err
 	}
 	doc, err := parseDocument(parser)
-	if err != nil {
-		return nil, err
+	if doc != nil {
+		doc.Comments = attachComments(parser, doc)
+	}
+	if err = syntaxErrors(parser, err); err != nil {
+		return doc, err
 	}
 	return doc, nil
 }
 
-// TODO: test and expose parseValue as a public
-func parseValue(p ParseParams) (ast.Value, error) {
+// ParseValue parses a single GraphQL value, such as the default values
+// printed by introspection.
+func ParseValue(p ParseParams) (ast.Value, error) {
 	var value ast.Value
 	var sourceObj *source.Source
 	switch src := p.Source.(type) {
@@ -90,8 +153,11 @@ func parseValue(p ParseParams) (ast.Value, error) {
 		return value, err
 	}
 	value, err = parseValueLiteral(parser, false)
-	if err != nil {
-		return value, err
+	if err == nil {
+		_, err = expect(parser, lexer.EOF)
+	}
+	if err = syntaxErrors(parser, err); err != nil {
+		return nil, err
 	}
 	return value, nil
 }
@@ -109,18 +175,26 @@ func parseName(parser *Parser) (*ast.Name, error) {
 }
 
 func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
-	lexToken := lexer.Lex(s)
-	token, err := lexToken(0)
-	if err != nil {
-		return &Parser{}, err
+	if opts.MaxBytes > 0 && len(s.Body) > opts.MaxBytes {
+		description := fmt.Sprintf("Document is larger than %d bytes.", opts.MaxBytes)
+		return &Parser{}, gqlerrors.NewSyntaxError(s, opts.MaxBytes, description)
 	}
-	return &Parser{
-		LexToken: lexToken,
+	parser := &Parser{
+		LexToken: lexer.Lex(s),
 		Source:   s,
 		Options:  opts,
 		PrevEnd:  0,
-		Token:    token,
-	}, nil
+	}
+	token, err := lex(parser, 0)
+	if err != nil {
+		return &Parser{}, err
+	}
+	keepComments(parser, -1, token)
+	parser.Token = token
+	if err := countToken(parser); err != nil {
+		return &Parser{}, err
+	}
+	return parser, nil
 }
 
 /* Implements the parsing rules in the Document section. */
@@ -139,16 +213,19 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 		} else if skp {
 			break
 		}
+		definitionStart := parser.Token.Start
 		switch kind := parser.Token.Kind; kind {
 		case lexer.BRACE_L:
 			item = parseOperationDefinition
 		case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
 			item = parseTypeSystemDefinition
 		default:
-			return nil, unexpected(parser, lexer.Token{})
+			item = parseUnexpected
 		}
 		if node, err = item(parser); err != nil {
-			return nil, err
+			if node, err = recoverDefinition(parser, definitionStart, err); err != nil {
+				return nil, err
+			}
 		}
 		nodes = append(nodes, node)
 	}
@@ -316,6 +393,11 @@ func parseVariable(parser *Parser) (*ast.Variable, error) {
  * SelectionSet : { Selection+ }
  */
 func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
+	leave, err := enterSelectionSet(parser)
+	defer leave()
+	if err != nil {
+		return nil, err
+	}
 	start := parser.Token.Start
 	selections := []ast.Selection{}
 	if iSelections, err := reverse(parser,
@@ -342,10 +424,20 @@ func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
  *   - InlineFragment
  */
 func parseSelection(parser *Parser) (interface{}, error) {
+	start, nesting := parser.Token.Start, nestingOf(parser)
+	var (
+		selection interface{}
+		err       error
+	)
 	if peek(parser, lexer.SPREAD) {
-		return parseFragment(parser)
+		selection, err = parseFragment(parser)
+	} else {
+		selection, err = parseField(parser)
 	}
-	return parseField(parser)
+	if err != nil {
+		return recoverSelection(parser, start, nesting, err)
+	}
+	return selection, nil
 }
 
 /**
@@ -643,6 +735,11 @@ func parseValueValue(parser *Parser) (interface{}, error) {
  *   - [ Value[?Const]+ ]
  */
 func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
+	leave, err := enterValue(parser)
+	defer leave()
+	if err != nil {
+		return nil, err
+	}
 	start := parser.Token.Start
 	var item parseFn = parseValueValue
 	if isConst {
@@ -671,6 +768,11 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  *   - { ObjectField[?Const]+ }
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
+	leave, err := enterValue(parser)
+	defer leave()
+	if err != nil {
+		return nil, err
+	}
 	start := parser.Token.Start
 	if _, err := expect(parser, lexer.BRACE_L); err != nil {
 		return nil, err
@@ -776,15 +878,19 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 	// [ String! ]!
 	switch token.Kind {
 	case lexer.BRACKET_L:
+		var leave func()
+		leave, err = enterValue(parser)
+		defer leave()
+		if err != nil {
+			return nil, err
+		}
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
 		if ttype, err = parseType(parser); err != nil {
 			return nil, err
 		}
-		fallthrough
-	case lexer.BRACKET_R:
-		if err = advance(parser); err != nil {
+		if _, err = expect(parser, lexer.BRACKET_R); err != nil {
 			return nil, err
 		}
 		ttype = ast.NewList(&ast.List{
@@ -795,6 +901,10 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 		if ttype, err = parseNamed(parser); err != nil {
 			return nil, err
 		}
+	default:
+		if ttype, err = parseMissingType(parser); err != nil {
+			return nil, err
+		}
 	}
 
 	// BANG must be executed
@@ -809,6 +919,27 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 	return ttype, nil
 }
 
+// parseMissingType fails on the current token, which can't start a type. A tolerant
+// parse records the error and goes on with a Named type without a name in place of the
+// missing type, so that the nodes holding types always have one.
+func parseMissingType(parser *Parser) (ast.Type, error) {
+	token, err := expect(parser, lexer.NAME)
+	if !recoverable(parser, err) {
+		return nil, err
+	}
+	emptyLoc := func() *ast.Location {
+		location := loc(parser, token.Start)
+		if location != nil {
+			location.End = token.Start
+		}
+		return location
+	}
+	return ast.NewNamed(&ast.Named{
+		Name: ast.NewName(&ast.Name{Loc: emptyLoc()}),
+		Loc:  emptyLoc(),
+	}), nil
+}
+
 /**
  * NamedType : Name
  */
@@ -958,21 +1089,9 @@ func parseScalarTypeDefinition(parser *Parser) (ast.Node, error) {
  */
 
 func parseObjectTypeDefinition(parser *Parser) (ast.Node, error) {
-	start := parser.Token.Start
 	description, err := parseDescription(parser)
-	token := parser.Token
-	if token.Kind == lexer.NAME && token.Value == lexer.EXTEND {
-		if err = advance(parser); err != nil {
-			return nil, err
-		}
-		definition, err := parseObjectType(parser, description)
-		if err != nil {
-			return nil, err
-		}
-		return ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
-			Loc:        loc(parser, start),
-			Definition: definition.(*ast.ObjectDefinition),
-		}), nil
+	if err != nil {
+		return nil, err
 	}
 	return parseObjectType(parser, description)
 }
@@ -1163,7 +1282,7 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 /**
  * InterfaceTypeDefinition :
  *   Description?
- *   interface Name Directives? { FieldDefinition+ }
+ *   interface Name ImplementsInterfaces? Directives? { FieldDefinition+ }
  */
 func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1179,6 +1298,10 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
 	directives, err := parseDirectives(parser)
 	if err != nil {
 		return nil, err
@@ -1199,6 +1322,7 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
 		Name:        name,
 		Description: description,
+		Interfaces:  interfaces,
 		Directives:  directives,
 		Loc:         loc(parser, start),
 		Fields:      fields,
@@ -1379,9 +1503,363 @@ func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 	}), nil
 }
 
+/**
+ * TypeExtension :
+ *   - SchemaExtension
+ *   - ScalarTypeExtension
+ *   - ObjectTypeExtension
+ *   - InterfaceTypeExtension
+ *   - UnionTypeExtension
+ *   - EnumTypeExtension
+ *   - InputObjectTypeExtension
+ *
+ * A description preceding the extension is kept on the extended definition.
+ */
+func parseTypeExtension(parser *Parser) (ast.Node, error) {
+	start := parser.Token.Start
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = expectKeyWord(parser, lexer.EXTEND); err != nil {
+		return nil, err
+	}
+	token := parser.Token
+	if token.Kind != lexer.NAME {
+		return nil, unexpected(parser, token)
+	}
+	switch token.Value {
+	case lexer.SCHEMA:
+		if description != nil {
+			return nil, unexpected(parser, token)
+		}
+		definition, err := parseSchemaExtension(parser)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewSchemaExtensionDefinition(&ast.SchemaExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	case lexer.SCALAR:
+		definition, err := parseScalarTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		def := definition.(*ast.ScalarDefinition)
+		if len(def.Directives) == 0 {
+			return nil, unexpected(parser, lexer.Token{})
+		}
+		def.Description = description
+		return ast.NewScalarExtensionDefinition(&ast.ScalarExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: def,
+		}), nil
+	case lexer.TYPE:
+		definition, err := parseObjectTypeExtension(parser, description)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	case lexer.INTERFACE:
+		definition, err := parseInterfaceTypeExtension(parser, description)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewInterfaceExtensionDefinition(&ast.InterfaceExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	case lexer.UNION:
+		definition, err := parseUnionTypeExtension(parser, description)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewUnionExtensionDefinition(&ast.UnionExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	case lexer.ENUM:
+		definition, err := parseEnumTypeExtension(parser, description)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewEnumExtensionDefinition(&ast.EnumExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	case lexer.INPUT:
+		definition, err := parseInputObjectTypeExtension(parser, description)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewInputObjectExtensionDefinition(&ast.InputObjectExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: definition,
+		}), nil
+	}
+	return nil, unexpected(parser, token)
+}
+
+/**
+ * SchemaExtension :
+ *   - extend schema Directives? { OperationTypeDefinition+ }
+ *   - extend schema Directives
+ */
+func parseSchemaExtension(parser *Parser) (*ast.SchemaDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.SCHEMA); err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	operationTypesI, err := optionalReverse(parser,
+		lexer.BRACE_L, parseOperationTypeDefinition, lexer.BRACE_R,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(directives) == 0 && operationTypesI == nil {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	operationTypes := []*ast.OperationTypeDefinition{}
+	for _, op := range operationTypesI {
+		if op, ok := op.(*ast.OperationTypeDefinition); ok {
+			operationTypes = append(operationTypes, op)
+		}
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		OperationTypes: operationTypes,
+		Directives:     directives,
+		Loc:            loc(parser, start),
+	}), nil
+}
+
+/**
+ * ObjectTypeExtension :
+ *   - extend type Name ImplementsInterfaces? Directives? { FieldDefinition+ }
+ *   - extend type Name ImplementsInterfaces? Directives
+ *   - extend type Name ImplementsInterfaces
+ */
+func parseObjectTypeExtension(parser *Parser, description *ast.StringValue) (*ast.ObjectDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.TYPE); err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	iFields, err := optionalReverse(parser,
+		lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(interfaces) == 0 && len(directives) == 0 && iFields == nil {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	fields := []*ast.FieldDefinition{}
+	for _, iField := range iFields {
+		if iField != nil {
+			fields = append(fields, iField.(*ast.FieldDefinition))
+		}
+	}
+	return ast.NewObjectDefinition(&ast.ObjectDefinition{
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Fields:      fields,
+	}), nil
+}
+
+/**
+ * InterfaceTypeExtension :
+ *   - extend interface Name ImplementsInterfaces? Directives? { FieldDefinition+ }
+ *   - extend interface Name ImplementsInterfaces? Directives
+ *   - extend interface Name ImplementsInterfaces
+ */
+func parseInterfaceTypeExtension(parser *Parser, description *ast.StringValue) (*ast.InterfaceDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.INTERFACE); err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	iFields, err := optionalReverse(parser,
+		lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(interfaces) == 0 && len(directives) == 0 && iFields == nil {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	fields := []*ast.FieldDefinition{}
+	for _, iField := range iFields {
+		if iField != nil {
+			fields = append(fields, iField.(*ast.FieldDefinition))
+		}
+	}
+	return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+		Name:        name,
+		Description: description,
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Fields:      fields,
+	}), nil
+}
+
+/**
+ * UnionTypeExtension :
+ *   - extend union Name Directives? = UnionMembers
+ *   - extend union Name Directives
+ */
+func parseUnionTypeExtension(parser *Parser, description *ast.StringValue) (*ast.UnionDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.UNION); err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	types := []*ast.Named{}
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
+		return nil, err
+	} else if skp {
+		if types, err = parseUnionMembers(parser); err != nil {
+			return nil, err
+		}
+	} else if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewUnionDefinition(&ast.UnionDefinition{
+		Name:        name,
+		Description: description,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Types:       types,
+	}), nil
+}
+
+/**
+ * EnumTypeExtension :
+ *   - extend enum Name Directives? { EnumValueDefinition+ }
+ *   - extend enum Name Directives
+ */
+func parseEnumTypeExtension(parser *Parser, description *ast.StringValue) (*ast.EnumDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.ENUM); err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	iEnumValueDefs, err := optionalReverse(parser,
+		lexer.BRACE_L, parseEnumValueDefinition, lexer.BRACE_R,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(directives) == 0 && iEnumValueDefs == nil {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	values := []*ast.EnumValueDefinition{}
+	for _, iEnumValueDef := range iEnumValueDefs {
+		if iEnumValueDef != nil {
+			values = append(values, iEnumValueDef.(*ast.EnumValueDefinition))
+		}
+	}
+	return ast.NewEnumDefinition(&ast.EnumDefinition{
+		Name:        name,
+		Description: description,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Values:      values,
+	}), nil
+}
+
+/**
+ * InputObjectTypeExtension :
+ *   - extend input Name Directives? { InputValueDefinition+ }
+ *   - extend input Name Directives
+ */
+func parseInputObjectTypeExtension(parser *Parser, description *ast.StringValue) (*ast.InputObjectDefinition, error) {
+	start := parser.Token.Start
+	if _, err := expectKeyWord(parser, lexer.INPUT); err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	iInputValueDefinitions, err := optionalReverse(parser,
+		lexer.BRACE_L, parseInputValueDef, lexer.BRACE_R,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(directives) == 0 && iInputValueDefinitions == nil {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	fields := []*ast.InputValueDefinition{}
+	for _, iInputValueDefinition := range iInputValueDefinitions {
+		if iInputValueDefinition != nil {
+			fields = append(fields, iInputValueDefinition.(*ast.InputValueDefinition))
+		}
+	}
+	return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+		Name:        name,
+		Description: description,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Fields:      fields,
+	}), nil
+}
+
 /**
  * DirectiveDefinition :
- *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
+ *   - Description? directive @ Name ArgumentsDefinition? repeatable? on DirectiveLocations
  */
 func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	var (
@@ -1389,6 +1867,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		description *ast.StringValue
 		name        *ast.Name
 		args        []*ast.InputValueDefinition
+		repeatable  bool
 		locations   []*ast.Name
 	)
 	start := parser.Token.Start
@@ -1407,6 +1886,12 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if args, err = parseArgumentDefs(parser); err != nil {
 		return nil, err
 	}
+	if peek(parser, lexer.NAME) && parser.Token.Value == "repeatable" {
+		repeatable = true
+		if err = advance(parser); err != nil {
+			return nil, err
+		}
+	}
 	if _, err = expectKeyWord(parser, "on"); err != nil {
 		return nil, err
 	}
@@ -1419,6 +1904,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		Name:        name,
 		Description: description,
 		Arguments:   args,
+		Repeatable:  repeatable,
 		Locations:   locations,
 	}), nil
 }
@@ -1490,18 +1976,98 @@ func loc(parser *Parser, start int) *ast.Location {
 
 // Moves the internal parser object to the next lexed token.
 func advance(parser *Parser) error {
+	switch kind := parser.Token.Kind; kind {
+	case lexer.BRACE_L, lexer.BRACKET_L, lexer.PAREN_L:
+		parser.open = append(parser.open, kind)
+	case lexer.BRACE_R, lexer.BRACKET_R, lexer.PAREN_R:
+		// A closing token closes the ones left open since its opening token, if any.
+		for i := len(parser.open) - 1; i >= 0; i-- {
+			if closing[parser.open[i]] == kind {
+				parser.open = parser.open[:i]
+				break
+			}
+		}
+	}
 	parser.PrevEnd = parser.Token.End
-	token, err := parser.LexToken(parser.PrevEnd)
+	token, err := lex(parser, parser.PrevEnd)
 	if err != nil {
 		return err
 	}
-	parser.Token = token
+	keepComments(parser, parser.PrevEnd, token)
+	parser.prevKind, parser.Token = parser.Token.Kind, token
+	return countToken(parser)
+}
+
+var closing = map[lexer.TokenKind]lexer.TokenKind{
+	lexer.BRACE_L:   lexer.BRACE_R,
+	lexer.BRACKET_L: lexer.BRACKET_R,
+	lexer.PAREN_L:   lexer.PAREN_R,
+}
+
+// lex lexes the token starting at or after position. When parsing tolerantly, the
+// characters which can't be lexed are recorded as errors and skipped.
+func lex(parser *Parser, position int) (lexer.Token, error) {
+	for {
+		token, err := parser.LexToken(position)
+		if err == nil || !parser.Options.Tolerant {
+			return token, err
+		}
+		gErr, ok := err.(*gqlerrors.Error)
+		if !ok || len(gErr.Positions) == 0 {
+			return token, err
+		}
+		recordError(parser, gErr)
+		if next := gErr.Positions[0] + 1; next > position {
+			position = next
+		} else {
+			position++
+		}
+	}
+}
+
+// countToken counts the current token against the MaxTokens limit.
+func countToken(parser *Parser) error {
+	if parser.Token.Kind == lexer.EOF {
+		return nil
+	}
+	parser.tokens++
+	if max := parser.Options.MaxTokens; max > 0 && parser.tokens > max {
+		parser.limited = true
+		description := fmt.Sprintf("Document contains more than %d tokens.", max)
+		return gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
 	return nil
 }
 
+// enterSelectionSet counts the selection set starting at the current token against the
+// MaxSelectionDepth limit, until the returned function is called.
+func enterSelectionSet(parser *Parser) (func(), error) {
+	parser.selectionDepth++
+	leave := func() { parser.selectionDepth-- }
+	if max := parser.Options.MaxSelectionDepth; max > 0 && parser.selectionDepth > max {
+		parser.limited = true
+		description := fmt.Sprintf("Selection sets are nested deeper than %d levels.", max)
+		return leave, gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
+	return leave, nil
+}
+
+// enterValue counts the list or object value, or list type, starting at the current
+// token against the MaxValueDepth limit, until the returned function is called.
+func enterValue(parser *Parser) (func(), error) {
+	parser.valueDepth++
+	leave := func() { parser.valueDepth-- }
+	if max := parser.Options.MaxValueDepth; max > 0 && parser.valueDepth > max {
+		parser.limited = true
+		description := fmt.Sprintf("Values are nested deeper than %d levels.", max)
+		return leave, gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
+	return leave, nil
+}
+
 // lookahead retrieves the next token
 func lookahead(parser *Parser) (lexer.Token, error) {
-	return parser.LexToken(parser.Token.End)
+	return lex(parser, parser.Token.End)
 }
 
 // Determines if the next token is of a given kind
@@ -1556,11 +2122,29 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 	return gqlerrors.NewSyntaxError(parser.Source, token.Start, description)
 }
 
+// parseUnexpected fails on the current token, which can't start a definition.
+func parseUnexpected(parser *Parser) (ast.Node, error) {
+	return nil, unexpected(parser, lexer.Token{})
+}
+
 func unexpectedEmpty(parser *Parser, beginLoc int, openKind, closeKind lexer.TokenKind) error {
 	description := fmt.Sprintf("Unexpected empty IN %s%s", openKind, closeKind)
 	return gqlerrors.NewSyntaxError(parser.Source, beginLoc, description)
 }
 
+// optionalReverse is like reverse but returns nil, without advancing the parser,
+// when the next token is not of openKind.
+func optionalReverse(parser *Parser, openKind lexer.TokenKind, parseFn parseFn, closeKind lexer.TokenKind) ([]interface{}, error) {
+	if !peek(parser, openKind) {
+		return nil, nil
+	}
+	nodes, err := reverse(parser, openKind, parseFn, closeKind, false)
+	if err == nil && nodes == nil {
+		nodes = []interface{}{}
+	}
+	return nodes, err
+}
+
 //  Returns list of parse nodes, determined by
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
@@ -1589,3 +2173,231 @@ func reverse(parser *Parser, openKind lexer.TokenKind, parseFn parseFn, closeKin
 	}
 	return nodes, nil
 }
+
+/* Implements the recovery from syntax errors of tolerant parsing. */
+
+// recordError records err, which has a position, unless it was already recorded, as
+// happens when a token is lexed again.
+func recordError(parser *Parser, err *gqlerrors.Error) {
+	for _, recorded := range parser.errors {
+		if recorded.Positions[0] == err.Positions[0] && recorded.Message == err.Message {
+			return
+		}
+	}
+	parser.errors = append(parser.errors, err)
+}
+
+// recoverable reports whether the parse can go on after err, recording it if so.
+func recoverable(parser *Parser, err error) bool {
+	gErr, ok := err.(*gqlerrors.Error)
+	if !ok || len(gErr.Positions) == 0 || !parser.Options.Tolerant || parser.limited {
+		return false
+	}
+	recordError(parser, gErr)
+	return true
+}
+
+// recoverDefinition recovers from err, raised by the definition starting at start, by
+// skipping to the next definition. The skipped source is held by the returned
+// ast.BadDefinition.
+func recoverDefinition(parser *Parser, start int, err error) (ast.Node, error) {
+	if !recoverable(parser, err) {
+		return nil, err
+	}
+	if err := skipTo(parser, start, 0, isDefinitionStart); err != nil {
+		return nil, err
+	}
+	return ast.NewBadDefinition(&ast.BadDefinition{
+		Loc: loc(parser, start),
+	}), nil
+}
+
+// recoverSelection recovers from err, raised by the selection starting at start, by
+// skipping to the next selection of its selection set, or to the end of the set. The
+// skipped source is held by the returned ast.BadSelection. Reaching the end of the
+// source leaves the recovery to the definition.
+func recoverSelection(parser *Parser, start, nesting int, err error) (interface{}, error) {
+	if peek(parser, lexer.EOF) || !recoverable(parser, err) {
+		return nil, err
+	}
+	if err := skipTo(parser, start, nesting, isSelectionStart); err != nil {
+		return nil, err
+	}
+	return ast.NewBadSelection(&ast.BadSelection{
+		Loc: loc(parser, start),
+	}), nil
+}
+
+// skipTo advances past the token at start, if the parser is still there, and then to
+// the first token found at the given nesting for which stop returns true, or to the
+// end of the source.
+func skipTo(parser *Parser, start, nesting int, stop func(parser *Parser) bool) error {
+	for !peek(parser, lexer.EOF) {
+		if parser.Token.Start > start && nestingOf(parser) == nesting && stop(parser) {
+			return nil
+		}
+		if err := advance(parser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nestingOf returns the number of brackets, braces and parentheses open around the
+// current token. A closing token is counted inside the token it closes, leaving out
+// those left open since.
+func nestingOf(parser *Parser) int {
+	kind := parser.Token.Kind
+	switch kind {
+	case lexer.BRACE_R, lexer.BRACKET_R, lexer.PAREN_R:
+		for i := len(parser.open) - 1; i >= 0; i-- {
+			if closing[parser.open[i]] == kind {
+				return i + 1
+			}
+		}
+	}
+	return len(parser.open)
+}
+
+// isDefinitionStart reports whether the current token starts a definition: a keyword,
+// a description, or the selection set of an operation following another definition.
+func isDefinitionStart(parser *Parser) bool {
+	switch parser.Token.Kind {
+	case lexer.NAME:
+		_, ok := tokenDefinitionFn[parser.Token.Value]
+		return ok
+	case lexer.STRING, lexer.BLOCK_STRING:
+		return true
+	case lexer.BRACE_L:
+		return parser.prevKind == lexer.BRACE_R
+	}
+	return false
+}
+
+// isSelectionStart reports whether the current token starts a selection, or ends the
+// selection set.
+func isSelectionStart(parser *Parser) bool {
+	switch parser.Token.Kind {
+	case lexer.NAME, lexer.SPREAD, lexer.BRACE_R:
+		return true
+	}
+	return false
+}
+
+// syntaxErrors returns err, or the errors recorded by a tolerant parse along with err.
+func syntaxErrors(parser *Parser, err error) error {
+	if len(parser.errors) == 0 {
+		return err
+	}
+	if gErr, ok := err.(*gqlerrors.Error); ok && len(gErr.Positions) > 0 {
+		recordError(parser, gErr)
+	} else if err != nil {
+		return err
+	}
+	errs := parser.errors
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Positions[0] < errs[j].Positions[0]
+	})
+	return errs
+}
+
+/* Implements the comments kept with KeepComments. */
+
+// tokenComments are the comments between the end of a token, or -1 at the start of
+// the source, and the start of the next one.
+type tokenComments struct {
+	prevEnd   int
+	nextStart int
+	comments  []lexer.Token
+}
+
+// keepComments keeps the comments before token, which follows the token ending at
+// prevEnd.
+func keepComments(parser *Parser, prevEnd int, token lexer.Token) {
+	if !parser.Options.KeepComments || parser.Options.NoLocation {
+		return
+	}
+	start := prevEnd
+	if start < 0 {
+		start = 0
+	}
+	if comments := lexer.ReadComments(parser.Source, start, token.Start); len(comments) > 0 {
+		parser.comments = append(parser.comments, tokenComments{prevEnd, token.Start, comments})
+	}
+}
+
+// attachComments attaches the kept comments to the outermost nodes of doc starting
+// right after them, or ending right before them. Comments between a block and its
+// first node lead the next node, and those left over trail doc.
+func attachComments(parser *Parser, doc *ast.Document) ast.CommentMap {
+	if len(parser.comments) == 0 {
+		return nil
+	}
+	starting, ending := map[int]ast.Node{}, map[int]ast.Node{}
+	starts := []int{}
+	record := func(node ast.Node, loc *ast.Location) {
+		if _, ok := starting[loc.Start]; !ok {
+			starting[loc.Start] = node
+			starts = append(starts, loc.Start)
+		}
+		if _, ok := ending[loc.End]; !ok {
+			ending[loc.End] = node
+		}
+	}
+	visitor.Walk(doc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			node := c.Node()
+			loc := node.GetLoc()
+			if node == ast.Node(doc) || loc == nil {
+				return visitor.ActionNoChange
+			}
+			// Descriptions aren't walked: comments before them lead the node they
+			// describe, but they may end their line with a comment.
+			if described, ok := node.(ast.DescribableNode); ok {
+				if desc := described.GetDescription(); desc != nil && desc.Loc != nil {
+					record(node, &ast.Location{Start: desc.Loc.Start, End: loc.End})
+					record(desc, desc.Loc)
+				}
+			}
+			record(node, loc)
+			return visitor.ActionNoChange
+		},
+	})
+	sort.Ints(starts)
+
+	commentMap := ast.CommentMap{}
+	trivia := func(node ast.Node) *ast.Trivia {
+		if commentMap[node] == nil {
+			commentMap[node] = &ast.Trivia{}
+		}
+		return commentMap[node]
+	}
+	body := parser.Source.Body
+	for _, kept := range parser.comments {
+		comments := make([]*ast.Comment, len(kept.comments))
+		for i, token := range kept.comments {
+			comments[i] = &ast.Comment{Loc: loc(parser, token.Start), Value: token.Value}
+			comments[i].Loc.End = token.End
+		}
+		if first := kept.comments[0]; kept.prevEnd >= 0 && !bytes.ContainsAny(body[kept.prevEnd:first.Start], "\r\n") {
+			if node, ok := ending[kept.prevEnd]; ok {
+				trivia(node).Inline = comments[0]
+				comments = comments[1:]
+			}
+		}
+		if len(comments) == 0 {
+			continue
+		}
+		if node, ok := starting[kept.nextStart]; ok {
+			trivia(node).Leading = append(trivia(node).Leading, comments...)
+		} else if node, ok := ending[kept.prevEnd]; ok {
+			trivia(node).Trailing = append(trivia(node).Trailing, comments...)
+		} else if i := sort.SearchInts(starts, kept.nextStart); i < len(starts) {
+			node := starting[starts[i]]
+			trivia(node).Leading = append(trivia(node).Leading, comments...)
+		} else {
+			trivia(doc).Trailing = append(trivia(doc).Trailing, comments...)
+		}
+	}
+	return commentMap
+}
@@ -843,3 +843,220 @@ func toError(err error) *gqlerrors.Error {
 		return nil
 	}
 }
+
+func TestParseValue(t *testing.T) {
+	value, err := ParseValue(ParseParams{
+		Source:  `{list: [RED, 1.5, "str"], flag: true}`,
+		Options: ParseOptions{NoLocation: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	object, ok := value.(*ast.ObjectValue)
+	if !ok || len(object.Fields) != 2 {
+		t.Fatalf("unexpected value: %#v", value)
+	}
+	list, ok := object.Fields[0].Value.(*ast.ListValue)
+	if !ok || len(list.Values) != 3 {
+		t.Fatalf("unexpected list value: %#v", object.Fields[0].Value)
+	}
+	if _, ok := list.Values[0].(*ast.EnumValue); !ok {
+		t.Fatalf("expected enum value, got %#v", list.Values[0])
+	}
+
+	_, err = ParseValue(ParseParams{Source: `1 2`})
+	if err == nil || !strings.Contains(err.Error(), `Expected EOF, found Int "2"`) {
+		t.Fatalf("expected trailing tokens to be rejected, got %v", err)
+	}
+}
+
+func TestParseEnforcesLimits(t *testing.T) {
+	tests := []struct {
+		source   string
+		options  ParseOptions
+		expected string
+	}{
+		{`{ a }`, ParseOptions{MaxBytes: 4}, `Syntax Error GraphQL (1:5) Document is larger than 4 bytes.`},
+		{`{ a b c }`, ParseOptions{MaxTokens: 4}, `Syntax Error GraphQL (1:9) Document contains more than 4 tokens.`},
+		{`{ a { b { c } } }`, ParseOptions{MaxSelectionDepth: 2}, `Syntax Error GraphQL (1:9) Selection sets are nested deeper than 2 levels.`},
+		{`{ a(x: [{y: [1]}]) }`, ParseOptions{MaxValueDepth: 2}, `Syntax Error GraphQL (1:13) Values are nested deeper than 2 levels.`},
+		{`query ($x: [[[Int]]]) { a }`, ParseOptions{MaxValueDepth: 2}, `Syntax Error GraphQL (1:14) Values are nested deeper than 2 levels.`},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{Source: test.source, Options: test.options})
+		checkErrorMessage(t, err, test.expected)
+	}
+
+	// Documents within the limits parse.
+	_, err := Parse(ParseParams{
+		Source: `query ($x: [[Int]]) { a(x: [{y: 1}]) { b { c } } }`,
+		Options: ParseOptions{
+			MaxBytes: 50, MaxTokens: 31, MaxSelectionDepth: 3, MaxValueDepth: 2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTolerantParseRecoversFromSyntaxErrors(t *testing.T) {
+	doc, err := Parse(ParseParams{
+		Source: `query A { a(x: ) b ... on { c } d }
+fragment F on T { e ? }
+{ f } }
+query B { g`,
+		Options: ParseOptions{Tolerant: true},
+	})
+	errs, ok := err.(SyntaxErrors)
+	if !ok {
+		t.Fatalf("expected SyntaxErrors, got %#v", err)
+	}
+	expectedLocations := [][]location.SourceLocation{
+		{{Line: 1, Column: 16}},
+		{{Line: 1, Column: 27}},
+		{{Line: 2, Column: 21}},
+		{{Line: 3, Column: 7}},
+		{{Line: 4, Column: 12}},
+	}
+	locations := [][]location.SourceLocation{}
+	for _, err := range errs {
+		locations = append(locations, err.Locations)
+	}
+	if !reflect.DeepEqual(locations, expectedLocations) {
+		t.Fatalf("unexpected error locations %v in:\n%v", locations, err)
+	}
+
+	kinds := []string{}
+	for _, definition := range doc.Definitions {
+		kinds = append(kinds, definition.GetKind())
+	}
+	expectedKinds := []string{"OperationDefinition", "FragmentDefinition", "OperationDefinition", "BadDefinition", "BadDefinition"}
+	if !reflect.DeepEqual(kinds, expectedKinds) {
+		t.Fatalf("unexpected definitions %v", kinds)
+	}
+	selections := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections
+	if len(selections) != 4 || selections[0].(ast.Node).GetKind() != "BadSelection" || selections[2].(ast.Node).GetKind() != "BadSelection" {
+		t.Fatalf("unexpected selections %#v", selections)
+	}
+	if loc := selections[0].(ast.Node).GetLoc(); loc.Start != 10 || loc.End != 16 {
+		t.Fatalf("unexpected location of the bad selection: %v", loc)
+	}
+
+	expected := `query A {
+  b
+  d
+}
+
+fragment F on T {
+  e
+}
+
+{
+  f
+}
+`
+	if printed := printer.Print(doc); printed != expected {
+		t.Fatalf("unexpected document:\n%v", printed)
+	}
+}
+
+func TestParseFailsOnMissingTypes(t *testing.T) {
+	tests := []struct {
+		source, message string
+	}{
+		{`query Q($bar: = 1) { hero { name } }`, `Syntax Error GraphQL (1:15) Expected Name, found =`},
+		{`type T { f: }`, `Syntax Error GraphQL (1:13) Expected Name, found }`},
+		{`query Q($bar: [Int}) { a }`, `Syntax Error GraphQL (1:19) Expected ], found }`},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{Source: test.source})
+		checkErrorMessage(t, err, test.message)
+
+		doc, err := Parse(ParseParams{Source: test.source, Options: ParseOptions{Tolerant: true}})
+		errs, ok := err.(SyntaxErrors)
+		if !ok || len(errs) != 1 {
+			t.Fatalf("expected a single syntax error for %q, got %v", test.source, err)
+		}
+		checkErrorMessage(t, errs[0], test.message)
+		if doc == nil {
+			t.Fatalf("expected a partial document for %q", test.source)
+		}
+	}
+
+	doc, _ := Parse(ParseParams{Source: tests[0].source, Options: ParseOptions{Tolerant: true}})
+	variable := doc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0]
+	if named, ok := variable.Type.(*ast.Named); !ok || named.Name == nil || named.Name.Value != "" {
+		t.Fatalf("expected an unnamed type in place of the missing one, got %#v", variable.Type)
+	}
+	if variable.DefaultValue == nil {
+		t.Fatalf("expected the default value to be parsed")
+	}
+	doc, _ = Parse(ParseParams{Source: tests[1].source, Options: ParseOptions{Tolerant: true}})
+	field := doc.Definitions[0].(*ast.ObjectDefinition).Fields[0]
+	if named, ok := field.Type.(*ast.Named); !ok || named.Name == nil || named.Name.Value != "" {
+		t.Fatalf("expected an unnamed type in place of the missing one, got %#v", field.Type)
+	}
+}
+
+func TestTolerantParseStopsAtLimits(t *testing.T) {
+	doc, err := Parse(ParseParams{
+		Source:  `{ a(x: ) } { b { c } }`,
+		Options: ParseOptions{Tolerant: true, MaxSelectionDepth: 1},
+	})
+	if doc != nil {
+		t.Fatalf("expected no document, got %v", doc)
+	}
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:8) Unexpected )`)
+	if errs, ok := err.(SyntaxErrors); !ok || len(errs) != 2 {
+		t.Fatalf("expected two errors, got %v", err)
+	}
+	checkErrorMessage(t, err.(SyntaxErrors)[1], `Syntax Error GraphQL (1:16) Selection sets are nested deeper than 1 levels.`)
+}
+
+func TestParseKeepsCommentsAttachedToTheNearestNodes(t *testing.T) {
+	doc, err := Parse(ParseParams{
+		Source:  "# query\n{\n  a # inline\n  # trailing\n}\n# end\n",
+		Options: ParseOptions{KeepComments: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	operation := doc.Definitions[0].(*ast.OperationDefinition)
+	field := operation.SelectionSet.Selections[0].(*ast.Field)
+
+	values := func(comments []*ast.Comment) []string {
+		values := []string{}
+		for _, comment := range comments {
+			values = append(values, comment.Value)
+		}
+		return values
+	}
+	trivia := doc.Comments[operation]
+	if trivia == nil || !reflect.DeepEqual(values(trivia.Leading), []string{" query"}) ||
+		!reflect.DeepEqual(values(trivia.Trailing), []string{" end"}) {
+		t.Fatalf("expected the operation to be led and trailed by comments, got %v", trivia)
+	}
+	trivia = doc.Comments[field]
+	if trivia == nil || trivia.Inline == nil || trivia.Inline.Value != " inline" {
+		t.Fatalf("expected the field to have an inline comment, got %v", trivia)
+	}
+	if loc := trivia.Inline.Loc; loc.Start != 14 || loc.End != 22 {
+		t.Fatalf("unexpected location of the inline comment: %v", loc)
+	}
+	if !reflect.DeepEqual(values(trivia.Trailing), []string{" trailing"}) {
+		t.Fatalf("expected the field to be trailed by a comment, got %v", trivia.Trailing)
+	}
+
+	doc, err = Parse(ParseParams{Source: "# nothing but comments", Options: ParseOptions{KeepComments: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trivia := doc.Comments[doc]; trivia == nil || !reflect.DeepEqual(values(trivia.Trailing), []string{" nothing but comments"}) {
+		t.Fatalf("expected the document to be trailed by the comment, got %v", trivia)
+	}
+
+	doc, err = Parse(ParseParams{Source: "# query\n{ a }"})
+	if err != nil || doc.Comments != nil {
+		t.Fatalf("expected comments to be dropped by default, got %v, %v", doc.Comments, err)
+	}
+}
@@ -121,6 +121,89 @@ extend type Hello {
 	}
 }
 
+func TestSchemaParser_EnumExtension(t *testing.T) {
+	body := `extend enum Hello { WORLD }`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 27),
+		Definitions: []ast.Node{
+			ast.NewEnumExtensionDefinition(&ast.EnumExtensionDefinition{
+				Loc: testLoc(0, 27),
+				Definition: ast.NewEnumDefinition(&ast.EnumDefinition{
+					Loc: testLoc(7, 27),
+					Name: ast.NewName(&ast.Name{
+						Value: "Hello",
+						Loc:   testLoc(12, 17),
+					}),
+					Directives: []*ast.Directive{},
+					Values: []*ast.EnumValueDefinition{
+						ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+							Name: ast.NewName(&ast.Name{
+								Value: "WORLD",
+								Loc:   testLoc(20, 25),
+							}),
+							Directives: []*ast.Directive{},
+							Loc:        testLoc(20, 25),
+						}),
+					},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_SchemaExtensionWithoutOperationTypes(t *testing.T) {
+	body := `extend schema @foo`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 18),
+		Definitions: []ast.Node{
+			ast.NewSchemaExtensionDefinition(&ast.SchemaExtensionDefinition{
+				Loc: testLoc(0, 18),
+				Definition: ast.NewSchemaDefinition(&ast.SchemaDefinition{
+					Loc: testLoc(7, 18),
+					Directives: []*ast.Directive{
+						ast.NewDirective(&ast.Directive{
+							Name: ast.NewName(&ast.Name{
+								Value: "foo",
+								Loc:   testLoc(15, 18),
+							}),
+							Arguments: []*ast.Argument{},
+							Loc:       testLoc(14, 18),
+						}),
+					},
+					OperationTypes: []*ast.OperationTypeDefinition{},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_EmptyExtensionsShouldFail(t *testing.T) {
+	for _, body := range []string{
+		`extend schema`,
+		`extend scalar Hello`,
+		`extend type Hello`,
+		`extend interface Hello`,
+		`extend union Hello`,
+		`extend enum Hello`,
+		`extend input Hello`,
+		`extend directive @hello on FIELD`,
+		`"description" extend schema @foo`,
+	} {
+		_, err := Parse(ParseParams{Source: body})
+		if err == nil {
+			t.Fatalf("expected syntax error for %q", body)
+		}
+	}
+}
+
 func TestSchemaParser_Directives(t *testing.T) {
 
 	body := `
@@ -416,6 +499,7 @@ interface Hello {
 					Loc:   testLoc(11, 16),
 				}),
 				Directives: []*ast.Directive{},
+				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
 						Loc: testLoc(21, 34),
@@ -442,6 +526,44 @@ interface Hello {
 	}
 }
 
+func TestSchemaParser_InterfaceInheritingInterfaces(t *testing.T) {
+	body := `interface Hello implements & Wo & rld { }`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 41),
+		Definitions: []ast.Node{
+			ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+				Loc: testLoc(0, 41),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(10, 15),
+				}),
+				Directives: []*ast.Directive{},
+				Interfaces: []*ast.Named{
+					ast.NewNamed(&ast.Named{
+						Name: ast.NewName(&ast.Name{
+							Value: "Wo",
+							Loc:   testLoc(29, 31),
+						}),
+						Loc: testLoc(29, 31),
+					}),
+					ast.NewNamed(&ast.Named{
+						Name: ast.NewName(&ast.Name{
+							Value: "rld",
+							Loc:   testLoc(34, 37),
+						}),
+						Loc: testLoc(34, 37),
+					}),
+				},
+				Fields: []*ast.FieldDefinition{},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
 func TestSchemaParser_SimpleFieldWithArg(t *testing.T) {
 	body := `
 type Hello {
@@ -1,962 +1,984 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
 
-	"reflect"
-
 	"github.com/tailor-inc/graphql/language/ast"
-	"github.com/tailor-inc/graphql/language/visitor"
 )
 
-func getMapValue(m map[string]interface{}, key string) interface{} {
-	tokens := strings.Split(key, ".")
-	valMap := m
-	for _, token := range tokens {
-		v, ok := valMap[token]
-		if !ok {
-			return nil
-		}
-		switch v := v.(type) {
-		case []interface{}:
-			return v
-		case map[string]interface{}:
-			valMap = v
-			continue
-		default:
-			return v
-		}
+// PrintOptions configures Fprint. The zero value prints like Print.
+type PrintOptions struct {
+	// Indent is the number of spaces of each level of indentation, 2 when zero.
+	Indent int
+
+	// Minify prints the node on a single line, with the least whitespace separating
+	// its tokens, as for queries sent over the wire. Descriptions are printed as
+	// strings.
+	Minify bool
+
+	// BlockDescriptions prints descriptions as block strings with the quotes on their
+	// own lines, escaping the triple quotes they contain, rather than putting them on
+	// the lines of the text when it fits on one.
+	BlockDescriptions bool
+
+	// Sort prints type system definitions, and the fields, arguments, enum values,
+	// interfaces and union members they declare, ordered by name, so that the output
+	// doesn't depend on the order of declaration. Schema and directive definitions come
+	// first. Operations, fragments and selections keep their order.
+	Sort bool
+
+	// Comments are the comments printed along with the nodes they are attached to.
+	// When nil, the comments a document was parsed with are printed. Minified output
+	// has no comments.
+	Comments ast.CommentMap
+}
+
+// Print returns the GraphQL source of astNode, which is a string, or nil when astNode
+// can't be printed.
+func Print(astNode ast.Node) (printed interface{}) {
+	if astNode == nil {
+		return nil
+	}
+	var sb strings.Builder
+	if err := Fprint(&sb, astNode, PrintOptions{}); err != nil {
+		return nil
 	}
-	return valMap
+	return sb.String()
 }
-func getMapSliceValue(m map[string]interface{}, key string) []interface{} {
-	tokens := strings.Split(key, ".")
-	valMap := m
-	for _, token := range tokens {
-		v, ok := valMap[token]
-		if !ok {
-			return []interface{}{}
-		}
-		switch v := v.(type) {
-		case []interface{}:
-			return v
-		default:
-			return []interface{}{}
+
+// Fprint writes the GraphQL source of node to w. Nodes are printed straight from their
+// fields, and node types this package doesn't know about, such as the placeholders of
+// tolerant parsing, print nothing.
+func Fprint(w io.Writer, node ast.Node, opts PrintOptions) (err error) {
+	p := &printer{w: w, opts: opts, indent: opts.Indent}
+	if p.indent <= 0 {
+		p.indent = 2
+	}
+	if !opts.Minify {
+		p.comments = opts.Comments
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("printer: unable to print %T: %v", node, r)
 		}
+	}()
+	p.node(node)
+	p.flush()
+	return p.err
+}
+
+// printer buffers the output, indenting every new line by the current level.
+type printer struct {
+	w      io.Writer
+	err    error
+	opts   PrintOptions
+	indent int
+	level  int
+	buf    []byte
+	last   byte
+	// comments are the comments to print. broken is set when a comment ends the
+	// current line, so that the next write starts a new one, and extended while
+	// printing the definition of an extension, whose description is already written.
+	comments ast.CommentMap
+	broken   bool
+	extended bool
+}
+
+const flushSize = 4096
+
+func (p *printer) flush() {
+	if len(p.buf) == 0 || p.err != nil {
+		return
 	}
-	return []interface{}{}
+	_, p.err = p.w.Write(p.buf)
+	p.buf = p.buf[:0]
 }
-func getMapValueString(m map[string]interface{}, key string) string {
-	tokens := strings.Split(key, ".")
-	valMap := m
-	for _, token := range tokens {
-		v, ok := valMap[token]
-		if !ok {
-			return ""
+
+// write writes s, following its newlines with the indentation of the current level.
+func (p *printer) write(s string) {
+	if s == "" {
+		return
+	}
+	if p.broken {
+		p.broken = false
+		switch {
+		case s == " ":
+			s = "\n"
+		case s[0] != '\n':
+			s = "\n" + s
 		}
-		if v == nil {
-			return ""
+	}
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			p.buf = append(p.buf, s...)
+			break
 		}
-		switch v := v.(type) {
-		case map[string]interface{}:
-			valMap = v
-			continue
-		case string:
-			return v
-		default:
-			return fmt.Sprintf("%v", v)
+		p.buf = append(p.buf, s[:i+1]...)
+		for n := p.level * p.indent; n > 0; n-- {
+			p.buf = append(p.buf, ' ')
 		}
+		s = s[i+1:]
+	}
+	p.last = p.buf[len(p.buf)-1]
+	if len(p.buf) >= flushSize {
+		p.flush()
 	}
-	return ""
 }
-func getDescription(raw interface{}) string {
-	var desc string
 
-	switch node := raw.(type) {
-	case ast.DescribableNode:
-		if sval := node.GetDescription(); sval != nil {
-			desc = sval.Value
-		}
-	case map[string]interface{}:
-		desc = getMapValueString(node, "Description.Value")
+// token writes a token. When minifying, it is separated from the previous one by a
+// space only if they would otherwise read as one.
+func (p *printer) token(s string) {
+	if s == "" {
+		return
 	}
-	if desc != "" {
-		sep := ""
-		if strings.ContainsRune(desc, '\n') {
-			sep = "\n"
-		}
-		desc = join([]string{`"""`, desc, `"""`}, sep)
+	if p.opts.Minify && needsSpace(p.last, s[0]) {
+		p.write(" ")
 	}
-	return desc
+	p.write(s)
 }
 
-func toSliceString(slice interface{}) []string {
-	if slice == nil {
-		return []string{}
-	}
-	res := []string{}
-	switch reflect.TypeOf(slice).Kind() {
-	case reflect.Slice:
-		s := reflect.ValueOf(slice)
-		for i := 0; i < s.Len(); i++ {
-			elem := s.Index(i)
-			elemInterface := elem.Interface()
-			if elem, ok := elemInterface.(string); ok {
-				res = append(res, elem)
-			}
-		}
-		return res
-	default:
-		return res
+func needsSpace(last, next byte) bool {
+	switch {
+	case isNameByte(last):
+		return isNameByte(next) || next == '-'
+	case last == '"':
+		return next == '"'
 	}
+	return false
 }
 
-func join(str []string, sep string) string {
-	ss := []string{}
-	// filter out empty strings
-	for _, s := range str {
-		if s == "" {
-			continue
-		}
-		ss = append(ss, s)
+func isNameByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// space writes a space, which minified output does without.
+func (p *printer) space() {
+	if !p.opts.Minify {
+		p.write(" ")
 	}
-	return strings.Join(ss, sep)
 }
 
-func wrap(start, maybeString, end string) string {
-	if maybeString == "" {
-		return maybeString
+// newline starts a new line, which minified output does without.
+func (p *printer) newline() {
+	if !p.opts.Minify {
+		p.write("\n")
 	}
-	return start + maybeString + end
 }
 
-// Given array, print each item on its own line, wrapped in an indented "{ }" block.
-func block(maybeArray interface{}) string {
-	s := toSliceString(maybeArray)
-	if len(s) == 0 {
-		return "{}"
+// punct writes a punctuator followed by a space, such as ": " or ", ".
+func (p *printer) punct(s string) {
+	p.token(s)
+	p.space()
+}
+
+func (p *printer) node(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.Name:
+		p.name(node)
+	case *ast.Document:
+		p.document(node)
+	case *ast.OperationDefinition:
+		p.operationDefinition(node)
+	case *ast.VariableDefinition:
+		p.variableDefinition(node)
+	case *ast.Variable:
+		p.variable(node)
+	case *ast.SelectionSet:
+		p.selectionSet(node)
+	case *ast.Field:
+		p.field(node)
+	case *ast.Argument:
+		p.argument(node)
+	case *ast.FragmentSpread:
+		p.fragmentSpread(node)
+	case *ast.InlineFragment:
+		p.inlineFragment(node)
+	case *ast.FragmentDefinition:
+		p.fragmentDefinition(node)
+	case *ast.IntValue, *ast.FloatValue, *ast.StringValue, *ast.BooleanValue,
+		*ast.EnumValue, *ast.ListValue, *ast.ObjectValue:
+		p.value(node.(ast.Value))
+	case *ast.ObjectField:
+		p.objectField(node)
+	case *ast.Directive:
+		p.directive(node)
+	case *ast.Named, *ast.List, *ast.NonNull:
+		p.typ(node.(ast.Type))
+	case *ast.SchemaDefinition:
+		p.schemaDefinition(node)
+	case *ast.OperationTypeDefinition:
+		p.operationTypeDefinition(node)
+	case *ast.ScalarDefinition:
+		p.scalarDefinition(node)
+	case *ast.ObjectDefinition:
+		p.objectDefinition(node)
+	case *ast.FieldDefinition:
+		p.fieldDefinition(node)
+	case *ast.InputValueDefinition:
+		p.inputValueDefinition(node)
+	case *ast.InterfaceDefinition:
+		p.interfaceDefinition(node)
+	case *ast.UnionDefinition:
+		p.unionDefinition(node)
+	case *ast.EnumDefinition:
+		p.enumDefinition(node)
+	case *ast.EnumValueDefinition:
+		p.enumValueDefinition(node)
+	case *ast.InputObjectDefinition:
+		p.inputObjectDefinition(node)
+	case *ast.DirectiveDefinition:
+		p.directiveDefinition(node)
+	case *ast.SchemaExtensionDefinition:
+		p.extend(node, nil)
+		p.schemaDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.ScalarExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.scalarDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.TypeExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.objectDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.InterfaceExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.interfaceDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.UnionExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.unionDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.EnumExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.enumDefinition(node.Definition)
+		p.trailing(node)
+	case *ast.InputObjectExtensionDefinition:
+		p.extend(node, node.Definition.Description)
+		p.inputObjectDefinition(node.Definition)
+		p.trailing(node)
 	}
-	return indent("{\n"+join(s, "\n")) + "\n}"
 }
 
-func indent(maybeString interface{}) string {
-	if maybeString == nil {
+func nameOf(name *ast.Name) string {
+	if name == nil {
 		return ""
 	}
-	switch str := maybeString.(type) {
-	case string:
-		return strings.Replace(str, "\n", "\n  ", -1)
-	}
-	return ""
+	return name.Value
 }
 
-var printDocASTReducer = map[string]visitor.VisitFunc{
-	"Name": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Name:
-			return visitor.ActionUpdate, node.Value
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValue(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"Variable": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Variable:
-			return visitor.ActionUpdate, fmt.Sprintf("$%v", node.Name)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "$" + getMapValueString(node, "Name")
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Document
-	"Document": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Document:
-			definitions := toSliceString(node.Definitions)
-			return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
-		case map[string]interface{}:
-			definitions := toSliceString(getMapValue(node, "Definitions"))
-			return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
+func (p *printer) name(name *ast.Name) {
+	p.leading(name)
+	p.token(nameOf(name))
+	p.trailing(name)
+}
+
+func (p *printer) document(node *ast.Document) {
+	if p.comments == nil && !p.opts.Minify {
+		p.comments = node.Comments
+	}
+	definitions := node.Definitions
+	if p.opts.Sort {
+		definitions = sortDefinitions(definitions)
+	}
+	first := true
+	for _, definition := range definitions {
+		if _, bad := definition.(*ast.BadDefinition); bad || definition == nil {
+			continue
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"OperationDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.OperationDefinition:
-			op := string(node.Operation)
-			name := fmt.Sprintf("%v", node.Name)
-
-			varDefs := wrap("(", join(toSliceString(node.VariableDefinitions), ", "), ")")
-			directives := join(toSliceString(node.Directives), " ")
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			// Anonymous queries with no directives or variable definitions can use
-			// the query short form.
-			str := ""
-			if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
-				str = selectionSet
-			} else {
-				str = join([]string{
-					op,
-					join([]string{name, varDefs}, ""),
-					directives,
-					selectionSet,
-				}, " ")
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-
-			op := getMapValueString(node, "Operation")
-			name := getMapValueString(node, "Name")
-
-			varDefs := wrap("(", join(toSliceString(getMapValue(node, "VariableDefinitions")), ", "), ")")
-			directives := join(toSliceString(getMapValue(node, "Directives")), " ")
-			selectionSet := getMapValueString(node, "SelectionSet")
-			str := ""
-			if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
-				str = selectionSet
-			} else {
-				str = join([]string{
-					op,
-					join([]string{name, varDefs}, ""),
-					directives,
-					selectionSet,
-				}, " ")
-			}
-			return visitor.ActionUpdate, str
+		if !first {
+			p.newline()
+			p.newline()
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"VariableDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.VariableDefinition:
-			variable := fmt.Sprintf("%v", node.Variable)
-			ttype := fmt.Sprintf("%v", node.Type)
-			defaultValue := fmt.Sprintf("%v", node.DefaultValue)
-
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
-		case map[string]interface{}:
+		first = false
+		p.node(definition)
+	}
+	p.trailing(node)
+	p.newline()
+}
 
-			variable := getMapValueString(node, "Variable")
-			ttype := getMapValueString(node, "Type")
-			defaultValue := getMapValueString(node, "DefaultValue")
+func (p *printer) operationDefinition(node *ast.OperationDefinition) {
+	p.leading(node)
+	defer p.trailing(node)
+	name := nameOf(node.Name)
+	if name == "" && len(node.VariableDefinitions) == 0 && len(node.Directives) == 0 &&
+		node.Operation == ast.OperationTypeQuery {
+		p.selectionSet(node.SelectionSet)
+		return
+	}
+	p.token(node.Operation)
+	if name != "" || len(node.VariableDefinitions) > 0 {
+		p.space()
+		p.token(name)
+		if len(node.VariableDefinitions) > 0 {
+			multiline := false
+			for _, definition := range node.VariableDefinitions {
+				multiline = multiline || p.comments[definition] != nil
+			}
+			p.list(len(node.VariableDefinitions), multiline, func(i int) {
+				p.variableDefinition(node.VariableDefinitions[i])
+			})
+		}
+	}
+	p.spacedDirectives(node.Directives)
+	if node.SelectionSet != nil {
+		p.space()
+		p.selectionSet(node.SelectionSet)
+	}
+}
 
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
+func (p *printer) variableDefinition(node *ast.VariableDefinition) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.variable(node.Variable)
+	p.punct(":")
+	p.typ(node.Type)
+	if node.DefaultValue != nil {
+		p.space()
+		p.punct("=")
+		p.value(node.DefaultValue)
+	}
+}
 
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"SelectionSet": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.SelectionSet:
-			str := block(node.Selections)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			selections := getMapValue(node, "Selections")
-			str := block(selections)
-			return visitor.ActionUpdate, str
+func (p *printer) variable(node *ast.Variable) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token("$")
+	p.name(node.Name)
+}
 
+func (p *printer) selectionSet(node *ast.SelectionSet) {
+	if node == nil {
+		return
+	}
+	p.leading(node)
+	defer p.trailing(node)
+	n := 0
+	for _, selection := range node.Selections {
+		if printable(selection) {
+			n++
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"Field": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Argument:
-			name := fmt.Sprintf("%v", node.Name)
-			value := fmt.Sprintf("%v", node.Value)
-			return visitor.ActionUpdate, name + ": " + value
-		case map[string]interface{}:
-
-			alias := getMapValueString(node, "Alias")
-			name := getMapValueString(node, "Name")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
-
-			str := join(
-				[]string{
-					wrap("", alias, ": ") + name + wrap("(", join(args, ", "), ")"),
-					join(directives, " "),
-					selectionSet,
-				},
-				" ",
-			)
-			return visitor.ActionUpdate, str
+	}
+	p.blockStart(n)
+	for _, selection := range node.Selections {
+		if !printable(selection) {
+			continue
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"Argument": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
+		p.newline()
+		switch selection := selection.(type) {
+		case *ast.Field:
+			p.field(selection)
 		case *ast.FragmentSpread:
-			name := fmt.Sprintf("%v", node.Name)
-			directives := toSliceString(node.Directives)
-			return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			value := getMapValueString(node, "Value")
-			return visitor.ActionUpdate, name + ": " + value
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Fragments
-	"FragmentSpread": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
+			p.fragmentSpread(selection)
 		case *ast.InlineFragment:
-			typeCondition := fmt.Sprintf("%v", node.TypeCondition)
-			directives := toSliceString(node.Directives)
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			return visitor.ActionUpdate, "... on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"InlineFragment": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case map[string]interface{}:
-			typeCondition := getMapValueString(node, "TypeCondition")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
-			return visitor.ActionUpdate,
-				join([]string{
-					"...",
-					wrap("on ", typeCondition, ""),
-					join(directives, " "),
-					selectionSet,
-				}, " ")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"FragmentDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FragmentDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			typeCondition := fmt.Sprintf("%v", node.TypeCondition)
-			directives := toSliceString(node.Directives)
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			typeCondition := getMapValueString(node, "TypeCondition")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Value
-	"IntValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.IntValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"FloatValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FloatValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
+			p.inlineFragment(selection)
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.StringValue:
-			return visitor.ActionUpdate, strconv.Quote(node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, `"` + getMapValueString(node, "Value") + `"`
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"BooleanValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.BooleanValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ListValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ListValue:
-			return visitor.ActionUpdate, "[" + join(toSliceString(node.Values), ", ") + "]"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "[" + join(toSliceString(getMapValue(node, "Values")), ", ") + "]"
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectValue:
-			return visitor.ActionUpdate, "{" + join(toSliceString(node.Fields), ", ") + "}"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "{" + join(toSliceString(getMapValue(node, "Fields")), ", ") + "}"
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectField": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectField:
-			name := fmt.Sprintf("%v", node.Name)
-			value := fmt.Sprintf("%v", node.Value)
-			return visitor.ActionUpdate, name + ": " + value
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			value := getMapValueString(node, "Value")
-			return visitor.ActionUpdate, name + ": " + value
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Directive
-	"Directive": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Directive:
-			name := fmt.Sprintf("%v", node.Name)
-			args := toSliceString(node.Arguments)
-			return visitor.ActionUpdate, "@" + name + wrap("(", join(args, ", "), ")")
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			return visitor.ActionUpdate, "@" + name + wrap("(", join(args, ", "), ")")
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Type
-	"Named": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Named:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Name)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Name")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"List": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.List:
-			return visitor.ActionUpdate, "[" + fmt.Sprintf("%v", node.Type) + "]"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "[" + getMapValueString(node, "Type") + "]"
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"NonNull": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.NonNull:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Type) + "!"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Type") + "!"
-		}
-		return visitor.ActionNoChange, nil
-	},
-
-	// Type System Definitions
-	"SchemaDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.SchemaDefinition:
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"schema",
-				join(directives, " "),
-				block(node.OperationTypes),
-			}, " ")
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			operationTypes := toSliceString(getMapValue(node, "OperationTypes"))
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"schema",
-				join(directives, " "),
-				block(operationTypes),
-			}, " ")
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"OperationTypeDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.OperationTypeDefinition:
-			str := fmt.Sprintf("%v: %v", node.Operation, node.Type)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			operation := getMapValueString(node, "Operation")
-			ttype := getMapValueString(node, "Type")
-			str := fmt.Sprintf("%v: %v", operation, ttype)
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ScalarDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ScalarDefinition:
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"scalar",
-				fmt.Sprintf("%v", node.Name),
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"scalar",
-				name,
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			interfaces := toSliceString(node.Interfaces)
-			fields := node.Fields
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"type",
-				name,
-				wrap("implements ", join(interfaces, " & "), ""),
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			interfaces := toSliceString(getMapValue(node, "Interfaces"))
-			fields := getMapValue(node, "Fields")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"type",
-				name,
-				wrap("implements ", join(interfaces, " & "), ""),
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"FieldDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FieldDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			ttype := fmt.Sprintf("%v", node.Type)
-			args := toSliceString(node.Arguments)
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			hasArgDesc := false
-			for _, arg := range node.Arguments {
-				if arg.Description != nil && arg.Description.Value != "" {
-					hasArgDesc = true
-					break
-				}
-			}
-			var argsStr string
-			if hasArgDesc {
-				argsStr = wrap("(", indent("\n"+join(args, "\n")), "\n)")
-			} else {
-				argsStr = wrap("(", join(args, ", "), ")")
-			}
-			str := name + argsStr + ": " + ttype + wrap(" ", join(directives, " "), "")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			ttype := getMapValueString(node, "Type")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			hasArgDesc := false
-			for _, arg := range args {
-				if strings.HasPrefix(strings.TrimSpace(arg), `"""`) {
-					hasArgDesc = true
-					break
-				}
-			}
-			var argsStr string
-			if hasArgDesc {
-				argsStr = wrap("(", indent("\n"+join(args, "\n")), "\n)")
-			} else {
-				argsStr = wrap("(", join(args, ", "), ")")
-			}
-			str := name + argsStr + ": " + ttype + wrap(" ", join(directives, " "), "")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"InputValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InputValueDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			ttype := fmt.Sprintf("%v", node.Type)
-			defaultValue := fmt.Sprintf("%v", node.DefaultValue)
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				name + ": " + ttype,
-				wrap("= ", defaultValue, ""),
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			ttype := getMapValueString(node, "Type")
-			defaultValue := getMapValueString(node, "DefaultValue")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				name + ": " + ttype,
-				wrap("= ", defaultValue, ""),
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"InterfaceDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InterfaceDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			fields := node.Fields
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"interface",
-				name,
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			fields := getMapValue(node, "Fields")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"interface",
-				name,
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"UnionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.UnionDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			types := toSliceString(node.Types)
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"union",
-				name,
-				join(directives, " "),
-				"= " + join(types, " | "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			types := toSliceString(getMapValue(node, "Types"))
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"union",
-				name,
-				join(directives, " "),
-				"= " + join(types, " | "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			values := node.Values
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"enum",
-				name,
-				join(directives, " "),
-				block(values),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			values := getMapValue(node, "Values")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"enum",
-				name,
-				join(directives, " "),
-				block(values),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumValueDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				name,
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				name,
-				join(directives, " "),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("\n%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"InputObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InputObjectDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			fields := node.Fields
-			directives := []string{}
-			for _, directive := range node.Directives {
-				directives = append(directives, fmt.Sprintf("%v", directive.Name))
-			}
-			str := join([]string{
-				"input",
-				name,
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			fields := getMapValue(node, "Fields")
-			directives := []string{}
-			for _, directive := range getMapSliceValue(node, "Directives") {
-				directives = append(directives, fmt.Sprintf("%v", directive))
-			}
-			str := join([]string{
-				"input",
-				name,
-				join(directives, " "),
-				block(fields),
-			}, " ")
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
+	}
+	p.blockEnd(n)
+}
+
+// printable reports whether selection is printed, unlike nil and bad selections.
+func printable(selection ast.Selection) bool {
+	_, bad := selection.(*ast.BadSelection)
+	return !bad && selection != nil
+}
+
+// blockStart opens a block of n items, each printed on its own line after a newline.
+func (p *printer) blockStart(n int) {
+	p.token("{")
+	if n > 0 {
+		p.level++
+	}
+}
+
+func (p *printer) blockEnd(n int) {
+	if n > 0 {
+		p.level--
+		p.newline()
+	}
+	p.token("}")
+}
+
+func (p *printer) field(node *ast.Field) {
+	p.leading(node)
+	defer p.trailing(node)
+	if nameOf(node.Alias) != "" {
+		p.name(node.Alias)
+		p.punct(":")
+	}
+	p.name(node.Name)
+	p.arguments(node.Arguments)
+	p.spacedDirectives(node.Directives)
+	if node.SelectionSet != nil {
+		p.space()
+		p.selectionSet(node.SelectionSet)
+	}
+}
+
+func (p *printer) arguments(arguments []*ast.Argument) {
+	if len(arguments) == 0 {
+		return
+	}
+	multiline := false
+	for _, argument := range arguments {
+		multiline = multiline || p.comments[argument] != nil
+	}
+	p.list(len(arguments), multiline, func(i int) {
+		p.argument(arguments[i])
+	})
+}
+
+// list writes the n items of a list of arguments or variables on one line, or each on
+// its own line when multiline, as when they have comments.
+func (p *printer) list(n int, multiline bool, item func(i int)) {
+	p.token("(")
+	if multiline {
+		p.level++
+	}
+	for i := 0; i < n; i++ {
+		switch {
+		case multiline:
+			p.newline()
+		case i > 0:
+			p.punct(",")
+		}
+		item(i)
+	}
+	if multiline {
+		p.level--
+		p.newline()
+	}
+	p.token(")")
+}
+
+func (p *printer) argument(node *ast.Argument) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.name(node.Name)
+	p.punct(":")
+	p.value(node.Value)
+}
+
+func (p *printer) fragmentSpread(node *ast.FragmentSpread) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token("...")
+	p.name(node.Name)
+	p.spacedDirectives(node.Directives)
+}
+
+func (p *printer) inlineFragment(node *ast.InlineFragment) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token("...")
+	if node.TypeCondition != nil {
+		p.space()
+		p.token("on")
+		p.space()
+		p.typ(node.TypeCondition)
+	}
+	p.spacedDirectives(node.Directives)
+	if node.SelectionSet != nil {
+		p.space()
+		p.selectionSet(node.SelectionSet)
+	}
+}
+
+func (p *printer) fragmentDefinition(node *ast.FragmentDefinition) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token("fragment")
+	p.space()
+	p.name(node.Name)
+	p.space()
+	p.token("on")
+	p.space()
+	if node.TypeCondition != nil {
+		p.typ(node.TypeCondition)
+	}
+	p.spacedDirectives(node.Directives)
+	p.space()
+	p.selectionSet(node.SelectionSet)
+}
+
+func (p *printer) value(node ast.Value) {
+	p.leading(node)
+	defer p.trailing(node)
+	switch node := node.(type) {
+	case *ast.Variable:
+		p.variable(node)
+	case *ast.IntValue:
+		p.token(node.Value)
+	case *ast.FloatValue:
+		p.token(node.Value)
+	case *ast.StringValue:
+		p.token(strconv.Quote(node.Value))
+	case *ast.BooleanValue:
+		p.token(strconv.FormatBool(node.Value))
+	case *ast.EnumValue:
+		p.token(node.Value)
+	case *ast.ListValue:
+		p.token("[")
+		for i, value := range node.Values {
+			if i > 0 {
+				p.punct(",")
+			}
+			p.value(value)
+		}
+		p.token("]")
+	case *ast.ObjectValue:
+		p.token("{")
+		for i, field := range node.Fields {
+			if i > 0 {
+				p.punct(",")
+			}
+			p.objectField(field)
+		}
+		p.token("}")
+	}
+}
+
+func (p *printer) objectField(node *ast.ObjectField) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.name(node.Name)
+	p.punct(":")
+	p.value(node.Value)
+}
+
+func (p *printer) directive(node *ast.Directive) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token("@")
+	p.name(node.Name)
+	p.arguments(node.Arguments)
+}
+
+// spacedDirectives writes directives, each preceded by a space.
+func (p *printer) spacedDirectives(directives []*ast.Directive) {
+	for _, directive := range directives {
+		p.space()
+		p.directive(directive)
+	}
+}
+
+func (p *printer) typ(node ast.Type) {
+	p.leading(node)
+	defer p.trailing(node)
+	switch node := node.(type) {
+	case *ast.Named:
+		p.name(node.Name)
+	case *ast.List:
+		p.token("[")
+		p.typ(node.Type)
+		p.token("]")
+	case *ast.NonNull:
+		p.typ(node.Type)
+		p.token("!")
+	}
+}
+
+func (p *printer) schemaDefinition(node *ast.SchemaDefinition) {
+	p.describe(node, nil, false)
+	defer p.trailing(node)
+	p.token("schema")
+	p.spacedDirectives(node.Directives)
+	p.space()
+	p.blockStart(len(node.OperationTypes))
+	for _, operationType := range node.OperationTypes {
+		p.newline()
+		p.operationTypeDefinition(operationType)
+	}
+	p.blockEnd(len(node.OperationTypes))
+}
+
+func (p *printer) operationTypeDefinition(node *ast.OperationTypeDefinition) {
+	p.leading(node)
+	defer p.trailing(node)
+	p.token(node.Operation)
+	p.punct(":")
+	p.typ(node.Type)
+}
+
+// describe writes the leading comments and the description of a definition, the
+// description followed by a newline, or surrounded by newlines when it is the
+// description of a member. The description of the definition of an extension is
+// written by extend.
+func (p *printer) describe(node ast.Node, description *ast.StringValue, member bool) {
+	if p.extended {
+		p.extended = false
+		p.leading(node)
+		return
+	}
+	described := description != nil && description.Value != ""
+	if described && member {
+		p.newline()
+	}
+	p.leading(node)
+	if described {
+		p.description(description)
+	}
+}
+
+func (p *printer) description(description *ast.StringValue) {
+	desc := description.Value
+	switch {
+	case p.opts.Minify:
+		p.token(strconv.Quote(desc))
+	case p.opts.BlockDescriptions:
+		p.write(`"""` + "\n" + strings.Replace(desc, `"""`, `\"""`, -1) + "\n" + `"""`)
+	case strings.Contains(desc, "\n"):
+		p.write(`"""` + "\n" + desc + "\n" + `"""`)
+	default:
+		p.write(`"""` + desc + `"""`)
+	}
+	p.trailing(description)
+	p.newline()
+}
+
+// leading writes the comments on the lines before node, each on its own line. Groups
+// of comments separated by blank lines, from each other or from node, stay separated
+// by one.
+func (p *printer) leading(node ast.Node) {
+	if p.comments == nil {
+		return
+	}
+	trivia := p.comments[node]
+	if trivia == nil {
+		return
+	}
+	for i, comment := range trivia.Leading {
+		if i > 0 && blankLine(trivia.Leading[i-1].Loc, comment.Loc) {
+			p.newline()
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"TypeExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		commentDelim := `"""`
-		addExtension := func(definition string) string {
-			if strings.HasPrefix(definition, commentDelim) {
-				if sp := strings.Split(definition, commentDelim); len(sp) >= 3 {
-					return commentDelim + sp[1] + commentDelim + "\nextend " + strings.TrimLeft(strings.Join(sp[2:], commentDelim), "\n")
-				}
-			}
-			return "extend " + definition
+		p.write("#" + comment.Value)
+		p.write("\n")
+	}
+	if n := len(trivia.Leading); n > 0 && blankLine(trivia.Leading[n-1].Loc, start(node)) {
+		p.newline()
+	}
+}
+
+// trailing writes the comment ending the line of node, and the comments on the lines
+// after it. The line is then broken before anything else is written.
+func (p *printer) trailing(node ast.Node) {
+	if p.comments == nil {
+		return
+	}
+	trivia := p.comments[node]
+	if trivia == nil {
+		return
+	}
+	if trivia.Inline != nil {
+		p.write(" #" + trivia.Inline.Value)
+		p.broken = true
+	}
+	for i, comment := range trivia.Trailing {
+		if i > 0 && blankLine(trivia.Trailing[i-1].Loc, comment.Loc) {
+			p.write("\n")
 		}
-		switch node := p.Node.(type) {
-		case *ast.TypeExtensionDefinition:
-			definition := fmt.Sprintf("%v", node.Definition)
-			str := addExtension(definition)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			definition := getMapValueString(node, "Definition")
-			str := addExtension(definition)
-			return visitor.ActionUpdate, str
+		p.write("\n#" + comment.Value)
+		p.broken = true
+	}
+}
+
+// blankLine reports whether the source has a blank line between the locations a and b.
+func blankLine(a, b *ast.Location) bool {
+	if a == nil || b == nil || a.Source == nil || a.Source != b.Source || a.End > b.Start || b.Start > len(a.Source.Body) {
+		return false
+	}
+	return bytes.Count(a.Source.Body[a.End:b.Start], []byte("\n")) > 1
+}
+
+// start returns the location of node, starting at its description when it has one.
+func start(node ast.Node) *ast.Location {
+	if described, ok := node.(ast.DescribableNode); ok {
+		if description := described.GetDescription(); description != nil && description.Loc != nil {
+			return description.Loc
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"DirectiveDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
+	}
+	return node.GetLoc()
+}
+
+// keyword writes the keyword and name starting a type definition.
+func (p *printer) keyword(keyword string, name *ast.Name) {
+	p.token(keyword)
+	if nameOf(name) != "" {
+		p.space()
+		p.name(name)
+	}
+}
+
+func (p *printer) scalarDefinition(node *ast.ScalarDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("scalar", node.Name)
+	p.spacedDirectives(node.Directives)
+}
+
+func (p *printer) objectDefinition(node *ast.ObjectDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("type", node.Name)
+	p.implements(node.Interfaces)
+	p.spacedDirectives(node.Directives)
+	p.space()
+	p.fieldDefinitions(node.Fields)
+}
+
+func (p *printer) interfaceDefinition(node *ast.InterfaceDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("interface", node.Name)
+	p.implements(node.Interfaces)
+	p.spacedDirectives(node.Directives)
+	p.space()
+	p.fieldDefinitions(node.Fields)
+}
+
+func (p *printer) implements(interfaces []*ast.Named) {
+	if len(interfaces) == 0 {
+		return
+	}
+	if p.opts.Sort {
+		interfaces = sortNamed(interfaces)
+	}
+	p.space()
+	p.token("implements")
+	p.space()
+	for i, named := range interfaces {
+		if i > 0 {
+			p.space()
+			p.punct("&")
+		}
+		p.typ(named)
+	}
+}
+
+func (p *printer) fieldDefinitions(fields []*ast.FieldDefinition) {
+	if p.opts.Sort {
+		fields = append([]*ast.FieldDefinition{}, fields...)
+		sort.SliceStable(fields, func(i, j int) bool {
+			return nameOf(fields[i].Name) < nameOf(fields[j].Name)
+		})
+	}
+	p.blockStart(len(fields))
+	for _, field := range fields {
+		p.newline()
+		p.fieldDefinition(field)
+	}
+	p.blockEnd(len(fields))
+}
+
+func (p *printer) fieldDefinition(node *ast.FieldDefinition) {
+	p.describe(node, node.Description, true)
+	defer p.trailing(node)
+	p.name(node.Name)
+	p.argumentDefinitions(node.Arguments)
+	p.punct(":")
+	p.typ(node.Type)
+	p.spacedDirectives(node.Directives)
+}
+
+// argumentDefinitions writes arguments on one line, or each on its own line when any
+// of them has a description or comments.
+func (p *printer) argumentDefinitions(arguments []*ast.InputValueDefinition) {
+	if len(arguments) == 0 {
+		return
+	}
+	if p.opts.Sort {
+		arguments = sortInputValues(arguments)
+	}
+	multiline := false
+	for _, argument := range arguments {
+		described := argument.Description != nil && argument.Description.Value != ""
+		multiline = multiline || described || p.comments[argument] != nil
+	}
+	p.list(len(arguments), multiline, func(i int) {
+		p.inputValueDefinition(arguments[i])
+	})
+}
+
+func (p *printer) inputValueDefinition(node *ast.InputValueDefinition) {
+	p.describe(node, node.Description, true)
+	defer p.trailing(node)
+	p.name(node.Name)
+	p.punct(":")
+	p.typ(node.Type)
+	if node.DefaultValue != nil {
+		p.space()
+		p.punct("=")
+		p.value(node.DefaultValue)
+	}
+	p.spacedDirectives(node.Directives)
+}
+
+func (p *printer) unionDefinition(node *ast.UnionDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("union", node.Name)
+	p.spacedDirectives(node.Directives)
+	types := node.Types
+	if len(types) == 0 {
+		return
+	}
+	if p.opts.Sort {
+		types = sortNamed(types)
+	}
+	p.space()
+	p.punct("=")
+	for i, named := range types {
+		if i > 0 {
+			p.space()
+			p.punct("|")
+		}
+		p.typ(named)
+	}
+}
+
+func (p *printer) enumDefinition(node *ast.EnumDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("enum", node.Name)
+	p.spacedDirectives(node.Directives)
+	p.space()
+	values := node.Values
+	if p.opts.Sort {
+		values = append([]*ast.EnumValueDefinition{}, values...)
+		sort.SliceStable(values, func(i, j int) bool {
+			return nameOf(values[i].Name) < nameOf(values[j].Name)
+		})
+	}
+	p.blockStart(len(values))
+	for _, value := range values {
+		p.newline()
+		p.enumValueDefinition(value)
+	}
+	p.blockEnd(len(values))
+}
+
+func (p *printer) enumValueDefinition(node *ast.EnumValueDefinition) {
+	p.describe(node, node.Description, true)
+	defer p.trailing(node)
+	p.name(node.Name)
+	p.spacedDirectives(node.Directives)
+}
+
+func (p *printer) inputObjectDefinition(node *ast.InputObjectDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.keyword("input", node.Name)
+	p.spacedDirectives(node.Directives)
+	p.space()
+	fields := node.Fields
+	if p.opts.Sort {
+		fields = sortInputValues(fields)
+	}
+	p.blockStart(len(fields))
+	for _, field := range fields {
+		p.newline()
+		p.inputValueDefinition(field)
+	}
+	p.blockEnd(len(fields))
+}
+
+func (p *printer) directiveDefinition(node *ast.DirectiveDefinition) {
+	p.describe(node, node.Description, false)
+	defer p.trailing(node)
+	p.token("directive")
+	p.space()
+	p.token("@")
+	p.name(node.Name)
+	p.argumentDefinitions(node.Arguments)
+	if node.Repeatable {
+		p.space()
+		p.token("repeatable")
+	}
+	p.space()
+	p.token("on")
+	p.space()
+	for i, location := range node.Locations {
+		if i > 0 {
+			p.space()
+			p.punct("|")
+		}
+		p.name(location)
+	}
+}
+
+// extend writes the leading comments of an extension and "extend", keeping the
+// description of the extended definition in front of it.
+func (p *printer) extend(node ast.Node, description *ast.StringValue) {
+	p.leading(node)
+	if description != nil && description.Value != "" {
+		p.description(description)
+	}
+	p.token("extend")
+	p.space()
+	p.extended = true
+}
+
+// sortDefinitions returns definitions ordered for PrintOptions.Sort: executable
+// definitions in their order, then schema definitions and extensions, then directive
+// definitions by name, then type definitions and extensions by name.
+func sortDefinitions(definitions []ast.Node) []ast.Node {
+	type key struct {
+		group int
+		name  string
+	}
+	keyOf := func(definition ast.Node) key {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition, *ast.FragmentDefinition:
+			return key{0, ""}
+		case *ast.SchemaDefinition, *ast.SchemaExtensionDefinition:
+			return key{1, ""}
 		case *ast.DirectiveDefinition:
-			args := toSliceString(node.Arguments)
-			hasArgDesc := false
-			for _, arg := range node.Arguments {
-				if arg.Description != nil && arg.Description.Value != "" {
-					hasArgDesc = true
-					break
-				}
-			}
-			var argsStr string
-			if hasArgDesc {
-				argsStr = wrap("(", indent("\n"+join(args, "\n")), "\n)")
-			} else {
-				argsStr = wrap("(", join(args, ", "), ")")
-			}
-			str := fmt.Sprintf("directive @%v%v on %v", node.Name, argsStr, join(toSliceString(node.Locations), " | "))
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			locations := toSliceString(getMapValue(node, "Locations"))
-			args := toSliceString(getMapValue(node, "Arguments"))
-			hasArgDesc := false
-			for _, arg := range args {
-				if strings.HasPrefix(strings.TrimSpace(arg), `"""`) {
-					hasArgDesc = true
-					break
-				}
-			}
-			var argsStr string
-			if hasArgDesc {
-				argsStr = wrap("(", indent("\n"+join(args, "\n")), "\n)")
-			} else {
-				argsStr = wrap("(", join(args, ", "), ")")
-			}
-			str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
-			if desc := getDescription(node); desc != "" {
-				str = fmt.Sprintf("%s\n%s", desc, str)
-			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
+			return key{2, nameOf(definition.Name)}
+		case interface{ GetName() *ast.Name }:
+			return key{3, nameOf(definition.GetName())}
+		case *ast.ScalarExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		case *ast.TypeExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		case *ast.InterfaceExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		case *ast.UnionExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		case *ast.EnumExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		case *ast.InputObjectExtensionDefinition:
+			return key{3, nameOf(definition.Definition.Name)}
+		}
+		return key{}
+	}
+	sorted := append([]ast.Node{}, definitions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := keyOf(sorted[i]), keyOf(sorted[j])
+		if a.group != b.group {
+			return a.group < b.group
+		}
+		return a.name < b.name
+	})
+	return sorted
 }
 
-func Print(astNode ast.Node) (printed interface{}) {
-	defer func() interface{} {
-		if r := recover(); r != nil {
-			return fmt.Sprintf("%v", astNode)
-		}
-		return printed
-	}()
-	printed = visitor.Visit(astNode, &visitor.VisitorOptions{
-		LeaveKindMap: printDocASTReducer,
-	}, nil)
-	return printed
+func sortNamed(list []*ast.Named) []*ast.Named {
+	sorted := append([]*ast.Named{}, list...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return nameOf(sorted[i].Name) < nameOf(sorted[j].Name)
+	})
+	return sorted
+}
+
+func sortInputValues(list []*ast.InputValueDefinition) []*ast.InputValueDefinition {
+	sorted := append([]*ast.InputValueDefinition{}, list...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return nameOf(sorted[i].Name) < nameOf(sorted[j].Name)
+	})
+	return sorted
 }
@@ -3,6 +3,7 @@ package printer_test
 import (
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tailor-inc/graphql/language/ast"
@@ -200,3 +201,119 @@ func TestPrinter_CorrectlyPrintsStringArgumentsWithProperQuoting(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+func TestFprint_MinifiesQueries(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc := parse(t, string(b))
+
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, astDoc, printer.PrintOptions{Minify: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `query namedQuery($foo:ComplexFooType,$bar:Bar=DefaultBarValue){customUser:user(id:[987,654]){id...on User@defer{field2{id alias:field1(first:10,after:$foo)@include(if:$foo){id...frag}}}...@skip(unless:$foo){id}...{id}}}` +
+		`mutation favPost{fav(post:123)@defer{post{id}}}` +
+		`subscription PostFavSubscription($input:StoryLikeSubscribeInput){postFavSubscribe(input:$input){post{favers{count}favSentence{text}}}}` +
+		`fragment frag on Follower{foo(size:$size,bar:$b,obj:{key:"value"})}` +
+		`{unnamed(truthyVal:true,falseyVal:false)query}`
+	if sb.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, sb.String()))
+	}
+
+	// The minified document reads as the original one.
+	if printed, expected := printer.Print(parse(t, sb.String())), printer.Print(astDoc); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestFprint_IndentsByTheIndentWidth(t *testing.T) {
+	astDoc := parse(t, `{ a { b } }`)
+
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, astDoc, printer.PrintOptions{Indent: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{
+    a {
+        b
+    }
+}
+`
+	if sb.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, sb.String()))
+	}
+}
+
+func TestPrinter_PrintsKeptComments(t *testing.T) {
+	source := `# header
+
+# detached
+"""A""" # described
+type A implements B {
+  # lead
+  a(
+    # before x
+    x: Int # x
+  ): Int # a
+  # end
+}
+
+# extension
+extend type A @d {} # d
+
+# query
+query Q(
+  # after paren
+  $a: Int # a
+  $b: Int
+) {
+  # lead
+  a: b(
+    x: 1 # x
+    y: $b
+  ) # b
+  ...F # spread
+  # last
+}
+# tail
+
+# detached tail
+`
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source:  source,
+		Options: parser.ParseOptions{KeepComments: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if printed := printer.Print(astDoc); printed != source {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(source, printed))
+	}
+
+	// Minified output drops the comments.
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, astDoc.Definitions[0], printer.PrintOptions{Minify: true, Comments: astDoc.Comments}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `"A"type A implements B{a(x:Int):Int}`; sb.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, sb.String()))
+	}
+}
+
+func BenchmarkPrint(b *testing.B) {
+	source, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		b.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc, err := parser.Parse(parser.ParseParams{Source: string(source)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		printer.Print(astDoc)
+	}
+}
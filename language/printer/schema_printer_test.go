@@ -3,6 +3,7 @@ package printer_test
 import (
 	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tailor-inc/graphql/language/ast"
@@ -80,6 +81,10 @@ interface AnnotatedInterface @onInterface {
   annotatedField(arg: Type @onArg): Type @onField
 }
 
+interface Baz implements Bar & Node {
+  one: Type
+}
+
 union Feed = Story | Article | Advert
 
 union AnnotatedUnion @onUnion = A | B
@@ -113,11 +118,33 @@ extend type Foo {
 
 extend type Foo @onType {}
 
+extend schema @onSchema {
+  subscription: SubscriptionType
+}
+
+extend scalar CustomScalar @onScalar
+
+extend interface Bar @onInterface {
+  five: Type
+}
+
+extend union Feed @onUnion = Photo | Video
+
+extend enum Site {
+  VR
+}
+
+extend input InputType @onInputObjectType {
+  other: Float = 1.23e4
+}
+
 type NoFields {}
 
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 
 directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+
+directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION
 `
 	results := printer.Print(astDoc)
 	if !reflect.DeepEqual(expected, results) {
@@ -305,3 +332,77 @@ directive @DirectiveMultiLine on SCALAR
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+func TestSchemaPrinter_PrintsBlockDescriptions(t *testing.T) {
+	astDoc := parse(t, `
+"""A "quoted" \""" type"""
+type Foo {
+  "A field"
+  bar: ID
+}
+`)
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, astDoc, printer.PrintOptions{BlockDescriptions: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `"""
+A "quoted" \""" type
+"""
+type Foo {
+  
+  """
+  A field
+  """
+  bar: ID
+}
+`
+	if sb.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, sb.String()))
+	}
+}
+
+func TestSchemaPrinter_SortsTypeSystemDefinitions(t *testing.T) {
+	astDoc := parse(t, `
+type Query implements B & A { b(y: Int, x: Int): ID, a: ID }
+enum Color { RED, BLUE }
+extend type Query { c: ID }
+directive @d on FIELD
+schema { query: Query }
+union U = Query | Color
+input In { z: Int, y: Int }
+`)
+	var sb strings.Builder
+	if err := printer.Fprint(&sb, astDoc, printer.PrintOptions{Sort: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `schema {
+  query: Query
+}
+
+directive @d on FIELD
+
+enum Color {
+  BLUE
+  RED
+}
+
+input In {
+  y: Int
+  z: Int
+}
+
+type Query implements A & B {
+  a: ID
+  b(x: Int, y: Int): ID
+}
+
+extend type Query {
+  c: ID
+}
+
+union U = Color | Query
+`
+	if sb.String() != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, sb.String()))
+	}
+}
@@ -0,0 +1,259 @@
+//go:build ignore
+
+// gen_walk generates walk_gen.go, the code walking the children of each kind of node
+// for Walk, from QueryDocumentKeys and the node types of the ast package. Both are
+// parsed rather than imported, so that a broken walk_gen.go can be generated again.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldType is the type of a field of a node holding children.
+type fieldType struct {
+	name        string // The name of the node type, e.g. Name or Value.
+	pointer     bool
+	list        bool
+	isInterface bool
+}
+
+func (t fieldType) goType() string {
+	if t.pointer {
+		return "*ast." + t.name
+	}
+	return "ast." + t.name
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../ast", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	structs := map[string]*ast.StructType{}
+	interfaces := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				spec, ok := n.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				switch t := spec.Type.(type) {
+				case *ast.StructType:
+					structs[spec.Name.Name] = t
+				case *ast.InterfaceType:
+					interfaces[spec.Name.Name] = true
+				}
+				return false
+			})
+		}
+	}
+
+	documentKeys := parseDocumentKeys(fset)
+	kinds := []string{}
+	for kind := range documentKeys {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var body bytes.Buffer
+	helpers := map[string]fieldType{}
+	walked := []string{}
+	for _, kind := range kinds {
+		keys := documentKeys[kind]
+		if len(keys) == 0 {
+			continue
+		}
+		st, ok := structs[kind]
+		if !ok {
+			log.Fatalf("no node type for %s", kind)
+		}
+		walked = append(walked, kind)
+		fmt.Fprintf(&body, "\nfunc (w *walker) walk%s(node *ast.%s) {\n", kind, kind)
+		for _, key := range keys {
+			t := lookupField(st, kind, key, interfaces)
+			helpers[t.name] = t
+			owner := kind + "." + key
+			if !t.list {
+				if t.isInterface {
+					// Not all node interfaces, such as Selection, embed ast.Node.
+					fmt.Fprintf(&body, "if child, ok := node.%s.(ast.Node); ok {\n", key)
+				} else {
+					fmt.Fprintf(&body, "if child := node.%s; child != nil {\n", key)
+				}
+				fmt.Fprintf(&body, "if r, edited := w.walk(%q, child); edited {\n", key)
+				fmt.Fprintf(&body, "node.%s = as%s(r, %q)\n}\n}\n", key, t.name, owner)
+				continue
+			}
+			fmt.Fprintf(&body, "if len(node.%s) > 0 {\n", key)
+			fmt.Fprintf(&body, "w.push(%q)\n", key)
+			fmt.Fprintf(&body, "var list []%s\n", t.goType())
+			fmt.Fprintf(&body, "for i, item := range node.%s {\n", key)
+			fmt.Fprintf(&body, "var r ast.Node\nedited := false\n")
+			if t.isInterface {
+				fmt.Fprintf(&body, "if child, ok := item.(ast.Node); ok {\nr, edited = w.walk(i, child)\n}\n")
+			} else {
+				fmt.Fprintf(&body, "if item != nil {\nr, edited = w.walk(i, item)\n}\n")
+			}
+			fmt.Fprintf(&body, "if edited && list == nil {\n")
+			fmt.Fprintf(&body, "list = append(make([]%s, 0, len(node.%s)), node.%s[:i]...)\n}\n", t.goType(), key, key)
+			fmt.Fprintf(&body, "if edited {\nif r != nil {\nlist = append(list, as%s(r, %q))\n}\n", t.name, owner)
+			fmt.Fprintf(&body, "} else if list != nil {\nlist = append(list, item)\n}\n}\n")
+			fmt.Fprintf(&body, "if list != nil {\nnode.%s = list\n}\n", key)
+			fmt.Fprintf(&body, "w.pop()\n}\n")
+		}
+		body.WriteString("}\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen_walk.go; DO NOT EDIT.\n\n")
+	out.WriteString("package visitor\n\n")
+	out.WriteString("import \"github.com/tailor-inc/graphql/language/ast\"\n\n")
+	out.WriteString("// walkChildren walks the children of node in the order of QueryDocumentKeys.\n")
+	out.WriteString("func (w *walker) walkChildren(node ast.Node) {\nswitch node := node.(type) {\n")
+	for _, kind := range walked {
+		fmt.Fprintf(&out, "case *ast.%s:\nw.walk%s(node)\n", kind, kind)
+	}
+	out.WriteString("}\n}\n")
+
+	out.WriteString("\n// nodeKinds are the kinds of the nodes of QueryDocumentKeys, indexed by kindIndex.\n")
+	out.WriteString("var nodeKinds = [...]string{\n")
+	for _, kind := range kinds {
+		fmt.Fprintf(&out, "%q,\n", kind)
+	}
+	out.WriteString("}\n")
+	out.WriteString("\n// kindIndex returns the index of the kind of node in nodeKinds, or -1.\n")
+	out.WriteString("func kindIndex(node ast.Node) int {\nswitch node.(type) {\n")
+	for i, kind := range kinds {
+		fmt.Fprintf(&out, "case *ast.%s:\nreturn %d\n", kind, i)
+	}
+	out.WriteString("}\nreturn -1\n}\n")
+
+	out.WriteString("\n// KindWalkFuncs are the typed functions of a Walker called for the nodes of each kind,\n")
+	out.WriteString("// after Enter and before Leave. They return actions as WalkFunc does.\n")
+	out.WriteString("type KindWalkFuncs struct {\n")
+	for _, kind := range kinds {
+		fmt.Fprintf(&out, "Enter%s func(c *Cursor, node *ast.%s) string\n", kind, kind)
+		fmt.Fprintf(&out, "Leave%s func(c *Cursor, node *ast.%s) string\n", kind, kind)
+	}
+	out.WriteString("}\n")
+	for _, action := range []string{"Enter", "Leave"} {
+		method := strings.ToLower(action)
+		fmt.Fprintf(&out, "\n// %s calls the %s function of the kind of the node of c, if any.\n", method, action)
+		fmt.Fprintf(&out, "func (k *KindWalkFuncs) %s(c *Cursor) string {\nswitch node := c.node.(type) {\n", method)
+		for _, kind := range kinds {
+			fmt.Fprintf(&out, "case *ast.%s:\nif k.%s%s != nil {\nreturn k.%s%s(c, node)\n}\n", kind, action, kind, action, kind)
+		}
+		out.WriteString("}\nreturn ActionNoChange\n}\n")
+		fmt.Fprintf(&out, "\n// has%s reports whether k has any %s function.\n", action, action)
+		fmt.Fprintf(&out, "func (k *KindWalkFuncs) has%s() bool {\nreturn ", action)
+		for i, kind := range kinds {
+			if i > 0 {
+				out.WriteString(" ||\n")
+			}
+			fmt.Fprintf(&out, "k.%s%s != nil", action, kind)
+		}
+		out.WriteString("\n}\n")
+	}
+	out.Write(body.Bytes())
+
+	names := []string{}
+	for name := range helpers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := helpers[name]
+		fmt.Fprintf(&out, "\nfunc as%s(node ast.Node, parent string) %s {\n", name, t.goType())
+		fmt.Fprintf(&out, "if node == nil {\nreturn nil\n}\n")
+		fmt.Fprintf(&out, "n, ok := node.(%s)\n", t.goType())
+		fmt.Fprintf(&out, "if !ok {\nmismatch(node, parent)\n}\nreturn n\n}\n")
+	}
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, out.Bytes())
+	}
+	if err := os.WriteFile("walk_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseDocumentKeys returns the value of QueryDocumentKeys in visitor.go.
+func parseDocumentKeys(fset *token.FileSet) map[string][]string {
+	file, err := parser.ParseFile(fset, "visitor.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var documentKeys map[string][]string
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || spec.Names[0].Name != "QueryDocumentKeys" {
+			return true
+		}
+		documentKeys = map[string][]string{}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			keys := []string{}
+			for _, key := range kv.Value.(*ast.CompositeLit).Elts {
+				keys = append(keys, unquote(key))
+			}
+			documentKeys[unquote(kv.Key)] = keys
+		}
+		return false
+	})
+	if documentKeys == nil {
+		log.Fatal("no QueryDocumentKeys in visitor.go")
+	}
+	return documentKeys
+}
+
+func unquote(expr ast.Expr) string {
+	s, err := strconv.Unquote(expr.(*ast.BasicLit).Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return s
+}
+
+// lookupField returns the type of the field key of the node type kind.
+func lookupField(st *ast.StructType, kind, key string, interfaces map[string]bool) fieldType {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if name.Name != key {
+				continue
+			}
+			t := fieldType{}
+			expr := field.Type
+			if array, ok := expr.(*ast.ArrayType); ok {
+				t.list, expr = true, array.Elt
+			}
+			if star, ok := expr.(*ast.StarExpr); ok {
+				t.pointer, expr = true, star.X
+			}
+			ident, ok := expr.(*ast.Ident)
+			if !ok {
+				log.Fatalf("unexpected type of %s.%s", kind, key)
+			}
+			t.name = ident.Name
+			t.isInterface = interfaces[t.name]
+			if !t.pointer && !t.isInterface {
+				log.Fatalf("%s.%s is neither a node pointer nor a node interface", kind, key)
+			}
+			return t
+		}
+	}
+	log.Fatalf("no field %s in %s", key, kind)
+	return fieldType{}
+}
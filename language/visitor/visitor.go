@@ -113,6 +113,7 @@ var QueryDocumentKeys = KeyMap{
 	},
 	"InterfaceDefinition": []string{
 		"Name",
+		"Interfaces",
 		"Directives",
 		"Fields",
 	},
@@ -136,9 +137,18 @@ var QueryDocumentKeys = KeyMap{
 		"Fields",
 	},
 
-	"TypeExtensionDefinition": []string{"Definition"},
+	"TypeExtensionDefinition":        []string{"Definition"},
+	"SchemaExtensionDefinition":      []string{"Definition"},
+	"ScalarExtensionDefinition":      []string{"Definition"},
+	"InterfaceExtensionDefinition":   []string{"Definition"},
+	"UnionExtensionDefinition":       []string{"Definition"},
+	"EnumExtensionDefinition":        []string{"Definition"},
+	"InputObjectExtensionDefinition": []string{"Definition"},
 
 	"DirectiveDefinition": []string{"Name", "Arguments", "Locations"},
+
+	"BadDefinition": []string{},
+	"BadSelection":  []string{},
 }
 
 type stack struct {
@@ -0,0 +1,415 @@
+package visitor
+
+//go:generate go run gen_walk.go
+
+import (
+	"fmt"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/typeInfo"
+)
+
+// WalkFunc is called by Walk when entering or leaving the node of c. It returns
+// ActionNoChange to go on, ActionSkip to skip the children and the leave function of
+// the node it enters, or ActionBreak to stop the walk. Nodes are edited through c.
+type WalkFunc func(c *Cursor) string
+
+// Walker is the set of functions called by Walk for every node, and of the typed
+// functions of KindWalkFuncs called for the nodes of their kind only:
+//
+//	visitor.Walk(doc, &visitor.Walker{KindWalkFuncs: visitor.KindWalkFuncs{
+//		EnterField: func(c *visitor.Cursor, field *ast.Field) string {
+//			fields = append(fields, field.Name.Value)
+//			return visitor.ActionNoChange
+//		},
+//	}})
+type Walker struct {
+	Enter WalkFunc
+	Leave WalkFunc
+	KindWalkFuncs
+}
+
+// enterFunc returns the function entering nodes with w, which calls the kind function
+// of a node unless Enter skipped, broke or edited it. It is nil when w enters nothing.
+func (w *Walker) enterFunc() WalkFunc {
+	enter, kinds := w.Enter, w.KindWalkFuncs
+	if !kinds.hasEnter() {
+		return enter
+	}
+	return func(c *Cursor) string {
+		if enter != nil {
+			if action := enter(c); action != ActionNoChange || c.edited {
+				return action
+			}
+		}
+		return kinds.enter(c)
+	}
+}
+
+// leaveFunc returns the function leaving nodes with w, which calls Leave after the kind
+// function of a node unless it broke or edited it. It is nil when w leaves nothing.
+func (w *Walker) leaveFunc() WalkFunc {
+	leave, kinds := w.Leave, w.KindWalkFuncs
+	if !kinds.hasLeave() {
+		return leave
+	}
+	return func(c *Cursor) string {
+		if action := kinds.leave(c); action == ActionBreak || c.edited || leave == nil {
+			return action
+		}
+		return leave(c)
+	}
+}
+
+// Cursor describes the node being walked, and edits it in its parent.
+//
+// A cursor is only valid during the call it is given to, and so are the slices it
+// returns.
+type Cursor struct {
+	w           *walker
+	node        ast.Node
+	key         interface{}
+	kind        int
+	replacement ast.Node
+	edited      bool
+}
+
+// Node returns the node being walked.
+func (c *Cursor) Node() ast.Node {
+	return c.node
+}
+
+// Key returns the name of the field of the parent holding the node, or its index in
+// the list of that field. It is nil for the root.
+func (c *Cursor) Key() interface{} {
+	return c.key
+}
+
+// Parent returns the node holding the node, which is nil for the root.
+func (c *Cursor) Parent() ast.Node {
+	if len(c.w.stack) == 0 {
+		return nil
+	}
+	return c.w.stack[len(c.w.stack)-1]
+}
+
+// Ancestors returns the ancestors of the parent, starting with the root.
+func (c *Cursor) Ancestors() []ast.Node {
+	if len(c.w.stack) == 0 {
+		return nil
+	}
+	return c.w.stack[:len(c.w.stack)-1]
+}
+
+// Path returns the keys leading from the root to the node. Lists add both the name of
+// their field and the index of the node.
+func (c *Cursor) Path() []interface{} {
+	return c.w.path
+}
+
+// Replace replaces the node by node in its parent. When entering, the children of node
+// are walked instead. Replace panics when the parent can't hold node.
+func (c *Cursor) Replace(node ast.Node) {
+	c.replacement, c.edited = node, true
+	c.node, c.kind = node, kindIndex(node)
+}
+
+// Delete removes the node from its parent.
+func (c *Cursor) Delete() {
+	c.Replace(nil)
+}
+
+// Walk walks the tree of root in depth-first order, calling the functions of w, and
+// returns root, or the node it was replaced by. Unlike Visit, it doesn't use reflection:
+// the children of each kind of node are walked by generated code, in the order of
+// QueryDocumentKeys.
+func Walk(root ast.Node, w *Walker) ast.Node {
+	if root == nil || w == nil {
+		return root
+	}
+	wk := &walker{enter: w.enterFunc(), leave: w.leaveFunc(), trail: []ast.Node{nil}}
+	wk.cursor.w = wk
+	if node, edited := wk.walk(nil, root); edited {
+		return node
+	}
+	return root
+}
+
+type walker struct {
+	enter  WalkFunc
+	leave  WalkFunc
+	cursor Cursor
+	stack  []ast.Node
+	// trail is the parent and ancestors of VisitFuncParams: lists are nil, and so is the
+	// parent of the root.
+	trail   []ast.Node
+	path    []interface{}
+	stopped bool
+}
+
+// walk walks node, found under key in the last node of the stack. It returns the node
+// replacing it when it was edited, which is nil when it was deleted.
+func (w *walker) walk(key interface{}, node ast.Node) (ast.Node, bool) {
+	if w.stopped {
+		return node, false
+	}
+	if key != nil {
+		w.path = append(w.path, key)
+		defer w.popKey()
+	}
+
+	edited := false
+	if w.enter != nil {
+		action := w.call(w.enter, key, node)
+		if w.cursor.edited {
+			node, edited = w.cursor.replacement, true
+		}
+		switch action {
+		case ActionBreak:
+			w.stopped = true
+			return node, edited
+		case ActionSkip:
+			return node, edited
+		}
+		if node == nil {
+			return nil, edited
+		}
+	}
+
+	w.stack, w.trail = append(w.stack, node), append(w.trail, node)
+	w.walkChildren(node)
+	w.stack, w.trail = w.stack[:len(w.stack)-1], w.trail[:len(w.trail)-1]
+
+	if w.leave != nil && !w.stopped {
+		action := w.call(w.leave, key, node)
+		if w.cursor.edited {
+			node, edited = w.cursor.replacement, true
+		}
+		if action == ActionBreak {
+			w.stopped = true
+		}
+	}
+	return node, edited
+}
+
+func (w *walker) call(fn WalkFunc, key interface{}, node ast.Node) string {
+	w.cursor.node, w.cursor.key, w.cursor.kind = node, key, kindIndex(node)
+	w.cursor.replacement, w.cursor.edited = nil, false
+	return fn(&w.cursor)
+}
+
+// push enters the list of the field key.
+func (w *walker) push(key string) {
+	w.path, w.trail = append(w.path, key), append(w.trail, nil)
+}
+
+func (w *walker) pop() {
+	w.trail = w.trail[:len(w.trail)-1]
+	w.popKey()
+}
+
+func (w *walker) popKey() {
+	w.path = w.path[:len(w.path)-1]
+}
+
+// nodeKindIndexes maps the kinds of nodeKinds to their index.
+var nodeKindIndexes = map[string]int{}
+
+func init() {
+	for i, kind := range nodeKinds {
+		nodeKindIndexes[kind] = i
+	}
+}
+
+func mismatch(node ast.Node, parent string) {
+	panic(fmt.Sprintf("visitor: %s can't hold %T", parent, node))
+}
+
+// NewWalker creates a Walker calling the visit functions of visitorOpts, so that
+// visitors written for Visit can be walked. The VisitFuncParams are the ones of Visit,
+// except that Path holds the key of the node when leaving it too, and that the result
+// of ActionUpdate must be an ast.Node, or nil to delete the node.
+//
+// The visit functions of each kind of node are looked up once, so later changes to
+// visitorOpts are ignored.
+func NewWalker(visitorOpts *VisitorOptions) *Walker {
+	var enterFns, leaveFns [len(nodeKinds)]VisitFunc
+	hasEnter, hasLeave := false, false
+	if visitorOpts != nil {
+		for i := range nodeKinds {
+			enterFns[i], leaveFns[i] = visitorOpts.Enter, visitorOpts.Leave
+		}
+		resolve := func(kind string) {
+			if i, ok := nodeKindIndexes[kind]; ok {
+				enterFns[i] = GetVisitFn(visitorOpts, kind, false)
+				leaveFns[i] = GetVisitFn(visitorOpts, kind, true)
+			} else {
+				hasEnter, hasLeave = true, true
+			}
+		}
+		for kind := range visitorOpts.KindFuncMap {
+			resolve(kind)
+		}
+		for kind := range visitorOpts.EnterKindMap {
+			resolve(kind)
+		}
+		for kind := range visitorOpts.LeaveKindMap {
+			resolve(kind)
+		}
+	}
+	for i := range nodeKinds {
+		hasEnter = hasEnter || enterFns[i] != nil
+		hasLeave = hasLeave || leaveFns[i] != nil
+	}
+	call := func(c *Cursor, isLeaving bool) string {
+		var fn VisitFunc
+		switch {
+		case c.kind < 0:
+			fn = GetVisitFn(visitorOpts, c.node.GetKind(), isLeaving)
+		case isLeaving:
+			fn = leaveFns[c.kind]
+		default:
+			fn = enterFns[c.kind]
+		}
+		if fn == nil {
+			return ActionNoChange
+		}
+		trail := c.w.trail
+		action, result := fn(VisitFuncParams{
+			Node:      c.node,
+			Key:       c.key,
+			Parent:    trail[len(trail)-1],
+			Path:      c.w.path,
+			Ancestors: trail[:len(trail)-1],
+		})
+		if action != ActionUpdate {
+			return action
+		}
+		switch result := result.(type) {
+		case ast.Node:
+			c.Replace(result)
+		case nil:
+			c.Delete()
+		default:
+			panic(fmt.Sprintf("visitor: can't replace a node by %T", result))
+		}
+		return ActionNoChange
+	}
+	// Walkers without leave functions spare WalkInParallel the calls.
+	w := &Walker{}
+	if hasEnter {
+		w.Enter = func(c *Cursor) string {
+			return call(c, false)
+		}
+	}
+	if hasLeave {
+		w.Leave = func(c *Cursor) string {
+			return call(c, true)
+		}
+	}
+	return w
+}
+
+// WalkInParallel creates a Walker which calls many walkers for each node before moving
+// on, while keeping the skips and breaks of each of them apart.
+//
+// If a prior walker edits a node, no following walkers will see that node.
+func WalkInParallel(walkers ...*Walker) *Walker {
+	// skipping holds the node each walker skips, or the cursor itself once it broke.
+	skipping := make([]interface{}, len(walkers))
+	broken := &Cursor{}
+	enters, leaves := make([]WalkFunc, len(walkers)), make([]WalkFunc, len(walkers))
+	for i, w := range walkers {
+		enters[i], leaves[i] = w.enterFunc(), w.leaveFunc()
+	}
+	return &Walker{
+		Enter: func(c *Cursor) string {
+			node, active := c.node, false
+			for i, enter := range enters {
+				if skipping[i] != nil {
+					continue
+				}
+				if enter != nil {
+					switch enter(c) {
+					case ActionSkip:
+						skipping[i] = node
+						continue
+					case ActionBreak:
+						skipping[i] = broken
+						continue
+					}
+					if c.edited {
+						return ActionNoChange
+					}
+				}
+				active = true
+			}
+			if active {
+				return ActionNoChange
+			}
+			// Nobody walks the children: clear the skips now, as the node won't be left,
+			// and only stop once all the walkers broke.
+			action := ActionBreak
+			for i := range skipping {
+				if skipping[i] == node {
+					skipping[i] = nil
+				}
+				if skipping[i] != broken {
+					action = ActionSkip
+				}
+			}
+			return action
+		},
+		Leave: func(c *Cursor) string {
+			node := c.node
+			for i, leave := range leaves {
+				if skipping[i] != nil {
+					if skipping[i] == node {
+						skipping[i] = nil
+					}
+					continue
+				}
+				if leave != nil {
+					if leave(c) == ActionBreak {
+						skipping[i] = broken
+					}
+					if c.edited {
+						return ActionNoChange
+					}
+				}
+			}
+			return ActionNoChange
+		},
+	}
+}
+
+// WalkWithTypeInfo creates a Walker which maintains ttypeInfo along with walking w.
+func WalkWithTypeInfo(ttypeInfo typeInfo.TypeInfoI, w *Walker) *Walker {
+	enter, leave := w.enterFunc(), w.leaveFunc()
+	return &Walker{
+		Enter: func(c *Cursor) string {
+			node := c.node
+			ttypeInfo.Enter(node)
+			if enter == nil {
+				return ActionNoChange
+			}
+			action := enter(c)
+			if c.edited || action == ActionSkip {
+				ttypeInfo.Leave(node)
+				if c.edited && c.node != nil && action != ActionSkip {
+					ttypeInfo.Enter(c.node)
+				}
+			}
+			return action
+		},
+		Leave: func(c *Cursor) string {
+			node := c.node
+			action := ActionNoChange
+			if leave != nil {
+				action = leave(c)
+			}
+			ttypeInfo.Leave(node)
+			return action
+		},
+	}
+}
@@ -0,0 +1,2187 @@
+// Code generated by gen_walk.go; DO NOT EDIT.
+
+package visitor
+
+import "github.com/tailor-inc/graphql/language/ast"
+
+// walkChildren walks the children of node in the order of QueryDocumentKeys.
+func (w *walker) walkChildren(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.Argument:
+		w.walkArgument(node)
+	case *ast.Directive:
+		w.walkDirective(node)
+	case *ast.DirectiveDefinition:
+		w.walkDirectiveDefinition(node)
+	case *ast.Document:
+		w.walkDocument(node)
+	case *ast.EnumDefinition:
+		w.walkEnumDefinition(node)
+	case *ast.EnumExtensionDefinition:
+		w.walkEnumExtensionDefinition(node)
+	case *ast.EnumValueDefinition:
+		w.walkEnumValueDefinition(node)
+	case *ast.Field:
+		w.walkField(node)
+	case *ast.FieldDefinition:
+		w.walkFieldDefinition(node)
+	case *ast.FragmentDefinition:
+		w.walkFragmentDefinition(node)
+	case *ast.FragmentSpread:
+		w.walkFragmentSpread(node)
+	case *ast.InlineFragment:
+		w.walkInlineFragment(node)
+	case *ast.InputObjectDefinition:
+		w.walkInputObjectDefinition(node)
+	case *ast.InputObjectExtensionDefinition:
+		w.walkInputObjectExtensionDefinition(node)
+	case *ast.InputValueDefinition:
+		w.walkInputValueDefinition(node)
+	case *ast.InterfaceDefinition:
+		w.walkInterfaceDefinition(node)
+	case *ast.InterfaceExtensionDefinition:
+		w.walkInterfaceExtensionDefinition(node)
+	case *ast.List:
+		w.walkList(node)
+	case *ast.ListValue:
+		w.walkListValue(node)
+	case *ast.Named:
+		w.walkNamed(node)
+	case *ast.NonNull:
+		w.walkNonNull(node)
+	case *ast.ObjectDefinition:
+		w.walkObjectDefinition(node)
+	case *ast.ObjectField:
+		w.walkObjectField(node)
+	case *ast.ObjectValue:
+		w.walkObjectValue(node)
+	case *ast.OperationDefinition:
+		w.walkOperationDefinition(node)
+	case *ast.OperationTypeDefinition:
+		w.walkOperationTypeDefinition(node)
+	case *ast.ScalarDefinition:
+		w.walkScalarDefinition(node)
+	case *ast.ScalarExtensionDefinition:
+		w.walkScalarExtensionDefinition(node)
+	case *ast.SchemaDefinition:
+		w.walkSchemaDefinition(node)
+	case *ast.SchemaExtensionDefinition:
+		w.walkSchemaExtensionDefinition(node)
+	case *ast.SelectionSet:
+		w.walkSelectionSet(node)
+	case *ast.TypeExtensionDefinition:
+		w.walkTypeExtensionDefinition(node)
+	case *ast.UnionDefinition:
+		w.walkUnionDefinition(node)
+	case *ast.UnionExtensionDefinition:
+		w.walkUnionExtensionDefinition(node)
+	case *ast.Variable:
+		w.walkVariable(node)
+	case *ast.VariableDefinition:
+		w.walkVariableDefinition(node)
+	}
+}
+
+// nodeKinds are the kinds of the nodes of QueryDocumentKeys, indexed by kindIndex.
+var nodeKinds = [...]string{
+	"Argument",
+	"BadDefinition",
+	"BadSelection",
+	"BooleanValue",
+	"Directive",
+	"DirectiveDefinition",
+	"Document",
+	"EnumDefinition",
+	"EnumExtensionDefinition",
+	"EnumValue",
+	"EnumValueDefinition",
+	"Field",
+	"FieldDefinition",
+	"FloatValue",
+	"FragmentDefinition",
+	"FragmentSpread",
+	"InlineFragment",
+	"InputObjectDefinition",
+	"InputObjectExtensionDefinition",
+	"InputValueDefinition",
+	"IntValue",
+	"InterfaceDefinition",
+	"InterfaceExtensionDefinition",
+	"List",
+	"ListValue",
+	"Name",
+	"Named",
+	"NonNull",
+	"ObjectDefinition",
+	"ObjectField",
+	"ObjectValue",
+	"OperationDefinition",
+	"OperationTypeDefinition",
+	"ScalarDefinition",
+	"ScalarExtensionDefinition",
+	"SchemaDefinition",
+	"SchemaExtensionDefinition",
+	"SelectionSet",
+	"StringValue",
+	"TypeExtensionDefinition",
+	"UnionDefinition",
+	"UnionExtensionDefinition",
+	"Variable",
+	"VariableDefinition",
+}
+
+// kindIndex returns the index of the kind of node in nodeKinds, or -1.
+func kindIndex(node ast.Node) int {
+	switch node.(type) {
+	case *ast.Argument:
+		return 0
+	case *ast.BadDefinition:
+		return 1
+	case *ast.BadSelection:
+		return 2
+	case *ast.BooleanValue:
+		return 3
+	case *ast.Directive:
+		return 4
+	case *ast.DirectiveDefinition:
+		return 5
+	case *ast.Document:
+		return 6
+	case *ast.EnumDefinition:
+		return 7
+	case *ast.EnumExtensionDefinition:
+		return 8
+	case *ast.EnumValue:
+		return 9
+	case *ast.EnumValueDefinition:
+		return 10
+	case *ast.Field:
+		return 11
+	case *ast.FieldDefinition:
+		return 12
+	case *ast.FloatValue:
+		return 13
+	case *ast.FragmentDefinition:
+		return 14
+	case *ast.FragmentSpread:
+		return 15
+	case *ast.InlineFragment:
+		return 16
+	case *ast.InputObjectDefinition:
+		return 17
+	case *ast.InputObjectExtensionDefinition:
+		return 18
+	case *ast.InputValueDefinition:
+		return 19
+	case *ast.IntValue:
+		return 20
+	case *ast.InterfaceDefinition:
+		return 21
+	case *ast.InterfaceExtensionDefinition:
+		return 22
+	case *ast.List:
+		return 23
+	case *ast.ListValue:
+		return 24
+	case *ast.Name:
+		return 25
+	case *ast.Named:
+		return 26
+	case *ast.NonNull:
+		return 27
+	case *ast.ObjectDefinition:
+		return 28
+	case *ast.ObjectField:
+		return 29
+	case *ast.ObjectValue:
+		return 30
+	case *ast.OperationDefinition:
+		return 31
+	case *ast.OperationTypeDefinition:
+		return 32
+	case *ast.ScalarDefinition:
+		return 33
+	case *ast.ScalarExtensionDefinition:
+		return 34
+	case *ast.SchemaDefinition:
+		return 35
+	case *ast.SchemaExtensionDefinition:
+		return 36
+	case *ast.SelectionSet:
+		return 37
+	case *ast.StringValue:
+		return 38
+	case *ast.TypeExtensionDefinition:
+		return 39
+	case *ast.UnionDefinition:
+		return 40
+	case *ast.UnionExtensionDefinition:
+		return 41
+	case *ast.Variable:
+		return 42
+	case *ast.VariableDefinition:
+		return 43
+	}
+	return -1
+}
+
+// KindWalkFuncs are the typed functions of a Walker called for the nodes of each kind,
+// after Enter and before Leave. They return actions as WalkFunc does.
+type KindWalkFuncs struct {
+	EnterArgument                       func(c *Cursor, node *ast.Argument) string
+	LeaveArgument                       func(c *Cursor, node *ast.Argument) string
+	EnterBadDefinition                  func(c *Cursor, node *ast.BadDefinition) string
+	LeaveBadDefinition                  func(c *Cursor, node *ast.BadDefinition) string
+	EnterBadSelection                   func(c *Cursor, node *ast.BadSelection) string
+	LeaveBadSelection                   func(c *Cursor, node *ast.BadSelection) string
+	EnterBooleanValue                   func(c *Cursor, node *ast.BooleanValue) string
+	LeaveBooleanValue                   func(c *Cursor, node *ast.BooleanValue) string
+	EnterDirective                      func(c *Cursor, node *ast.Directive) string
+	LeaveDirective                      func(c *Cursor, node *ast.Directive) string
+	EnterDirectiveDefinition            func(c *Cursor, node *ast.DirectiveDefinition) string
+	LeaveDirectiveDefinition            func(c *Cursor, node *ast.DirectiveDefinition) string
+	EnterDocument                       func(c *Cursor, node *ast.Document) string
+	LeaveDocument                       func(c *Cursor, node *ast.Document) string
+	EnterEnumDefinition                 func(c *Cursor, node *ast.EnumDefinition) string
+	LeaveEnumDefinition                 func(c *Cursor, node *ast.EnumDefinition) string
+	EnterEnumExtensionDefinition        func(c *Cursor, node *ast.EnumExtensionDefinition) string
+	LeaveEnumExtensionDefinition        func(c *Cursor, node *ast.EnumExtensionDefinition) string
+	EnterEnumValue                      func(c *Cursor, node *ast.EnumValue) string
+	LeaveEnumValue                      func(c *Cursor, node *ast.EnumValue) string
+	EnterEnumValueDefinition            func(c *Cursor, node *ast.EnumValueDefinition) string
+	LeaveEnumValueDefinition            func(c *Cursor, node *ast.EnumValueDefinition) string
+	EnterField                          func(c *Cursor, node *ast.Field) string
+	LeaveField                          func(c *Cursor, node *ast.Field) string
+	EnterFieldDefinition                func(c *Cursor, node *ast.FieldDefinition) string
+	LeaveFieldDefinition                func(c *Cursor, node *ast.FieldDefinition) string
+	EnterFloatValue                     func(c *Cursor, node *ast.FloatValue) string
+	LeaveFloatValue                     func(c *Cursor, node *ast.FloatValue) string
+	EnterFragmentDefinition             func(c *Cursor, node *ast.FragmentDefinition) string
+	LeaveFragmentDefinition             func(c *Cursor, node *ast.FragmentDefinition) string
+	EnterFragmentSpread                 func(c *Cursor, node *ast.FragmentSpread) string
+	LeaveFragmentSpread                 func(c *Cursor, node *ast.FragmentSpread) string
+	EnterInlineFragment                 func(c *Cursor, node *ast.InlineFragment) string
+	LeaveInlineFragment                 func(c *Cursor, node *ast.InlineFragment) string
+	EnterInputObjectDefinition          func(c *Cursor, node *ast.InputObjectDefinition) string
+	LeaveInputObjectDefinition          func(c *Cursor, node *ast.InputObjectDefinition) string
+	EnterInputObjectExtensionDefinition func(c *Cursor, node *ast.InputObjectExtensionDefinition) string
+	LeaveInputObjectExtensionDefinition func(c *Cursor, node *ast.InputObjectExtensionDefinition) string
+	EnterInputValueDefinition           func(c *Cursor, node *ast.InputValueDefinition) string
+	LeaveInputValueDefinition           func(c *Cursor, node *ast.InputValueDefinition) string
+	EnterIntValue                       func(c *Cursor, node *ast.IntValue) string
+	LeaveIntValue                       func(c *Cursor, node *ast.IntValue) string
+	EnterInterfaceDefinition            func(c *Cursor, node *ast.InterfaceDefinition) string
+	LeaveInterfaceDefinition            func(c *Cursor, node *ast.InterfaceDefinition) string
+	EnterInterfaceExtensionDefinition   func(c *Cursor, node *ast.InterfaceExtensionDefinition) string
+	LeaveInterfaceExtensionDefinition   func(c *Cursor, node *ast.InterfaceExtensionDefinition) string
+	EnterList                           func(c *Cursor, node *ast.List) string
+	LeaveList                           func(c *Cursor, node *ast.List) string
+	EnterListValue                      func(c *Cursor, node *ast.ListValue) string
+	LeaveListValue                      func(c *Cursor, node *ast.ListValue) string
+	EnterName                           func(c *Cursor, node *ast.Name) string
+	LeaveName                           func(c *Cursor, node *ast.Name) string
+	EnterNamed                          func(c *Cursor, node *ast.Named) string
+	LeaveNamed                          func(c *Cursor, node *ast.Named) string
+	EnterNonNull                        func(c *Cursor, node *ast.NonNull) string
+	LeaveNonNull                        func(c *Cursor, node *ast.NonNull) string
+	EnterObjectDefinition               func(c *Cursor, node *ast.ObjectDefinition) string
+	LeaveObjectDefinition               func(c *Cursor, node *ast.ObjectDefinition) string
+	EnterObjectField                    func(c *Cursor, node *ast.ObjectField) string
+	LeaveObjectField                    func(c *Cursor, node *ast.ObjectField) string
+	EnterObjectValue                    func(c *Cursor, node *ast.ObjectValue) string
+	LeaveObjectValue                    func(c *Cursor, node *ast.ObjectValue) string
+	EnterOperationDefinition            func(c *Cursor, node *ast.OperationDefinition) string
+	LeaveOperationDefinition            func(c *Cursor, node *ast.OperationDefinition) string
+	EnterOperationTypeDefinition        func(c *Cursor, node *ast.OperationTypeDefinition) string
+	LeaveOperationTypeDefinition        func(c *Cursor, node *ast.OperationTypeDefinition) string
+	EnterScalarDefinition               func(c *Cursor, node *ast.ScalarDefinition) string
+	LeaveScalarDefinition               func(c *Cursor, node *ast.ScalarDefinition) string
+	EnterScalarExtensionDefinition      func(c *Cursor, node *ast.ScalarExtensionDefinition) string
+	LeaveScalarExtensionDefinition      func(c *Cursor, node *ast.ScalarExtensionDefinition) string
+	EnterSchemaDefinition               func(c *Cursor, node *ast.SchemaDefinition) string
+	LeaveSchemaDefinition               func(c *Cursor, node *ast.SchemaDefinition) string
+	EnterSchemaExtensionDefinition      func(c *Cursor, node *ast.SchemaExtensionDefinition) string
+	LeaveSchemaExtensionDefinition      func(c *Cursor, node *ast.SchemaExtensionDefinition) string
+	EnterSelectionSet                   func(c *Cursor, node *ast.SelectionSet) string
+	LeaveSelectionSet                   func(c *Cursor, node *ast.SelectionSet) string
+	EnterStringValue                    func(c *Cursor, node *ast.StringValue) string
+	LeaveStringValue                    func(c *Cursor, node *ast.StringValue) string
+	EnterTypeExtensionDefinition        func(c *Cursor, node *ast.TypeExtensionDefinition) string
+	LeaveTypeExtensionDefinition        func(c *Cursor, node *ast.TypeExtensionDefinition) string
+	EnterUnionDefinition                func(c *Cursor, node *ast.UnionDefinition) string
+	LeaveUnionDefinition                func(c *Cursor, node *ast.UnionDefinition) string
+	EnterUnionExtensionDefinition       func(c *Cursor, node *ast.UnionExtensionDefinition) string
+	LeaveUnionExtensionDefinition       func(c *Cursor, node *ast.UnionExtensionDefinition) string
+	EnterVariable                       func(c *Cursor, node *ast.Variable) string
+	LeaveVariable                       func(c *Cursor, node *ast.Variable) string
+	EnterVariableDefinition             func(c *Cursor, node *ast.VariableDefinition) string
+	LeaveVariableDefinition             func(c *Cursor, node *ast.VariableDefinition) string
+}
+
+// enter calls the Enter function of the kind of the node of c, if any.
+func (k *KindWalkFuncs) enter(c *Cursor) string {
+	switch node := c.node.(type) {
+	case *ast.Argument:
+		if k.EnterArgument != nil {
+			return k.EnterArgument(c, node)
+		}
+	case *ast.BadDefinition:
+		if k.EnterBadDefinition != nil {
+			return k.EnterBadDefinition(c, node)
+		}
+	case *ast.BadSelection:
+		if k.EnterBadSelection != nil {
+			return k.EnterBadSelection(c, node)
+		}
+	case *ast.BooleanValue:
+		if k.EnterBooleanValue != nil {
+			return k.EnterBooleanValue(c, node)
+		}
+	case *ast.Directive:
+		if k.EnterDirective != nil {
+			return k.EnterDirective(c, node)
+		}
+	case *ast.DirectiveDefinition:
+		if k.EnterDirectiveDefinition != nil {
+			return k.EnterDirectiveDefinition(c, node)
+		}
+	case *ast.Document:
+		if k.EnterDocument != nil {
+			return k.EnterDocument(c, node)
+		}
+	case *ast.EnumDefinition:
+		if k.EnterEnumDefinition != nil {
+			return k.EnterEnumDefinition(c, node)
+		}
+	case *ast.EnumExtensionDefinition:
+		if k.EnterEnumExtensionDefinition != nil {
+			return k.EnterEnumExtensionDefinition(c, node)
+		}
+	case *ast.EnumValue:
+		if k.EnterEnumValue != nil {
+			return k.EnterEnumValue(c, node)
+		}
+	case *ast.EnumValueDefinition:
+		if k.EnterEnumValueDefinition != nil {
+			return k.EnterEnumValueDefinition(c, node)
+		}
+	case *ast.Field:
+		if k.EnterField != nil {
+			return k.EnterField(c, node)
+		}
+	case *ast.FieldDefinition:
+		if k.EnterFieldDefinition != nil {
+			return k.EnterFieldDefinition(c, node)
+		}
+	case *ast.FloatValue:
+		if k.EnterFloatValue != nil {
+			return k.EnterFloatValue(c, node)
+		}
+	case *ast.FragmentDefinition:
+		if k.EnterFragmentDefinition != nil {
+			return k.EnterFragmentDefinition(c, node)
+		}
+	case *ast.FragmentSpread:
+		if k.EnterFragmentSpread != nil {
+			return k.EnterFragmentSpread(c, node)
+		}
+	case *ast.InlineFragment:
+		if k.EnterInlineFragment != nil {
+			return k.EnterInlineFragment(c, node)
+		}
+	case *ast.InputObjectDefinition:
+		if k.EnterInputObjectDefinition != nil {
+			return k.EnterInputObjectDefinition(c, node)
+		}
+	case *ast.InputObjectExtensionDefinition:
+		if k.EnterInputObjectExtensionDefinition != nil {
+			return k.EnterInputObjectExtensionDefinition(c, node)
+		}
+	case *ast.InputValueDefinition:
+		if k.EnterInputValueDefinition != nil {
+			return k.EnterInputValueDefinition(c, node)
+		}
+	case *ast.IntValue:
+		if k.EnterIntValue != nil {
+			return k.EnterIntValue(c, node)
+		}
+	case *ast.InterfaceDefinition:
+		if k.EnterInterfaceDefinition != nil {
+			return k.EnterInterfaceDefinition(c, node)
+		}
+	case *ast.InterfaceExtensionDefinition:
+		if k.EnterInterfaceExtensionDefinition != nil {
+			return k.EnterInterfaceExtensionDefinition(c, node)
+		}
+	case *ast.List:
+		if k.EnterList != nil {
+			return k.EnterList(c, node)
+		}
+	case *ast.ListValue:
+		if k.EnterListValue != nil {
+			return k.EnterListValue(c, node)
+		}
+	case *ast.Name:
+		if k.EnterName != nil {
+			return k.EnterName(c, node)
+		}
+	case *ast.Named:
+		if k.EnterNamed != nil {
+			return k.EnterNamed(c, node)
+		}
+	case *ast.NonNull:
+		if k.EnterNonNull != nil {
+			return k.EnterNonNull(c, node)
+		}
+	case *ast.ObjectDefinition:
+		if k.EnterObjectDefinition != nil {
+			return k.EnterObjectDefinition(c, node)
+		}
+	case *ast.ObjectField:
+		if k.EnterObjectField != nil {
+			return k.EnterObjectField(c, node)
+		}
+	case *ast.ObjectValue:
+		if k.EnterObjectValue != nil {
+			return k.EnterObjectValue(c, node)
+		}
+	case *ast.OperationDefinition:
+		if k.EnterOperationDefinition != nil {
+			return k.EnterOperationDefinition(c, node)
+		}
+	case *ast.OperationTypeDefinition:
+		if k.EnterOperationTypeDefinition != nil {
+			return k.EnterOperationTypeDefinition(c, node)
+		}
+	case *ast.ScalarDefinition:
+		if k.EnterScalarDefinition != nil {
+			return k.EnterScalarDefinition(c, node)
+		}
+	case *ast.ScalarExtensionDefinition:
+		if k.EnterScalarExtensionDefinition != nil {
+			return k.EnterScalarExtensionDefinition(c, node)
+		}
+	case *ast.SchemaDefinition:
+		if k.EnterSchemaDefinition != nil {
+			return k.EnterSchemaDefinition(c, node)
+		}
+	case *ast.SchemaExtensionDefinition:
+		if k.EnterSchemaExtensionDefinition != nil {
+			return k.EnterSchemaExtensionDefinition(c, node)
+		}
+	case *ast.SelectionSet:
+		if k.EnterSelectionSet != nil {
+			return k.EnterSelectionSet(c, node)
+		}
+	case *ast.StringValue:
+		if k.EnterStringValue != nil {
+			return k.EnterStringValue(c, node)
+		}
+	case *ast.TypeExtensionDefinition:
+		if k.EnterTypeExtensionDefinition != nil {
+			return k.EnterTypeExtensionDefinition(c, node)
+		}
+	case *ast.UnionDefinition:
+		if k.EnterUnionDefinition != nil {
+			return k.EnterUnionDefinition(c, node)
+		}
+	case *ast.UnionExtensionDefinition:
+		if k.EnterUnionExtensionDefinition != nil {
+			return k.EnterUnionExtensionDefinition(c, node)
+		}
+	case *ast.Variable:
+		if k.EnterVariable != nil {
+			return k.EnterVariable(c, node)
+		}
+	case *ast.VariableDefinition:
+		if k.EnterVariableDefinition != nil {
+			return k.EnterVariableDefinition(c, node)
+		}
+	}
+	return ActionNoChange
+}
+
+// hasEnter reports whether k has any Enter function.
+func (k *KindWalkFuncs) hasEnter() bool {
+	return k.EnterArgument != nil ||
+		k.EnterBadDefinition != nil ||
+		k.EnterBadSelection != nil ||
+		k.EnterBooleanValue != nil ||
+		k.EnterDirective != nil ||
+		k.EnterDirectiveDefinition != nil ||
+		k.EnterDocument != nil ||
+		k.EnterEnumDefinition != nil ||
+		k.EnterEnumExtensionDefinition != nil ||
+		k.EnterEnumValue != nil ||
+		k.EnterEnumValueDefinition != nil ||
+		k.EnterField != nil ||
+		k.EnterFieldDefinition != nil ||
+		k.EnterFloatValue != nil ||
+		k.EnterFragmentDefinition != nil ||
+		k.EnterFragmentSpread != nil ||
+		k.EnterInlineFragment != nil ||
+		k.EnterInputObjectDefinition != nil ||
+		k.EnterInputObjectExtensionDefinition != nil ||
+		k.EnterInputValueDefinition != nil ||
+		k.EnterIntValue != nil ||
+		k.EnterInterfaceDefinition != nil ||
+		k.EnterInterfaceExtensionDefinition != nil ||
+		k.EnterList != nil ||
+		k.EnterListValue != nil ||
+		k.EnterName != nil ||
+		k.EnterNamed != nil ||
+		k.EnterNonNull != nil ||
+		k.EnterObjectDefinition != nil ||
+		k.EnterObjectField != nil ||
+		k.EnterObjectValue != nil ||
+		k.EnterOperationDefinition != nil ||
+		k.EnterOperationTypeDefinition != nil ||
+		k.EnterScalarDefinition != nil ||
+		k.EnterScalarExtensionDefinition != nil ||
+		k.EnterSchemaDefinition != nil ||
+		k.EnterSchemaExtensionDefinition != nil ||
+		k.EnterSelectionSet != nil ||
+		k.EnterStringValue != nil ||
+		k.EnterTypeExtensionDefinition != nil ||
+		k.EnterUnionDefinition != nil ||
+		k.EnterUnionExtensionDefinition != nil ||
+		k.EnterVariable != nil ||
+		k.EnterVariableDefinition != nil
+}
+
+// leave calls the Leave function of the kind of the node of c, if any.
+func (k *KindWalkFuncs) leave(c *Cursor) string {
+	switch node := c.node.(type) {
+	case *ast.Argument:
+		if k.LeaveArgument != nil {
+			return k.LeaveArgument(c, node)
+		}
+	case *ast.BadDefinition:
+		if k.LeaveBadDefinition != nil {
+			return k.LeaveBadDefinition(c, node)
+		}
+	case *ast.BadSelection:
+		if k.LeaveBadSelection != nil {
+			return k.LeaveBadSelection(c, node)
+		}
+	case *ast.BooleanValue:
+		if k.LeaveBooleanValue != nil {
+			return k.LeaveBooleanValue(c, node)
+		}
+	case *ast.Directive:
+		if k.LeaveDirective != nil {
+			return k.LeaveDirective(c, node)
+		}
+	case *ast.DirectiveDefinition:
+		if k.LeaveDirectiveDefinition != nil {
+			return k.LeaveDirectiveDefinition(c, node)
+		}
+	case *ast.Document:
+		if k.LeaveDocument != nil {
+			return k.LeaveDocument(c, node)
+		}
+	case *ast.EnumDefinition:
+		if k.LeaveEnumDefinition != nil {
+			return k.LeaveEnumDefinition(c, node)
+		}
+	case *ast.EnumExtensionDefinition:
+		if k.LeaveEnumExtensionDefinition != nil {
+			return k.LeaveEnumExtensionDefinition(c, node)
+		}
+	case *ast.EnumValue:
+		if k.LeaveEnumValue != nil {
+			return k.LeaveEnumValue(c, node)
+		}
+	case *ast.EnumValueDefinition:
+		if k.LeaveEnumValueDefinition != nil {
+			return k.LeaveEnumValueDefinition(c, node)
+		}
+	case *ast.Field:
+		if k.LeaveField != nil {
+			return k.LeaveField(c, node)
+		}
+	case *ast.FieldDefinition:
+		if k.LeaveFieldDefinition != nil {
+			return k.LeaveFieldDefinition(c, node)
+		}
+	case *ast.FloatValue:
+		if k.LeaveFloatValue != nil {
+			return k.LeaveFloatValue(c, node)
+		}
+	case *ast.FragmentDefinition:
+		if k.LeaveFragmentDefinition != nil {
+			return k.LeaveFragmentDefinition(c, node)
+		}
+	case *ast.FragmentSpread:
+		if k.LeaveFragmentSpread != nil {
+			return k.LeaveFragmentSpread(c, node)
+		}
+	case *ast.InlineFragment:
+		if k.LeaveInlineFragment != nil {
+			return k.LeaveInlineFragment(c, node)
+		}
+	case *ast.InputObjectDefinition:
+		if k.LeaveInputObjectDefinition != nil {
+			return k.LeaveInputObjectDefinition(c, node)
+		}
+	case *ast.InputObjectExtensionDefinition:
+		if k.LeaveInputObjectExtensionDefinition != nil {
+			return k.LeaveInputObjectExtensionDefinition(c, node)
+		}
+	case *ast.InputValueDefinition:
+		if k.LeaveInputValueDefinition != nil {
+			return k.LeaveInputValueDefinition(c, node)
+		}
+	case *ast.IntValue:
+		if k.LeaveIntValue != nil {
+			return k.LeaveIntValue(c, node)
+		}
+	case *ast.InterfaceDefinition:
+		if k.LeaveInterfaceDefinition != nil {
+			return k.LeaveInterfaceDefinition(c, node)
+		}
+	case *ast.InterfaceExtensionDefinition:
+		if k.LeaveInterfaceExtensionDefinition != nil {
+			return k.LeaveInterfaceExtensionDefinition(c, node)
+		}
+	case *ast.List:
+		if k.LeaveList != nil {
+			return k.LeaveList(c, node)
+		}
+	case *ast.ListValue:
+		if k.LeaveListValue != nil {
+			return k.LeaveListValue(c, node)
+		}
+	case *ast.Name:
+		if k.LeaveName != nil {
+			return k.LeaveName(c, node)
+		}
+	case *ast.Named:
+		if k.LeaveNamed != nil {
+			return k.LeaveNamed(c, node)
+		}
+	case *ast.NonNull:
+		if k.LeaveNonNull != nil {
+			return k.LeaveNonNull(c, node)
+		}
+	case *ast.ObjectDefinition:
+		if k.LeaveObjectDefinition != nil {
+			return k.LeaveObjectDefinition(c, node)
+		}
+	case *ast.ObjectField:
+		if k.LeaveObjectField != nil {
+			return k.LeaveObjectField(c, node)
+		}
+	case *ast.ObjectValue:
+		if k.LeaveObjectValue != nil {
+			return k.LeaveObjectValue(c, node)
+		}
+	case *ast.OperationDefinition:
+		if k.LeaveOperationDefinition != nil {
+			return k.LeaveOperationDefinition(c, node)
+		}
+	case *ast.OperationTypeDefinition:
+		if k.LeaveOperationTypeDefinition != nil {
+			return k.LeaveOperationTypeDefinition(c, node)
+		}
+	case *ast.ScalarDefinition:
+		if k.LeaveScalarDefinition != nil {
+			return k.LeaveScalarDefinition(c, node)
+		}
+	case *ast.ScalarExtensionDefinition:
+		if k.LeaveScalarExtensionDefinition != nil {
+			return k.LeaveScalarExtensionDefinition(c, node)
+		}
+	case *ast.SchemaDefinition:
+		if k.LeaveSchemaDefinition != nil {
+			return k.LeaveSchemaDefinition(c, node)
+		}
+	case *ast.SchemaExtensionDefinition:
+		if k.LeaveSchemaExtensionDefinition != nil {
+			return k.LeaveSchemaExtensionDefinition(c, node)
+		}
+	case *ast.SelectionSet:
+		if k.LeaveSelectionSet != nil {
+			return k.LeaveSelectionSet(c, node)
+		}
+	case *ast.StringValue:
+		if k.LeaveStringValue != nil {
+			return k.LeaveStringValue(c, node)
+		}
+	case *ast.TypeExtensionDefinition:
+		if k.LeaveTypeExtensionDefinition != nil {
+			return k.LeaveTypeExtensionDefinition(c, node)
+		}
+	case *ast.UnionDefinition:
+		if k.LeaveUnionDefinition != nil {
+			return k.LeaveUnionDefinition(c, node)
+		}
+	case *ast.UnionExtensionDefinition:
+		if k.LeaveUnionExtensionDefinition != nil {
+			return k.LeaveUnionExtensionDefinition(c, node)
+		}
+	case *ast.Variable:
+		if k.LeaveVariable != nil {
+			return k.LeaveVariable(c, node)
+		}
+	case *ast.VariableDefinition:
+		if k.LeaveVariableDefinition != nil {
+			return k.LeaveVariableDefinition(c, node)
+		}
+	}
+	return ActionNoChange
+}
+
+// hasLeave reports whether k has any Leave function.
+func (k *KindWalkFuncs) hasLeave() bool {
+	return k.LeaveArgument != nil ||
+		k.LeaveBadDefinition != nil ||
+		k.LeaveBadSelection != nil ||
+		k.LeaveBooleanValue != nil ||
+		k.LeaveDirective != nil ||
+		k.LeaveDirectiveDefinition != nil ||
+		k.LeaveDocument != nil ||
+		k.LeaveEnumDefinition != nil ||
+		k.LeaveEnumExtensionDefinition != nil ||
+		k.LeaveEnumValue != nil ||
+		k.LeaveEnumValueDefinition != nil ||
+		k.LeaveField != nil ||
+		k.LeaveFieldDefinition != nil ||
+		k.LeaveFloatValue != nil ||
+		k.LeaveFragmentDefinition != nil ||
+		k.LeaveFragmentSpread != nil ||
+		k.LeaveInlineFragment != nil ||
+		k.LeaveInputObjectDefinition != nil ||
+		k.LeaveInputObjectExtensionDefinition != nil ||
+		k.LeaveInputValueDefinition != nil ||
+		k.LeaveIntValue != nil ||
+		k.LeaveInterfaceDefinition != nil ||
+		k.LeaveInterfaceExtensionDefinition != nil ||
+		k.LeaveList != nil ||
+		k.LeaveListValue != nil ||
+		k.LeaveName != nil ||
+		k.LeaveNamed != nil ||
+		k.LeaveNonNull != nil ||
+		k.LeaveObjectDefinition != nil ||
+		k.LeaveObjectField != nil ||
+		k.LeaveObjectValue != nil ||
+		k.LeaveOperationDefinition != nil ||
+		k.LeaveOperationTypeDefinition != nil ||
+		k.LeaveScalarDefinition != nil ||
+		k.LeaveScalarExtensionDefinition != nil ||
+		k.LeaveSchemaDefinition != nil ||
+		k.LeaveSchemaExtensionDefinition != nil ||
+		k.LeaveSelectionSet != nil ||
+		k.LeaveStringValue != nil ||
+		k.LeaveTypeExtensionDefinition != nil ||
+		k.LeaveUnionDefinition != nil ||
+		k.LeaveUnionExtensionDefinition != nil ||
+		k.LeaveVariable != nil ||
+		k.LeaveVariableDefinition != nil
+}
+
+func (w *walker) walkArgument(node *ast.Argument) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "Argument.Name")
+		}
+	}
+	if child, ok := node.Value.(ast.Node); ok {
+		if r, edited := w.walk("Value", child); edited {
+			node.Value = asValue(r, "Argument.Value")
+		}
+	}
+}
+
+func (w *walker) walkDirective(node *ast.Directive) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "Directive.Name")
+		}
+	}
+	if len(node.Arguments) > 0 {
+		w.push("Arguments")
+		var list []*ast.Argument
+		for i, item := range node.Arguments {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Argument, 0, len(node.Arguments)), node.Arguments[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asArgument(r, "Directive.Arguments"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Arguments = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkDirectiveDefinition(node *ast.DirectiveDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "DirectiveDefinition.Name")
+		}
+	}
+	if len(node.Arguments) > 0 {
+		w.push("Arguments")
+		var list []*ast.InputValueDefinition
+		for i, item := range node.Arguments {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.InputValueDefinition, 0, len(node.Arguments)), node.Arguments[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asInputValueDefinition(r, "DirectiveDefinition.Arguments"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Arguments = list
+		}
+		w.pop()
+	}
+	if len(node.Locations) > 0 {
+		w.push("Locations")
+		var list []*ast.Name
+		for i, item := range node.Locations {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Name, 0, len(node.Locations)), node.Locations[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asName(r, "DirectiveDefinition.Locations"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Locations = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkDocument(node *ast.Document) {
+	if len(node.Definitions) > 0 {
+		w.push("Definitions")
+		var list []ast.Node
+		for i, item := range node.Definitions {
+			var r ast.Node
+			edited := false
+			if child, ok := item.(ast.Node); ok {
+				r, edited = w.walk(i, child)
+			}
+			if edited && list == nil {
+				list = append(make([]ast.Node, 0, len(node.Definitions)), node.Definitions[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asNode(r, "Document.Definitions"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Definitions = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkEnumDefinition(node *ast.EnumDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "EnumDefinition.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "EnumDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.Values) > 0 {
+		w.push("Values")
+		var list []*ast.EnumValueDefinition
+		for i, item := range node.Values {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.EnumValueDefinition, 0, len(node.Values)), node.Values[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asEnumValueDefinition(r, "EnumDefinition.Values"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Values = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkEnumExtensionDefinition(node *ast.EnumExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asEnumDefinition(r, "EnumExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkEnumValueDefinition(node *ast.EnumValueDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "EnumValueDefinition.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "EnumValueDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkField(node *ast.Field) {
+	if child := node.Alias; child != nil {
+		if r, edited := w.walk("Alias", child); edited {
+			node.Alias = asName(r, "Field.Alias")
+		}
+	}
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "Field.Name")
+		}
+	}
+	if len(node.Arguments) > 0 {
+		w.push("Arguments")
+		var list []*ast.Argument
+		for i, item := range node.Arguments {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Argument, 0, len(node.Arguments)), node.Arguments[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asArgument(r, "Field.Arguments"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Arguments = list
+		}
+		w.pop()
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "Field.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if child := node.SelectionSet; child != nil {
+		if r, edited := w.walk("SelectionSet", child); edited {
+			node.SelectionSet = asSelectionSet(r, "Field.SelectionSet")
+		}
+	}
+}
+
+func (w *walker) walkFieldDefinition(node *ast.FieldDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "FieldDefinition.Name")
+		}
+	}
+	if len(node.Arguments) > 0 {
+		w.push("Arguments")
+		var list []*ast.InputValueDefinition
+		for i, item := range node.Arguments {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.InputValueDefinition, 0, len(node.Arguments)), node.Arguments[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asInputValueDefinition(r, "FieldDefinition.Arguments"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Arguments = list
+		}
+		w.pop()
+	}
+	if child, ok := node.Type.(ast.Node); ok {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asType(r, "FieldDefinition.Type")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "FieldDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkFragmentDefinition(node *ast.FragmentDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "FragmentDefinition.Name")
+		}
+	}
+	if child := node.TypeCondition; child != nil {
+		if r, edited := w.walk("TypeCondition", child); edited {
+			node.TypeCondition = asNamed(r, "FragmentDefinition.TypeCondition")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "FragmentDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if child := node.SelectionSet; child != nil {
+		if r, edited := w.walk("SelectionSet", child); edited {
+			node.SelectionSet = asSelectionSet(r, "FragmentDefinition.SelectionSet")
+		}
+	}
+}
+
+func (w *walker) walkFragmentSpread(node *ast.FragmentSpread) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "FragmentSpread.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "FragmentSpread.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkInlineFragment(node *ast.InlineFragment) {
+	if child := node.TypeCondition; child != nil {
+		if r, edited := w.walk("TypeCondition", child); edited {
+			node.TypeCondition = asNamed(r, "InlineFragment.TypeCondition")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "InlineFragment.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if child := node.SelectionSet; child != nil {
+		if r, edited := w.walk("SelectionSet", child); edited {
+			node.SelectionSet = asSelectionSet(r, "InlineFragment.SelectionSet")
+		}
+	}
+}
+
+func (w *walker) walkInputObjectDefinition(node *ast.InputObjectDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "InputObjectDefinition.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "InputObjectDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.Fields) > 0 {
+		w.push("Fields")
+		var list []*ast.InputValueDefinition
+		for i, item := range node.Fields {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.InputValueDefinition, 0, len(node.Fields)), node.Fields[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asInputValueDefinition(r, "InputObjectDefinition.Fields"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Fields = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkInputObjectExtensionDefinition(node *ast.InputObjectExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asInputObjectDefinition(r, "InputObjectExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkInputValueDefinition(node *ast.InputValueDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "InputValueDefinition.Name")
+		}
+	}
+	if child, ok := node.Type.(ast.Node); ok {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asType(r, "InputValueDefinition.Type")
+		}
+	}
+	if child, ok := node.DefaultValue.(ast.Node); ok {
+		if r, edited := w.walk("DefaultValue", child); edited {
+			node.DefaultValue = asValue(r, "InputValueDefinition.DefaultValue")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "InputValueDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkInterfaceDefinition(node *ast.InterfaceDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "InterfaceDefinition.Name")
+		}
+	}
+	if len(node.Interfaces) > 0 {
+		w.push("Interfaces")
+		var list []*ast.Named
+		for i, item := range node.Interfaces {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Named, 0, len(node.Interfaces)), node.Interfaces[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asNamed(r, "InterfaceDefinition.Interfaces"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Interfaces = list
+		}
+		w.pop()
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "InterfaceDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.Fields) > 0 {
+		w.push("Fields")
+		var list []*ast.FieldDefinition
+		for i, item := range node.Fields {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.FieldDefinition, 0, len(node.Fields)), node.Fields[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asFieldDefinition(r, "InterfaceDefinition.Fields"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Fields = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkInterfaceExtensionDefinition(node *ast.InterfaceExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asInterfaceDefinition(r, "InterfaceExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkList(node *ast.List) {
+	if child, ok := node.Type.(ast.Node); ok {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asType(r, "List.Type")
+		}
+	}
+}
+
+func (w *walker) walkListValue(node *ast.ListValue) {
+	if len(node.Values) > 0 {
+		w.push("Values")
+		var list []ast.Value
+		for i, item := range node.Values {
+			var r ast.Node
+			edited := false
+			if child, ok := item.(ast.Node); ok {
+				r, edited = w.walk(i, child)
+			}
+			if edited && list == nil {
+				list = append(make([]ast.Value, 0, len(node.Values)), node.Values[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asValue(r, "ListValue.Values"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Values = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkNamed(node *ast.Named) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "Named.Name")
+		}
+	}
+}
+
+func (w *walker) walkNonNull(node *ast.NonNull) {
+	if child, ok := node.Type.(ast.Node); ok {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asType(r, "NonNull.Type")
+		}
+	}
+}
+
+func (w *walker) walkObjectDefinition(node *ast.ObjectDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "ObjectDefinition.Name")
+		}
+	}
+	if len(node.Interfaces) > 0 {
+		w.push("Interfaces")
+		var list []*ast.Named
+		for i, item := range node.Interfaces {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Named, 0, len(node.Interfaces)), node.Interfaces[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asNamed(r, "ObjectDefinition.Interfaces"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Interfaces = list
+		}
+		w.pop()
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "ObjectDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.Fields) > 0 {
+		w.push("Fields")
+		var list []*ast.FieldDefinition
+		for i, item := range node.Fields {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.FieldDefinition, 0, len(node.Fields)), node.Fields[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asFieldDefinition(r, "ObjectDefinition.Fields"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Fields = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkObjectField(node *ast.ObjectField) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "ObjectField.Name")
+		}
+	}
+	if child, ok := node.Value.(ast.Node); ok {
+		if r, edited := w.walk("Value", child); edited {
+			node.Value = asValue(r, "ObjectField.Value")
+		}
+	}
+}
+
+func (w *walker) walkObjectValue(node *ast.ObjectValue) {
+	if len(node.Fields) > 0 {
+		w.push("Fields")
+		var list []*ast.ObjectField
+		for i, item := range node.Fields {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.ObjectField, 0, len(node.Fields)), node.Fields[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asObjectField(r, "ObjectValue.Fields"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Fields = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkOperationDefinition(node *ast.OperationDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "OperationDefinition.Name")
+		}
+	}
+	if len(node.VariableDefinitions) > 0 {
+		w.push("VariableDefinitions")
+		var list []*ast.VariableDefinition
+		for i, item := range node.VariableDefinitions {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.VariableDefinition, 0, len(node.VariableDefinitions)), node.VariableDefinitions[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asVariableDefinition(r, "OperationDefinition.VariableDefinitions"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.VariableDefinitions = list
+		}
+		w.pop()
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "OperationDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if child := node.SelectionSet; child != nil {
+		if r, edited := w.walk("SelectionSet", child); edited {
+			node.SelectionSet = asSelectionSet(r, "OperationDefinition.SelectionSet")
+		}
+	}
+}
+
+func (w *walker) walkOperationTypeDefinition(node *ast.OperationTypeDefinition) {
+	if child := node.Type; child != nil {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asNamed(r, "OperationTypeDefinition.Type")
+		}
+	}
+}
+
+func (w *walker) walkScalarDefinition(node *ast.ScalarDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "ScalarDefinition.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "ScalarDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkScalarExtensionDefinition(node *ast.ScalarExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asScalarDefinition(r, "ScalarExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkSchemaDefinition(node *ast.SchemaDefinition) {
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "SchemaDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.OperationTypes) > 0 {
+		w.push("OperationTypes")
+		var list []*ast.OperationTypeDefinition
+		for i, item := range node.OperationTypes {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.OperationTypeDefinition, 0, len(node.OperationTypes)), node.OperationTypes[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asOperationTypeDefinition(r, "SchemaDefinition.OperationTypes"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.OperationTypes = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkSchemaExtensionDefinition(node *ast.SchemaExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asSchemaDefinition(r, "SchemaExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkSelectionSet(node *ast.SelectionSet) {
+	if len(node.Selections) > 0 {
+		w.push("Selections")
+		var list []ast.Selection
+		for i, item := range node.Selections {
+			var r ast.Node
+			edited := false
+			if child, ok := item.(ast.Node); ok {
+				r, edited = w.walk(i, child)
+			}
+			if edited && list == nil {
+				list = append(make([]ast.Selection, 0, len(node.Selections)), node.Selections[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asSelection(r, "SelectionSet.Selections"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Selections = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkTypeExtensionDefinition(node *ast.TypeExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asObjectDefinition(r, "TypeExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkUnionDefinition(node *ast.UnionDefinition) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "UnionDefinition.Name")
+		}
+	}
+	if len(node.Directives) > 0 {
+		w.push("Directives")
+		var list []*ast.Directive
+		for i, item := range node.Directives {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Directive, 0, len(node.Directives)), node.Directives[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asDirective(r, "UnionDefinition.Directives"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Directives = list
+		}
+		w.pop()
+	}
+	if len(node.Types) > 0 {
+		w.push("Types")
+		var list []*ast.Named
+		for i, item := range node.Types {
+			var r ast.Node
+			edited := false
+			if item != nil {
+				r, edited = w.walk(i, item)
+			}
+			if edited && list == nil {
+				list = append(make([]*ast.Named, 0, len(node.Types)), node.Types[:i]...)
+			}
+			if edited {
+				if r != nil {
+					list = append(list, asNamed(r, "UnionDefinition.Types"))
+				}
+			} else if list != nil {
+				list = append(list, item)
+			}
+		}
+		if list != nil {
+			node.Types = list
+		}
+		w.pop()
+	}
+}
+
+func (w *walker) walkUnionExtensionDefinition(node *ast.UnionExtensionDefinition) {
+	if child := node.Definition; child != nil {
+		if r, edited := w.walk("Definition", child); edited {
+			node.Definition = asUnionDefinition(r, "UnionExtensionDefinition.Definition")
+		}
+	}
+}
+
+func (w *walker) walkVariable(node *ast.Variable) {
+	if child := node.Name; child != nil {
+		if r, edited := w.walk("Name", child); edited {
+			node.Name = asName(r, "Variable.Name")
+		}
+	}
+}
+
+func (w *walker) walkVariableDefinition(node *ast.VariableDefinition) {
+	if child := node.Variable; child != nil {
+		if r, edited := w.walk("Variable", child); edited {
+			node.Variable = asVariable(r, "VariableDefinition.Variable")
+		}
+	}
+	if child, ok := node.Type.(ast.Node); ok {
+		if r, edited := w.walk("Type", child); edited {
+			node.Type = asType(r, "VariableDefinition.Type")
+		}
+	}
+	if child, ok := node.DefaultValue.(ast.Node); ok {
+		if r, edited := w.walk("DefaultValue", child); edited {
+			node.DefaultValue = asValue(r, "VariableDefinition.DefaultValue")
+		}
+	}
+}
+
+func asArgument(node ast.Node, parent string) *ast.Argument {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.Argument)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asDirective(node ast.Node, parent string) *ast.Directive {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.Directive)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asEnumDefinition(node ast.Node, parent string) *ast.EnumDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.EnumDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asEnumValueDefinition(node ast.Node, parent string) *ast.EnumValueDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.EnumValueDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asFieldDefinition(node ast.Node, parent string) *ast.FieldDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.FieldDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asInputObjectDefinition(node ast.Node, parent string) *ast.InputObjectDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.InputObjectDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asInputValueDefinition(node ast.Node, parent string) *ast.InputValueDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.InputValueDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asInterfaceDefinition(node ast.Node, parent string) *ast.InterfaceDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.InterfaceDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asName(node ast.Node, parent string) *ast.Name {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.Name)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asNamed(node ast.Node, parent string) *ast.Named {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.Named)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asNode(node ast.Node, parent string) ast.Node {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(ast.Node)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asObjectDefinition(node ast.Node, parent string) *ast.ObjectDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.ObjectDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asObjectField(node ast.Node, parent string) *ast.ObjectField {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.ObjectField)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asOperationTypeDefinition(node ast.Node, parent string) *ast.OperationTypeDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.OperationTypeDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asScalarDefinition(node ast.Node, parent string) *ast.ScalarDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.ScalarDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asSchemaDefinition(node ast.Node, parent string) *ast.SchemaDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.SchemaDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asSelection(node ast.Node, parent string) ast.Selection {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(ast.Selection)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asSelectionSet(node ast.Node, parent string) *ast.SelectionSet {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.SelectionSet)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asType(node ast.Node, parent string) ast.Type {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(ast.Type)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asUnionDefinition(node ast.Node, parent string) *ast.UnionDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.UnionDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asValue(node ast.Node, parent string) ast.Value {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(ast.Value)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asVariable(node ast.Node, parent string) *ast.Variable {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.Variable)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
+
+func asVariableDefinition(node ast.Node, parent string) *ast.VariableDefinition {
+	if node == nil {
+		return nil
+	}
+	n, ok := node.(*ast.VariableDefinition)
+	if !ok {
+		mismatch(node, parent)
+	}
+	return n
+}
@@ -0,0 +1,299 @@
+package visitor_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+	"github.com/tailor-inc/graphql/language/visitor"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+// recordVisits returns visitor options recording the kind, key, parent kind and number
+// of ancestors of the nodes they enter and leave, and the path of those they enter.
+func recordVisits(visited *[]interface{}) *visitor.VisitorOptions {
+	record := func(action string) visitor.VisitFunc {
+		return func(p visitor.VisitFuncParams) (string, interface{}) {
+			var parentKind, path interface{}
+			if p.Parent != nil {
+				parentKind = p.Parent.GetKind()
+			}
+			if action == "enter" {
+				path = fmt.Sprint(p.Path)
+			}
+			*visited = append(*visited, []interface{}{
+				action, p.Node.(ast.Node).GetKind(), p.Key, parentKind, len(p.Ancestors), path,
+			})
+			return visitor.ActionNoChange, nil
+		}
+	}
+	return &visitor.VisitorOptions{Enter: record("enter"), Leave: record("leave")}
+}
+
+func TestWalk_WalksKitchenSinkLikeVisit(t *testing.T) {
+	for _, file := range []string{"../../kitchen-sink.graphql", "../../schema-kitchen-sink.graphql"} {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("unable to load %s", file)
+		}
+		astDoc := parse(t, string(b))
+
+		expectedVisited := []interface{}{}
+		visitor.Visit(astDoc, recordVisits(&expectedVisited), nil)
+		visited := []interface{}{}
+		visitor.Walk(astDoc, visitor.NewWalker(recordVisits(&visited)))
+
+		if !reflect.DeepEqual(visited, expectedVisited) {
+			t.Fatalf("Unexpected result for %s, Diff: %v", file, testutil.Diff(expectedVisited, visited))
+		}
+	}
+}
+
+func TestWalk_ProvidesTheCursor(t *testing.T) {
+	astDoc := parse(t, `{ a(x: 1) }`)
+
+	var cursor []interface{}
+	visitor.Walk(astDoc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if node, ok := c.Node().(*ast.IntValue); ok {
+				ancestors := []string{}
+				for _, ancestor := range c.Ancestors() {
+					ancestors = append(ancestors, ancestor.GetKind())
+				}
+				path := append([]interface{}{}, c.Path()...)
+				cursor = []interface{}{node.Value, c.Key(), c.Parent().GetKind(), ancestors, path}
+			}
+			return visitor.ActionNoChange
+		},
+	})
+
+	expected := []interface{}{
+		"1",
+		"Value",
+		"Argument",
+		[]string{"Document", "OperationDefinition", "SelectionSet", "Field"},
+		[]interface{}{"Definitions", 0, "SelectionSet", "Selections", 0, "Arguments", 0, "Value"},
+	}
+	if !reflect.DeepEqual(cursor, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, cursor))
+	}
+}
+
+func TestWalk_AllowsEditingOnEnterAndOnLeave(t *testing.T) {
+	astDoc := parse(t, `{ a, b, c { a, b, c } }`)
+
+	root := visitor.Walk(astDoc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if node, ok := c.Node().(*ast.Field); ok && node.Name.Value == "b" {
+				c.Delete()
+			}
+			return visitor.ActionNoChange
+		},
+		Leave: func(c *visitor.Cursor) string {
+			if node, ok := c.Node().(*ast.Name); ok && node.Value == "c" {
+				c.Replace(ast.NewName(&ast.Name{Value: "d"}))
+			}
+			return visitor.ActionNoChange
+		},
+	})
+
+	expected := `{
+  a
+  d {
+    a
+    d
+  }
+}
+`
+	if root != astDoc {
+		t.Fatalf("expected the root to be kept")
+	}
+	if printed := printer.Print(astDoc); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestWalk_WalksTheReplacementOfANodeOnEnter(t *testing.T) {
+	astDoc := parse(t, `{ a { x } }`)
+
+	names := []string{}
+	root := visitor.Walk(astDoc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			switch node := c.Node().(type) {
+			case *ast.Document:
+				c.Replace(parse(t, `{ b { y } }`))
+			case *ast.Name:
+				names = append(names, node.Value)
+			}
+			return visitor.ActionNoChange
+		},
+	})
+
+	if expected := []string{"b", "y"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, names))
+	}
+	if root == astDoc {
+		t.Fatalf("expected the root to be replaced")
+	}
+}
+
+func TestWalk_PanicsWhenAParentCannotHoldTheReplacement(t *testing.T) {
+	astDoc := parse(t, `{ a }`)
+
+	defer func() {
+		if r := recover(); r != "visitor: Field.Name can't hold *ast.IntValue" {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	visitor.Walk(astDoc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if _, ok := c.Node().(*ast.Name); ok {
+				c.Replace(ast.NewIntValue(&ast.IntValue{Value: "1"}))
+			}
+			return visitor.ActionNoChange
+		},
+	})
+}
+
+// walkNames returns a walker recording the names it enters as label:name, skipping the
+// field named skip and breaking at the name named stop.
+func walkNames(visited *[]string, label, skip, stop string) *visitor.Walker {
+	return &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			switch node := c.Node().(type) {
+			case *ast.Field:
+				if node.Name.Value == skip {
+					return visitor.ActionSkip
+				}
+			case *ast.Name:
+				*visited = append(*visited, label+":"+node.Value)
+				if node.Value == stop {
+					return visitor.ActionBreak
+				}
+			}
+			return visitor.ActionNoChange
+		},
+	}
+}
+
+func TestWalk_AllowsSkippingASubTreeAndEarlyExit(t *testing.T) {
+	astDoc := parse(t, `{ a, b { x }, c, d }`)
+
+	visited := []string{}
+	visitor.Walk(astDoc, walkNames(&visited, "w", "b", "c"))
+
+	if expected := []string{"w:a", "w:c"}; !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, visited))
+	}
+}
+
+func TestWalk_CallsTheFunctionsOfEachKind(t *testing.T) {
+	astDoc := parse(t, `{ a(x: 1), b { c }, d(x: 2) }`)
+
+	visited := []string{}
+	edited := visitor.Walk(astDoc, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if _, ok := c.Node().(*ast.Field); ok {
+				visited = append(visited, "enter")
+			}
+			return visitor.ActionNoChange
+		},
+		Leave: func(c *visitor.Cursor) string {
+			if _, ok := c.Node().(*ast.Field); ok {
+				visited = append(visited, "leave")
+			}
+			return visitor.ActionNoChange
+		},
+		KindWalkFuncs: visitor.KindWalkFuncs{
+			EnterField: func(c *visitor.Cursor, field *ast.Field) string {
+				visited = append(visited, "enter "+field.Name.Value)
+				if field.Name.Value == "b" {
+					return visitor.ActionSkip
+				}
+				return visitor.ActionNoChange
+			},
+			LeaveField: func(c *visitor.Cursor, field *ast.Field) string {
+				visited = append(visited, "leave "+field.Name.Value)
+				return visitor.ActionNoChange
+			},
+			LeaveIntValue: func(c *visitor.Cursor, value *ast.IntValue) string {
+				if value.Value == "2" {
+					c.Replace(ast.NewIntValue(&ast.IntValue{Value: "3"}))
+				}
+				return visitor.ActionNoChange
+			},
+		},
+	})
+
+	expected := []string{
+		"enter", "enter a", "leave a", "leave",
+		"enter", "enter b",
+		"enter", "enter d", "leave d", "leave",
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, visited))
+	}
+	if printed := printer.Print(edited); printed != "{\n  a(x: 1)\n  b {\n    c\n  }\n  d(x: 3)\n}\n" {
+		t.Fatalf("Unexpected result, got %v", printed)
+	}
+}
+
+func TestWalkInParallel_KeepsSkipsAndBreaksApart(t *testing.T) {
+	astDoc := parse(t, `{ a { x }, b { y }, c }`)
+
+	visited := []string{}
+	visitor.Walk(astDoc, visitor.WalkInParallel(
+		walkNames(&visited, "no-a", "a", "y"),
+		walkNames(&visited, "no-b", "b", ""),
+	))
+
+	expected := []string{
+		"no-b:a", "no-b:x",
+		"no-a:b", "no-a:y",
+		"no-b:c",
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, visited))
+	}
+}
+
+func TestWalkWithTypeInfo_MaintainsTypeInfoDuringWalk(t *testing.T) {
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{
+		Schema: testutil.TestSchema,
+	})
+	astDoc := parse(t, `{ human(id: 4) { name, pets { name }, unknown } }`)
+
+	visited := []interface{}{}
+	visitor.Walk(astDoc, visitor.WalkWithTypeInfo(typeInfo, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if node, ok := c.Node().(*ast.Name); ok {
+				var parentType, fieldType interface{}
+				if t := typeInfo.ParentType(); t != nil {
+					parentType = t.String()
+				}
+				if t := typeInfo.Type(); t != nil {
+					fieldType = t.String()
+				}
+				visited = append(visited, []interface{}{node.Value, parentType, fieldType})
+			}
+			if node, ok := c.Node().(*ast.Field); ok && node.Name.Value == "pets" {
+				return visitor.ActionSkip
+			}
+			return visitor.ActionNoChange
+		},
+	}))
+
+	expected := []interface{}{
+		[]interface{}{"human", "QueryRoot", "Human"},
+		[]interface{}{"id", "QueryRoot", "Human"},
+		[]interface{}{"name", "Human", "String"},
+		[]interface{}{"unknown", "Human", nil},
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, visited))
+	}
+}
@@ -0,0 +1,224 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MockFn returns the mock of a value, drawing random numbers from rand. The mock of an
+// object type is a map of the values of some of its fields, the others being mocked.
+type MockFn func(rand *rand.Rand) interface{}
+
+// MockConfig configures the mocks of AddMocks.
+type MockConfig struct {
+	// Seed seeds the random mocks. The mock of a field depends only on the seed and on
+	// its path in the response, so that a query always returns the same result.
+	Seed int64
+
+	// ListLength is the length of mocked lists, 2 when zero.
+	ListLength int
+
+	// Types maps the names of types to their mocks, replacing the default mocks of
+	// scalars, enums, objects, interfaces and unions. The mocks of interfaces and unions
+	// must set "__typename" to the name of the object type they resolve to. DateTime and
+	// the scalars of the scalars package are mocked by their name with values which
+	// they serialize, and other custom scalars as strings.
+	Types map[string]MockFn
+
+	// Fields maps field coordinates, as in "User.name", to resolvers replacing the
+	// mocks of the fields.
+	Fields map[string]FieldResolveFn
+
+	// PreserveResolvers keeps the resolvers of the fields which have one.
+	PreserveResolvers bool
+}
+
+// AddMocks returns a schema resolving every field of schema with plausible mock values,
+// which can serve queries before resolvers exist, as with a schema of ParseSDL.
+//
+// Scalars have random values of their type, lists have ListLength items and interfaces
+// and unions resolve to one of their possible types.
+func AddMocks(schema Schema, mocks MockConfig) (Schema, error) {
+	if mocks.ListLength == 0 {
+		mocks.ListLength = 2
+	}
+	m := &mocker{schema: schema, config: mocks}
+	return rebuildSchema(&schemaRebuilder{
+		schema:          schema,
+		wrapResolver:    m.resolver,
+		wrapResolveType: m.resolveType,
+		wrapIsTypeOf:    m.isTypeOf,
+		wrapSerialize:   m.serialize,
+	})
+}
+
+type mocker struct {
+	schema Schema
+	config MockConfig
+}
+
+// resolver returns the mock resolver of field. Fields are taken from the mocks of
+// their parent objects, when these have them.
+func (m *mocker) resolver(parentType Type, field *FieldDefinition, resolve FieldResolveFn) FieldResolveFn {
+	if field.Resolve != nil && m.config.PreserveResolvers {
+		return resolve
+	}
+	if mock, ok := m.config.Fields[parentType.Name()+"."+field.Name]; ok {
+		return mock
+	}
+	return func(p ResolveParams) (interface{}, error) {
+		if source, ok := p.Source.(map[string]interface{}); ok {
+			if value, ok := source[field.Name]; ok {
+				return value, nil
+			}
+		}
+		return m.value(field.Type, field.Name, m.rand(p.Info.Path)), nil
+	}
+}
+
+// rand returns the random numbers of the field at path.
+func (m *mocker) rand(path *ResponsePath) *rand.Rand {
+	h := fnv.New64a()
+	for ; path != nil; path = path.Prev {
+		fmt.Fprintf(h, "%v.", path.Key)
+	}
+	return rand.New(rand.NewSource(m.config.Seed ^ int64(h.Sum64())))
+}
+
+// value mocks a value of ttype for the field fieldName.
+func (m *mocker) value(ttype Type, fieldName string, r *rand.Rand) interface{} {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return m.value(ttype.OfType, fieldName, r)
+	case *List:
+		values := make([]interface{}, m.config.ListLength)
+		for i := range values {
+			values[i] = m.value(ttype.OfType, fieldName, r)
+		}
+		return values
+	case *Interface, *Union:
+		if mock, ok := m.config.Types[ttype.Name()]; ok {
+			return mock(r)
+		}
+		possibleTypes := append([]*Object(nil), m.schema.PossibleTypes(ttype)...)
+		if len(possibleTypes) == 0 {
+			return nil
+		}
+		sort.Slice(possibleTypes, func(i, j int) bool { return possibleTypes[i].Name() < possibleTypes[j].Name() })
+		object := possibleTypes[r.Intn(len(possibleTypes))]
+		value := map[string]interface{}{}
+		if fields, ok := m.value(object, fieldName, r).(map[string]interface{}); ok {
+			for name, field := range fields {
+				value[name] = field
+			}
+		}
+		value[TypeNameMetaFieldDef.Name] = object.Name()
+		return value
+	}
+
+	if mock, ok := m.config.Types[ttype.Name()]; ok {
+		return mock(r)
+	}
+	switch ttype := ttype.(type) {
+	case *Object:
+		return map[string]interface{}{}
+	case *Enum:
+		values := append([]*EnumValueDefinition(nil), ttype.Values()...)
+		if len(values) == 0 {
+			return nil
+		}
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+		return values[r.Intn(len(values))].Value
+	}
+	switch ttype {
+	case Int:
+		return r.Intn(100)
+	case Float:
+		return math.Round(r.Float64()*10000) / 100
+	case Boolean:
+		return r.Intn(2) == 1
+	case ID:
+		return strconv.Itoa(r.Intn(1000000))
+	}
+	if mock, ok := scalarMocks[ttype.Name()]; ok {
+		return mock(r)
+	}
+	// Strings tell which field they are mocking.
+	return fieldName + " " + strconv.Itoa(r.Intn(100))
+}
+
+// scalarMocks are the default mocks of DateTime and of the scalars of the scalars
+// package, by name, since their serialize rejects the strings mocking custom scalars.
+var scalarMocks = map[string]MockFn{
+	DateTime.Name(): func(r *rand.Rand) interface{} { return mockTime(r) },
+	"Date":          func(r *rand.Rand) interface{} { return mockTime(r).Format("2006-01-02") },
+	"Time":          func(r *rand.Rand) interface{} { return mockTime(r).Format("15:04:05") },
+	"Duration":      func(r *rand.Rand) interface{} { return "PT" + strconv.Itoa(r.Intn(600)+1) + "M" },
+	"BigInt":        func(r *rand.Rand) interface{} { return strconv.FormatInt(r.Int63(), 10) },
+	"Decimal": func(r *rand.Rand) interface{} {
+		return strconv.FormatFloat(math.Round(r.Float64()*10000)/100, 'f', -1, 64)
+	},
+	"Int64": func(r *rand.Rand) interface{} { return r.Int63() },
+	"UUID": func(r *rand.Rand) interface{} {
+		return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x", r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), r.Intn(1<<12), r.Int63n(1<<48))
+	},
+	"Email": func(r *rand.Rand) interface{} { return "user" + strconv.Itoa(r.Intn(100)) + "@example.com" },
+	"URL":   func(r *rand.Rand) interface{} { return "https://example.com/" + strconv.Itoa(r.Intn(100)) },
+	"Byte": func(r *rand.Rand) interface{} {
+		b := make([]byte, 8)
+		r.Read(b)
+		return base64.StdEncoding.EncodeToString(b)
+	},
+}
+
+// mockTime returns a time of the years 2020 to 2024, in seconds.
+func mockTime(r *rand.Rand) time.Time {
+	return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(r.Int63n(5*365*24*60*60)) * time.Second)
+}
+
+// resolveType resolves mocks of abstract types by their __typename.
+func (m *mocker) resolveType(abstractType Abstract, resolve ResolveTypeFn) ResolveTypeFn {
+	return func(p ResolveTypeParams) *Object {
+		if value, ok := p.Value.(map[string]interface{}); ok {
+			if name, ok := value[TypeNameMetaFieldDef.Name].(string); ok {
+				object, _ := p.Info.Schema.Type(name).(*Object)
+				return object
+			}
+		}
+		if resolve != nil {
+			return resolve(p)
+		}
+		return defaultResolveTypeFn(p, abstractType)
+	}
+}
+
+// isTypeOf accepts the mocks of object, which are maps of its fields.
+func (m *mocker) isTypeOf(object *Object, isTypeOf IsTypeOfFn) IsTypeOfFn {
+	if isTypeOf == nil {
+		return nil
+	}
+	return func(p IsTypeOfParams) bool {
+		if value, ok := p.Value.(map[string]interface{}); ok {
+			name, ok := value[TypeNameMetaFieldDef.Name]
+			return !ok || name == object.Name()
+		}
+		return isTypeOf(p)
+	}
+}
+
+// serialize passes through the mocks of custom scalars which serialize cannot
+// serialize, as the scalars of ParseSDL, which serialize no value.
+func (m *mocker) serialize(scalar *Scalar, serialize SerializeFn) SerializeFn {
+	return func(value interface{}) interface{} {
+		if serialized := serialize(value); serialized != nil {
+			return serialized
+		}
+		return value
+	}
+}
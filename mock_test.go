@@ -0,0 +1,203 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/scalars"
+)
+
+const mockSDL = `
+interface Node { id: ID! }
+
+type User implements Node {
+  id: ID!
+  name: String
+  age: Int
+  score: Float
+  active: Boolean
+  role: Role
+  joined: Date
+  posts: [Post!]!
+}
+
+type Post implements Node {
+  id: ID!
+  title: String
+}
+
+enum Role { ADMIN USER }
+
+union SearchResult = User | Post
+
+scalar Date
+
+type Query {
+  me: User
+  search: [SearchResult]
+  node(id: ID!): Node
+  version: String
+}
+`
+
+func mockSchema(t *testing.T, mocks graphql.MockConfig) graphql.Schema {
+	schema, err := graphql.ParseSDL(mockSDL, func(typeName, fieldName string) graphql.FieldResolveFn {
+		if typeName == "Query" && fieldName == "version" {
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				return "1.0", nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocked, err := graphql.AddMocks(*schema, mocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mocked
+}
+
+func mockQuery(t *testing.T, schema graphql.Schema, query string) map[string]interface{} {
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+const mockQueryString = `{
+	me { id name age score active role joined posts { id title } }
+	search { __typename ... on Node { id } }
+	node(id: "1") { __typename }
+	version
+}`
+
+func TestAddMocks_MocksEveryField(t *testing.T) {
+	schema := mockSchema(t, graphql.MockConfig{Seed: 1, ListLength: 3})
+	data := mockQuery(t, schema, mockQueryString)
+
+	me := data["me"].(map[string]interface{})
+	for field, ok := range map[string]bool{
+		"id":     isString(me["id"]),
+		"name":   isString(me["name"]),
+		"age":    isNumber(me["age"]),
+		"score":  isNumber(me["score"]),
+		"active": isBool(me["active"]),
+		"role":   me["role"] == "ADMIN" || me["role"] == "USER",
+		"joined": isString(me["joined"]),
+	} {
+		if !ok {
+			t.Fatalf("Unexpected mock of %v: %v", field, me[field])
+		}
+	}
+	if posts := me["posts"].([]interface{}); len(posts) != 3 {
+		t.Fatalf("expected 3 posts, got %v", posts)
+	}
+	for _, result := range data["search"].([]interface{}) {
+		result := result.(map[string]interface{})
+		if typename := result["__typename"]; (typename != "User" && typename != "Post") || !isString(result["id"]) {
+			t.Fatalf("Unexpected search result %v", result)
+		}
+	}
+	if typename := data["node"].(map[string]interface{})["__typename"]; typename != "User" && typename != "Post" {
+		t.Fatalf("Unexpected node type %v", typename)
+	}
+	if data["version"] == "1.0" {
+		t.Fatalf("expected the resolver of version to be replaced")
+	}
+
+	again, _ := json.Marshal(mockQuery(t, schema, mockQueryString))
+	first, _ := json.Marshal(data)
+	if string(again) != string(first) {
+		t.Fatalf("expected the mocks to be deterministic, got %s and %s", first, again)
+	}
+	other, _ := json.Marshal(mockQuery(t, mockSchema(t, graphql.MockConfig{Seed: 2, ListLength: 3}), mockQueryString))
+	if string(other) == string(first) {
+		t.Fatalf("expected the mocks to depend on the seed")
+	}
+}
+
+func TestAddMocks_OverridesTypesAndFields(t *testing.T) {
+	schema := mockSchema(t, graphql.MockConfig{
+		Types: map[string]graphql.MockFn{
+			"Date": func(r *rand.Rand) interface{} { return "2026-01-01" },
+			"User": func(r *rand.Rand) interface{} { return map[string]interface{}{"name": "Ada"} },
+		},
+		Fields: map[string]graphql.FieldResolveFn{
+			"User.age": func(p graphql.ResolveParams) (interface{}, error) { return 36, nil },
+		},
+		PreserveResolvers: true,
+	})
+	data := mockQuery(t, schema, `{ me { name age joined posts { id } } version }`)
+
+	me := data["me"].(map[string]interface{})
+	if me["name"] != "Ada" || me["age"] != float64(36) || me["joined"] != "2026-01-01" {
+		t.Fatalf("expected the overrides to be used, got %v", me)
+	}
+	if posts := me["posts"].([]interface{}); len(posts) != 2 {
+		t.Fatalf("expected 2 posts by default, got %v", posts)
+	}
+	if data["version"] != "1.0" {
+		t.Fatalf("expected the resolver of version to be preserved, got %v", data["version"])
+	}
+}
+
+func TestAddMocks_MocksScalarsByName(t *testing.T) {
+	sdl := `scalar DateTime type Query { now: DateTime`
+	query := `{ now`
+	for _, scalar := range scalars.All() {
+		if scalar != scalars.Upload && scalar != scalars.JSON {
+			sdl = `scalar ` + scalar.Name() + ` ` + sdl + ` ` + scalar.Name() + `: ` + scalar.Name()
+			query += ` ` + scalar.Name()
+		}
+	}
+	schema, err := graphql.ParseSDL(sdl+` }`, func(typeName, fieldName string) graphql.FieldResolveFn {
+		return nil
+	}, scalars.Lookup, func(name string) graphql.Type {
+		if name == graphql.DateTime.Name() {
+			return graphql.DateTime
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocked, err := graphql.AddMocks(*schema, graphql.MockConfig{Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := mockQuery(t, mocked, query+` }`)
+	for field, value := range data {
+		scalar := mocked.QueryType().Fields()[field].Type.(*graphql.Scalar)
+		if s, ok := value.(string); ok && scalar.ParseValue(s) == nil || value == nil {
+			t.Fatalf("Unexpected mock of %v: %v", field, value)
+		}
+	}
+}
+
+func isString(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && s != ""
+}
+
+func isNumber(value interface{}) bool {
+	_, ok := value.(float64)
+	return ok
+}
+
+func isBool(value interface{}) bool {
+	_, ok := value.(bool)
+	return ok
+}
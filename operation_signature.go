@@ -0,0 +1,262 @@
+package graphql
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// OperationSignature returns the signature of an operation, which is the same for all
+// operations of the same shape. It follows the usage reporting signature of Apollo:
+// only the operation and the fragments it uses are kept, literal values are hidden,
+// aliases are dropped, fields, fragments, variables, arguments and the directives of
+// fragments are sorted, and the result is printed with as little whitespace as possible.
+//
+// operationName may be empty if document contains a single operation.
+func OperationSignature(document *ast.Document, operationName string) (string, error) {
+	operation, fragments, err := signatureDefinitions(document, operationName)
+	if err != nil {
+		return "", err
+	}
+
+	definitions := []ast.Node{}
+	for _, fragment := range fragments {
+		definitions = append(definitions, ast.NewFragmentDefinition(&ast.FragmentDefinition{
+			Name:                fragment.Name,
+			VariableDefinitions: signatureVariableDefinitions(fragment.VariableDefinitions),
+			TypeCondition:       fragment.TypeCondition,
+			Directives:          signatureDirectives(fragment.Directives),
+			SelectionSet:        signatureSelectionSet(fragment.SelectionSet),
+		}))
+	}
+	definitions = append(definitions, ast.NewOperationDefinition(&ast.OperationDefinition{
+		Operation:           operation.Operation,
+		Name:                operation.Name,
+		VariableDefinitions: signatureVariableDefinitions(operation.VariableDefinitions),
+		Directives:          signatureDirectiveArguments(operation.Directives),
+		SelectionSet:        signatureSelectionSet(operation.SelectionSet),
+	}))
+	return printWithReducedWhitespace(definitions), nil
+}
+
+// OperationSignatureHash returns a stable hash of the signature and the name of an
+// operation. It is the hex encoded SHA-1 of the Apollo stats report key
+// "# <name>\n<signature>", which Apollo uses as the ID of the operation.
+func OperationSignatureHash(document *ast.Document, operationName string) (string, error) {
+	signature, err := OperationSignature(document, operationName)
+	if err != nil {
+		return "", err
+	}
+	operation, _, _ := signatureDefinitions(document, operationName)
+	name := "-"
+	if operation.Name != nil && operation.Name.Value != "" {
+		name = operation.Name.Value
+	}
+	hash := sha1.Sum([]byte("# " + name + "\n" + signature))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// signatureDefinitions returns the operation and the fragments it uses, with the
+// fragments sorted by name.
+func signatureDefinitions(document *ast.Document, operationName string) (*ast.OperationDefinition, []*ast.FragmentDefinition, error) {
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" && operation != nil {
+				return nil, nil, errors.New("Must provide operation name if query contains multiple operations.")
+			}
+			if operationName == "" || definition.Name != nil && definition.Name.Value == operationName {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			if definition.Name != nil {
+				fragments[definition.Name.Value] = definition
+			}
+		}
+	}
+	if operation == nil {
+		if operationName != "" {
+			return nil, nil, fmt.Errorf(`Unknown operation named "%v".`, operationName)
+		}
+		return nil, nil, errors.New("Must provide an operation.")
+	}
+
+	used := map[string]bool{}
+	var collect func(selectionSet *ast.SelectionSet)
+	collect = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			if spread, ok := selection.(*ast.FragmentSpread); ok {
+				name := spread.Name.Value
+				if fragment, ok := fragments[name]; ok && !used[name] {
+					used[name] = true
+					collect(fragment.SelectionSet)
+				}
+				continue
+			}
+			collect(selection.GetSelectionSet())
+		}
+	}
+	collect(operation.SelectionSet)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	usedFragments := make([]*ast.FragmentDefinition, 0, len(names))
+	for _, name := range names {
+		usedFragments = append(usedFragments, fragments[name])
+	}
+	return operation, usedFragments, nil
+}
+
+// signatureSelectionSet drops aliases and sorts fields before fragment spreads before
+// inline fragments. Fields and spreads are sorted by name, inline fragments keep their
+// order. As in Apollo, the directives of fields and operations keep their order.
+func signatureSelectionSet(selectionSet *ast.SelectionSet) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	selections := make([]ast.Selection, 0, len(selectionSet.Selections))
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selections = append(selections, ast.NewField(&ast.Field{
+				Name:         selection.Name,
+				Arguments:    signatureArguments(selection.Arguments),
+				Directives:   signatureDirectiveArguments(selection.Directives),
+				SelectionSet: signatureSelectionSet(selection.SelectionSet),
+			}))
+		case *ast.FragmentSpread:
+			selections = append(selections, ast.NewFragmentSpread(&ast.FragmentSpread{
+				Name:       selection.Name,
+				Directives: signatureDirectives(selection.Directives),
+			}))
+		case *ast.InlineFragment:
+			selections = append(selections, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: selection.TypeCondition,
+				Directives:    signatureDirectives(selection.Directives),
+				SelectionSet:  signatureSelectionSet(selection.SelectionSet),
+			}))
+		}
+	}
+	sort.SliceStable(selections, func(i, j int) bool {
+		iKind, jKind := selectionKind(selections[i]), selectionKind(selections[j])
+		if iKind != jKind {
+			return iKind < jKind
+		}
+		return selectionName(selections[i]) < selectionName(selections[j])
+	})
+	return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+}
+
+func selectionKind(selection ast.Selection) string {
+	return selection.(ast.Node).GetKind()
+}
+
+func selectionName(selection ast.Selection) string {
+	switch selection := selection.(type) {
+	case *ast.Field:
+		return selection.Name.Value
+	case *ast.FragmentSpread:
+		return selection.Name.Value
+	}
+	return ""
+}
+
+func signatureVariableDefinitions(variableDefinitions []*ast.VariableDefinition) []*ast.VariableDefinition {
+	result := make([]*ast.VariableDefinition, 0, len(variableDefinitions))
+	for _, variableDefinition := range variableDefinitions {
+		result = append(result, ast.NewVariableDefinition(&ast.VariableDefinition{
+			Variable:     variableDefinition.Variable,
+			Type:         variableDefinition.Type,
+			DefaultValue: hideLiteral(variableDefinition.DefaultValue),
+		}))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Variable.Name.Value < result[j].Variable.Name.Value
+	})
+	return result
+}
+
+func signatureDirectives(directives []*ast.Directive) []*ast.Directive {
+	result := signatureDirectiveArguments(directives)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name.Value < result[j].Name.Value
+	})
+	return result
+}
+
+func signatureDirectiveArguments(directives []*ast.Directive) []*ast.Directive {
+	result := make([]*ast.Directive, 0, len(directives))
+	for _, directive := range directives {
+		result = append(result, ast.NewDirective(&ast.Directive{
+			Name:      directive.Name,
+			Arguments: signatureArguments(directive.Arguments),
+		}))
+	}
+	return result
+}
+
+func signatureArguments(arguments []*ast.Argument) []*ast.Argument {
+	result := make([]*ast.Argument, 0, len(arguments))
+	for _, argument := range arguments {
+		result = append(result, ast.NewArgument(&ast.Argument{
+			Name:  argument.Name,
+			Value: hideLiteral(argument.Value),
+		}))
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name.Value < result[j].Name.Value
+	})
+	return result
+}
+
+// hideLiteral replaces numbers by 0, strings by "" and lists and objects by empty ones.
+// Booleans, enum values, null and variables are kept.
+func hideLiteral(value ast.Value) ast.Value {
+	switch value.(type) {
+	case *ast.IntValue:
+		return ast.NewIntValue(&ast.IntValue{Value: "0"})
+	case *ast.FloatValue:
+		return ast.NewFloatValue(&ast.FloatValue{Value: "0"})
+	case *ast.StringValue:
+		return ast.NewStringValue(&ast.StringValue{Value: ""})
+	case *ast.ListValue:
+		return ast.NewListValue(&ast.ListValue{Values: []ast.Value{}})
+	case *ast.ObjectValue:
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: []*ast.ObjectField{}})
+	}
+	return value
+}
+
+var (
+	whitespaceRegexp       = regexp.MustCompile(`\s+`)
+	spaceAfterPunctuation  = regexp.MustCompile(`([^_a-zA-Z0-9]) `)
+	spaceBeforePunctuation = regexp.MustCompile(` ([^_a-zA-Z0-9])`)
+)
+
+// printWithReducedWhitespace prints definitions and removes all whitespace that is not
+// needed to separate names. Literals are hidden by then, so no string holds whitespace.
+func printWithReducedWhitespace(definitions []ast.Node) string {
+	printed := ""
+	for _, definition := range definitions {
+		printed += fmt.Sprint(printer.Print(definition)) + " "
+	}
+	printed = whitespaceRegexp.ReplaceAllString(printed, " ")
+	printed = spaceAfterPunctuation.ReplaceAllString(printed, "$1")
+	printed = spaceBeforePunctuation.ReplaceAllString(printed, "$1")
+	return strings.TrimSpace(printed)
+}
@@ -0,0 +1,158 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/parser"
+)
+
+func parseSignatureDocument(t *testing.T, query string) *ast.Document {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return document
+}
+
+func TestOperationSignature(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		expected      string
+	}{
+		{
+			name: "basic",
+			query: `
+				{
+					user {
+						name
+					}
+				}
+			`,
+			expected: `{user{name}}`,
+		},
+		{
+			name: "fragments, inline fragments, aliases and literals",
+			query: `
+				query Foo($b: Int, $a: Boolean) {
+					user(name: "hello", age: 5) {
+						...Bar
+						... on User {
+							hello
+							bee
+						}
+						tz
+						aliased: name
+					}
+				}
+
+				fragment Bar on User {
+					age @skip(if: $a)
+					...Nested
+				}
+
+				fragment Nested on User {
+					blah
+				}
+			`,
+			expected: `fragment Bar on User{age@skip(if:$a)...Nested}fragment Nested on User{blah}` +
+				`query Foo($a:Boolean,$b:Int){user(age:0,name:""){name tz...Bar...on User{bee hello}}}`,
+		},
+		{
+			name: "keeps only the chosen operation and the fragments it uses",
+			query: `
+				query Unused { a }
+				query Used { ...Used }
+				fragment Unused on Query { b }
+				fragment Used on Query { c }
+			`,
+			operationName: "Used",
+			expected:      `fragment Used on Query{c}query Used{...Used}`,
+		},
+		{
+			name: "hides every literal",
+			query: `
+				query ($list: [Int] = [1, 2], $object: In = {a: 1.5}) {
+					f(string: "s", float: 1.5, list: [ENUM], object: {a: 1}, boolean: true, enum: VALUE, variable: $list)
+				}
+			`,
+			expected: `query($list:[Int]=[],$object:In={}){f(boolean:true,enum:VALUE,float:0,list:[],object:{},string:"",variable:$list)}`,
+		},
+		{
+			name: "sorts the directives of fragments but not of fields",
+			query: `
+				{
+					f @b @a(y: 1, x: 2)
+					... @d @c { g }
+				}
+			`,
+			expected: `{f@b@a(x:0,y:0)...@c@d{g}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature, err := graphql.OperationSignature(parseSignatureDocument(t, test.query), test.operationName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if signature != test.expected {
+				t.Fatalf("Expected signature %v, got %v", test.expected, signature)
+			}
+		})
+	}
+}
+
+func TestOperationSignature_IsTheSameForOperationsOfTheSameShape(t *testing.T) {
+	first, err := graphql.OperationSignature(parseSignatureDocument(t, `
+		query Q { b: user(id: 1) { name id } }
+	`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, err := graphql.OperationSignature(parseSignatureDocument(t, `query Q {
+		user(id: 2) { id, name }
+	}`), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first != second {
+		t.Fatalf("Expected equal signatures, got %v and %v", first, second)
+	}
+}
+
+func TestOperationSignature_RequiresAKnownOperation(t *testing.T) {
+	document := parseSignatureDocument(t, `query A { a } query B { b }`)
+	if _, err := graphql.OperationSignature(document, ""); err == nil ||
+		err.Error() != "Must provide operation name if query contains multiple operations." {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := graphql.OperationSignature(document, "C"); err == nil || err.Error() != `Unknown operation named "C".` {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestOperationSignatureHash(t *testing.T) {
+	document := parseSignatureDocument(t, `
+		query Foo($b: Int, $a: Boolean) {
+			user(name: "hello", age: 5) {
+				...Bar
+				... on User { hello bee }
+				tz
+				aliased: name
+			}
+		}
+		fragment Bar on User { age @skip(if: $a) ...Nested }
+		fragment Nested on User { blah }
+	`)
+	hash, err := graphql.OperationSignatureHash(document, "Foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// SHA-1 of "# Foo\n" followed by the signature.
+	if expected := "d7b27fb600a2b427ff4cf42bfa2085fb79740f58"; hash != expected {
+		t.Fatalf("Expected hash %v, got %v", expected, hash)
+	}
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// OrderedMap is an object of the data of a Result. It keeps its fields in the order of
+// the selection set, which is the order they are marshaled to JSON in, as required by
+// the specification.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewOrderedMap creates an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{values: map[string]interface{}{}}
+}
+
+// Set sets the value of key, appending key unless it is already set.
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Get returns the value of key.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Keys returns the keys of m in order.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+// Len returns the number of keys of m.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Map returns m as a map, converting the nested ordered maps too.
+func (m *OrderedMap) Map() map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(m.keys))
+	for key, value := range m.values {
+		result[key] = plainValue(value)
+	}
+	return result
+}
+
+// MarshalJSON encodes m as a JSON object with the keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into m, keeping its keys in order. Nested
+// objects are decoded as ordered maps too, and numbers as json.Number so that they
+// encode back unchanged.
+func (m *OrderedMap) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return err
+	}
+	decoded, ok := value.(*OrderedMap)
+	if !ok {
+		return errors.New("graphql: cannot unmarshal a JSON value which is not an object into an OrderedMap")
+	}
+	*m = *decoded
+	return nil
+}
+
+// decodeOrderedValue decodes the next JSON value of decoder, with its objects as
+// ordered maps.
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		m := NewOrderedMap()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(key.(string), value)
+		}
+		_, err := decoder.Token()
+		return m, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := decoder.Token()
+		return list, err
+	}
+	return token, nil
+}
+
+// plainValue replaces the ordered maps of value by maps.
+func plainValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *OrderedMap:
+		if value == nil {
+			return nil
+		}
+		return value.Map()
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = plainValue(item)
+		}
+		return list
+	}
+	return value
+}
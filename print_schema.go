@@ -0,0 +1,229 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/printer"
+)
+
+// PrintSchema returns the SDL of schema in a canonical form: definitions are sorted by
+// name, as are the fields, arguments and values they declare, so that equivalent
+// schemas print the same way. Introspection types, the specified scalars and the
+// specified directives are left out, and the schema definition is only printed when a
+// root operation type isn't named after its operation.
+func PrintSchema(schema Schema) string {
+	var definitions []ast.Node
+	if definition := schemaAsNode(schema); definition != nil {
+		definitions = append(definitions, definition)
+	}
+	for _, directive := range schema.Directives() {
+		if !isSpecifiedDirective(directive) {
+			definitions = append(definitions, directiveAsNode(directive))
+		}
+	}
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || isSpecifiedScalar(ttype) {
+			continue
+		}
+		definitions = append(definitions, namedTypeAsNode(ttype))
+	}
+
+	var sb strings.Builder
+	printer.Fprint(&sb, ast.NewDocument(&ast.Document{Definitions: definitions}), printer.PrintOptions{Sort: true})
+	return sb.String()
+}
+
+func isSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if directive.Name == specified.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpecifiedScalar(ttype Type) bool {
+	switch ttype {
+	case String, Int, Float, Boolean, ID:
+		return true
+	}
+	return false
+}
+
+// schemaAsNode returns the schema definition of schema, or nil when its root operation
+// types have the default names.
+func schemaAsNode(schema Schema) *ast.SchemaDefinition {
+	roots := []struct {
+		operation string
+		ttype     *Object
+	}{
+		{ast.OperationTypeQuery, schema.QueryType()},
+		{ast.OperationTypeMutation, schema.MutationType()},
+		{ast.OperationTypeSubscription, schema.SubscriptionType()},
+	}
+	var operationTypes []*ast.OperationTypeDefinition
+	named := true
+	for _, root := range roots {
+		if root.ttype == nil {
+			continue
+		}
+		named = named && strings.EqualFold(root.ttype.Name(), root.operation)
+		operationTypes = append(operationTypes, ast.NewOperationTypeDefinition(&ast.OperationTypeDefinition{
+			Operation: root.operation,
+			Type:      ast.NewNamed(&ast.Named{Name: nameAsNode(root.ttype.Name())}),
+		}))
+	}
+	if named {
+		return nil
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{OperationTypes: operationTypes})
+}
+
+func nameAsNode(name string) *ast.Name {
+	return ast.NewName(&ast.Name{Value: name})
+}
+
+func descriptionAsNode(description string) *ast.StringValue {
+	if description == "" {
+		return nil
+	}
+	return ast.NewStringValue(&ast.StringValue{Value: description})
+}
+
+func namedAsNodes(types []Type) []*ast.Named {
+	var named []*ast.Named
+	for _, ttype := range types {
+		named = append(named, ast.NewNamed(&ast.Named{Name: nameAsNode(ttype.Name())}))
+	}
+	return named
+}
+
+func directiveAsNode(directive *Directive) *ast.DirectiveDefinition {
+	var locations []*ast.Name
+	for _, location := range directive.Locations {
+		locations = append(locations, nameAsNode(location))
+	}
+	return ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
+		Name:        nameAsNode(directive.Name),
+		Description: descriptionAsNode(directive.Description),
+		Arguments:   argumentsAsNodes(directive.Args),
+		Repeatable:  directive.IsRepeatable,
+		Locations:   locations,
+	})
+}
+
+// namedTypeAsNode returns the definition of ttype, which is a named type.
+func namedTypeAsNode(ttype Type) ast.Node {
+	switch ttype := ttype.(type) {
+	case *Object:
+		var interfaces []Type
+		for _, iface := range ttype.Interfaces() {
+			interfaces = append(interfaces, iface)
+		}
+		return ast.NewObjectDefinition(&ast.ObjectDefinition{
+			Name:        nameAsNode(ttype.Name()),
+			Description: descriptionAsNode(ttype.Description()),
+			Interfaces:  namedAsNodes(interfaces),
+			Directives:  directivesAsNode(ttype.Directives()),
+			Fields:      fieldsAsNodes(ttype.Fields()),
+		})
+	case *Interface:
+		var interfaces []Type
+		for _, iface := range ttype.Interfaces() {
+			interfaces = append(interfaces, iface)
+		}
+		return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+			Name:        nameAsNode(ttype.Name()),
+			Description: descriptionAsNode(ttype.Description()),
+			Interfaces:  namedAsNodes(interfaces),
+			Directives:  directivesAsNode(ttype.Directives()),
+			Fields:      fieldsAsNodes(ttype.Fields()),
+		})
+	case *Union:
+		var types []Type
+		for _, object := range ttype.Types() {
+			types = append(types, object)
+		}
+		return ast.NewUnionDefinition(&ast.UnionDefinition{
+			Name:        nameAsNode(ttype.Name()),
+			Description: descriptionAsNode(ttype.Description()),
+			Directives:  directivesAsNode(ttype.Directives()),
+			Types:       namedAsNodes(types),
+		})
+	case *Enum:
+		var values []*ast.EnumValueDefinition
+		for _, value := range ttype.Values() {
+			values = append(values, ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+				Name:        nameAsNode(value.Name),
+				Description: descriptionAsNode(value.Description),
+				Directives:  deprecatedAsNode(value.DeprecationReason),
+			}))
+		}
+		return ast.NewEnumDefinition(&ast.EnumDefinition{
+			Name:        nameAsNode(ttype.Name()),
+			Description: descriptionAsNode(ttype.Description()),
+			Directives:  directivesAsNode(ttype.Directives()),
+			Values:      values,
+		})
+	case *InputObject:
+		var fields []*ast.InputValueDefinition
+		for _, field := range ttype.Fields() {
+			fields = append(fields, inputValueAsNode(field.Name(), field.Description(), field.Type, field.DefaultValue, field.DeprecationReason))
+		}
+		var directives []*ast.Directive
+		if ttype.IsOneOf() {
+			directives = directivesAsNode([]*ObjectDirective{{Directive: OneOfDirective}})
+		}
+		return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+			Name:        nameAsNode(ttype.Name()),
+			Description: descriptionAsNode(ttype.Description()),
+			Directives:  append(directives, directivesAsNode(ttype.Directives())...),
+			Fields:      fields,
+		})
+	case *Scalar:
+		definition := scalarAsNode(ttype)
+		definition.Description = descriptionAsNode(ttype.Description())
+		return definition
+	}
+	return nil
+}
+
+func fieldsAsNodes(fields FieldDefinitionMap) []*ast.FieldDefinition {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var definitions []*ast.FieldDefinition
+	for _, name := range names {
+		field := fields[name]
+		definitions = append(definitions, ast.NewFieldDefinition(&ast.FieldDefinition{
+			Name:        nameAsNode(field.Name),
+			Description: descriptionAsNode(field.Description),
+			Arguments:   argumentsAsNodes(field.Args),
+			Type:        typeAsNode(field.Type),
+			Directives:  append(directivesAsNode(field.Directives), deprecatedAsNode(field.DeprecationReason)...),
+		}))
+	}
+	return definitions
+}
+
+func argumentsAsNodes(args []*Argument) []*ast.InputValueDefinition {
+	var definitions []*ast.InputValueDefinition
+	for _, arg := range args {
+		definitions = append(definitions, inputValueAsNode(arg.Name(), arg.Description(), arg.Type, arg.DefaultValue, arg.DeprecationReason))
+	}
+	return definitions
+}
+
+func inputValueAsNode(name, description string, ttype Input, defaultValue interface{}, deprecationReason string) *ast.InputValueDefinition {
+	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
+		Name:         nameAsNode(name),
+		Description:  descriptionAsNode(description),
+		Type:         typeAsNode(ttype),
+		DefaultValue: astFromInputValue(defaultValue, ttype),
+		Directives:   deprecatedAsNode(deprecationReason),
+	})
+}
@@ -0,0 +1,90 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+// parseSDL builds a schema without resolvers from sdl.
+func parseSDL(t *testing.T, sdl string) graphql.Schema {
+	schema, err := graphql.ParseSDL(sdl, func(string, string) graphql.FieldResolveFn { return nil })
+	if err != nil {
+		t.Fatalf("unable to build the schema: %v", err)
+	}
+	return *schema
+}
+
+func TestPrintSchema_PrintsCanonicalSDL(t *testing.T) {
+	schema := parseSDL(t, `
+schema { query: Root }
+
+union Result = Root
+
+"""The root."""
+type Root implements Node {
+  list(order: Order = ASC, first: Int = 10): [String!]! @deprecated(reason: "Use items.")
+  id: ID!
+}
+
+directive @auth(role: String!) repeatable on OBJECT | FIELD_DEFINITION
+
+input Filter @oneOf { name: String, id: ID }
+
+enum Order { DESC ASC @deprecated }
+
+interface Node { id: ID! }
+
+scalar Date
+`)
+
+	expected := `schema {
+  query: Root
+}
+
+directive @auth(role: String!) repeatable on OBJECT | FIELD_DEFINITION
+
+scalar Date
+
+input Filter @oneOf {
+  id: ID
+  name: String
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Order {
+  ASC @deprecated
+  DESC
+}
+
+union Result = Root
+
+"""The root."""
+type Root implements Node {
+  id: ID!
+  list(first: Int = 10, order: Order = ASC): [String!]! @deprecated(reason: "Use items.")
+}
+`
+	if printed := graphql.PrintSchema(schema); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+
+	// The printed schema builds the same schema.
+	if printed := graphql.PrintSchema(parseSDL(t, expected)); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestPrintSchema_PrintsIntrospectedSchemas(t *testing.T) {
+	schema, err := graphql.BuildClientSchema(graphql.IntrospectionFromSchema(testutil.StarWarsSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if printed, expected := graphql.PrintSchema(schema), graphql.PrintSchema(testutil.StarWarsSchema); printed != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
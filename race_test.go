@@ -27,6 +27,12 @@ func TestRace(t *testing.T) {
 		)
 
 		func main() {
+			color := graphql.NewEnum(graphql.EnumConfig{
+				Name: "Color",
+				Values: graphql.EnumValueConfigMap{
+					"RED": &graphql.EnumValueConfig{Value: 0},
+				},
+			})
 			var wg sync.WaitGroup
 			wg.Add(2)
 			for i := 0; i < 2; i++ {
@@ -38,6 +44,9 @@ func TestRace(t *testing.T) {
 							Fields: graphql.Fields{
 								"hello": &graphql.Field{
 									Type: graphql.String,
+									Args: graphql.FieldConfigArgument{
+										"color": &graphql.ArgumentConfig{Type: color, DefaultValue: 0},
+									},
 									Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 										return "world", nil
 									},
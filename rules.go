@@ -29,11 +29,13 @@ var SpecifiedRules = []ValidationRuleFn{
 	NoUndefinedVariablesRule,
 	NoUnusedFragmentsRule,
 	NoUnusedVariablesRule,
+	OneOfInputObjectsRule,
 	OverlappingFieldsCanBeMergedRule,
 	PossibleFragmentSpreadsRule,
 	ProvidedNonNullArgumentsRule,
 	ScalarLeafsRule,
 	UniqueArgumentNamesRule,
+	UniqueDirectivesPerLocationRule,
 	UniqueFragmentNamesRule,
 	UniqueInputFieldNamesRule,
 	UniqueOperationNamesRule,
@@ -74,7 +76,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if argAST, ok := p.Node.(*ast.Argument); ok {
 						if argDef := context.Argument(); argDef != nil {
-							if isValid, messages := isValidLiteralValue(argDef.Type, argAST.Value); !isValid {
+							if isValid, messages := isValidLiteralValue(argDef.Type, argAST.Value, context.Schema().inputVisibility(context.Context())); !isValid {
 								var messagesStr, argNameValue string
 								if argAST.Name != nil {
 									argNameValue = argAST.Name.Value
@@ -132,7 +134,7 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 								[]ast.Node{defaultValue},
 							)
 						}
-						if isValid, messages := isValidLiteralValue(ttype, defaultValue); !isValid && defaultValue != nil {
+						if isValid, messages := isValidLiteralValue(ttype, defaultValue, context.Schema().inputVisibility(context.Context())); !isValid && defaultValue != nil {
 							if len(messages) > 0 {
 								messagesStr = "\n" + strings.Join(messages, "\n")
 							}
@@ -229,12 +231,12 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 								nodeName = node.Name.Value
 							}
 							// First determine if there are any suggested types to condition on.
-							suggestedTypeNames := getSuggestedTypeNames(context.Schema(), ttype, nodeName)
+							suggestedTypeNames := getSuggestedTypeNames(context, ttype, nodeName)
 
 							// If there are no suggested types, then perhaps this was a typo?
 							suggestedFieldNames := []string{}
 							if len(suggestedTypeNames) == 0 {
-								suggestedFieldNames = getSuggestedFieldNames(context.Schema(), ttype, nodeName)
+								suggestedFieldNames = getSuggestedFieldNames(context, ttype, nodeName)
 							}
 							reportError(
 								context,
@@ -256,8 +258,8 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 // getSuggestedTypeNames Go through all of the implementations of type, as well as the interfaces
 // that they implement. If any of those types include the provided field,
 // suggest them, sorted by how often the type is referenced,  starting
-// with Interfaces.
-func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []string {
+// with Interfaces. Hidden types and fields are never suggested.
+func getSuggestedTypeNames(context *ValidationContext, ttype Output, fieldName string) []string {
 	var (
 		suggestedObjectTypes = []string{}
 		suggestedInterfaces  = []*suggestedInterface{}
@@ -266,18 +268,21 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 		// stores a maps of object name => true to remove duplicates from results
 		suggestedObjectMap = map[string]bool{}
 	)
-	possibleTypes := schema.PossibleTypes(ttype)
+	schema := context.Schema()
+	possibleTypes := schema.visibleObjects(context.Context(), schema.PossibleTypes(ttype))
 
 	for _, possibleType := range possibleTypes {
-		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil {
+		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil ||
+			!schema.isFieldVisible(context.Context(), possibleType, field) {
 			continue
 		}
 		// This object type defines this field.
 		suggestedObjectTypes = append(suggestedObjectTypes, possibleType.Name())
 		suggestedObjectMap[possibleType.Name()] = true
 
-		for _, possibleInterface := range possibleType.Interfaces() {
-			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil {
+		for _, possibleInterface := range schema.visibleInterfaces(context.Context(), possibleType.Interfaces()) {
+			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil ||
+				!schema.isFieldVisible(context.Context(), possibleInterface, field) {
 				continue
 			}
 
@@ -323,14 +328,14 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 
 // getSuggestedFieldNames For the field name provided, determine if there are any similar field names
 // that may be the result of a typo.
-func getSuggestedFieldNames(schema *Schema, ttype Output, fieldName string) []string {
+func getSuggestedFieldNames(context *ValidationContext, ttype Output, fieldName string) []string {
 
 	fields := FieldDefinitionMap{}
 	switch ttype := ttype.(type) {
 	case *Object:
-		fields = ttype.Fields()
+		fields = context.Schema().visibleFields(context.Context(), ttype)
 	case *Interface:
-		fields = ttype.Fields()
+		fields = context.Schema().visibleFields(context.Context(), ttype)
 	default:
 		return []string{}
 	}
@@ -463,7 +468,8 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 							if fieldDef == nil {
 								return action, nil
 							}
-							for _, arg := range fieldDef.Args {
+							parentType, _ := context.ParentType().(Type)
+							for _, arg := range context.Schema().visibleArguments(context.Context(), parentType, fieldDef.Args) {
 								if arg.Name() == node.Name.Value {
 									fieldArgDef = arg
 									break
@@ -489,7 +495,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 							if directive = context.Directive(); directive == nil {
 								return action, nil
 							}
-							for _, arg := range directive.Args {
+							for _, arg := range context.Schema().visibleArguments(context.Context(), nil, directive.Args) {
 								if arg.Name() == node.Name.Value {
 									fieldArgDef = arg
 									break
@@ -528,6 +534,22 @@ func MisplaceDirectiveMessage(directiveName string, location string) string {
 // A GraphQL document is only valid if all `@directives` are known by the
 // schema and legally positioned.
 func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
+	locationsByDirective := map[string][]string{}
+	for _, def := range schemaOrSpecifiedDirectives(context.Schema()) {
+		locationsByDirective[def.Name] = def.Locations
+	}
+	if doc := context.Document(); doc != nil {
+		for _, def := range doc.Definitions {
+			if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+				locations := []string{}
+				for _, location := range def.Locations {
+					locations = append(locations, location.Value)
+				}
+				locationsByDirective[def.Name.Value] = locations
+			}
+		}
+	}
+
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
 			kinds.Directive: {
@@ -541,13 +563,8 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 							nodeName = node.Name.Value
 						}
 
-						var directiveDef *Directive
-						for _, def := range context.Schema().Directives() {
-							if def.Name == nodeName {
-								directiveDef = def
-							}
-						}
-						if directiveDef == nil {
+						locations, ok := locationsByDirective[nodeName]
+						if !ok {
 							return reportError(
 								context,
 								fmt.Sprintf(`Unknown directive "%v".`, nodeName),
@@ -558,7 +575,7 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 						candidateLocation := getDirectiveLocationForASTPath(p.Ancestors)
 
 						directiveHasLocation := false
-						for _, loc := range directiveDef.Locations {
+						for _, loc := range locations {
 							if loc == candidateLocation {
 								directiveHasLocation = true
 								break
@@ -590,6 +607,15 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
+// schemaOrSpecifiedDirectives returns the directives of schema, or the specified directives
+// when validating a type system document on its own.
+func schemaOrSpecifiedDirectives(schema *Schema) []*Directive {
+	if schema == nil {
+		return SpecifiedDirectives
+	}
+	return schema.Directives()
+}
+
 func getDirectiveLocationForASTPath(ancestors []ast.Node) string {
 	var appliedTo ast.Node
 	if len(ancestors) > 0 {
@@ -746,10 +772,12 @@ func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 							typeNameValue = typeName.Value
 						}
 						ttype := context.Schema().Type(typeNameValue)
-						if ttype == nil {
+						if ttype == nil || !context.Schema().IsVisible(context.Context(), ttype) {
 							suggestedTypes := []string{}
-							for key := range context.Schema().TypeMap() {
-								suggestedTypes = append(suggestedTypes, key)
+							for key, suggestedType := range context.Schema().TypeMap() {
+								if context.Schema().IsVisible(context.Context(), suggestedType) {
+									suggestedTypes = append(suggestedTypes, key)
+								}
 							}
 							reportError(
 								context,
@@ -1135,6 +1163,69 @@ func NoUnusedVariablesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
+// OneOfInputObjectsRule OneOf input objects
+//
+// A GraphQL document is only valid if every OneOf input object literal specifies
+// exactly one field, and a variable given to that field is of a non-null type.
+func OneOfInputObjectsRule(context *ValidationContext) *ValidationRuleInstance {
+	variableDefinitions := map[string]*ast.VariableDefinition{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					variableDefinitions = map[string]*ast.VariableDefinition{}
+					if node, ok := p.Node.(*ast.OperationDefinition); ok && node != nil {
+						for _, def := range node.VariableDefinitions {
+							if def.Variable != nil && def.Variable.Name != nil {
+								variableDefinitions[def.Variable.Name.Value] = def
+							}
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.ObjectValue: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.ObjectValue)
+					if !ok || node == nil {
+						return visitor.ActionNoChange, nil
+					}
+					ttype, ok := GetNamed(context.InputType()).(*InputObject)
+					if !ok || !ttype.IsOneOf() {
+						return visitor.ActionNoChange, nil
+					}
+					if len(node.Fields) != 1 {
+						return reportError(
+							context,
+							fmt.Sprintf(`OneOf Input Object "%v" must specify exactly one key.`, ttype.Name()),
+							[]ast.Node{node},
+						)
+					}
+					variable, ok := node.Fields[0].Value.(*ast.Variable)
+					if !ok || variable.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					if def, ok := variableDefinitions[variable.Name.Value]; ok {
+						if _, nonNull := def.Type.(*ast.NonNull); !nonNull {
+							return reportError(
+								context,
+								fmt.Sprintf(`Variable "%v" must be non-nullable to be used for OneOf Input Object "%v".`,
+									variable.Name.Value, ttype.Name()),
+								[]ast.Node{variable},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
 func getFragmentType(context *ValidationContext, name string) Type {
 	frag := context.Fragment(name)
 	if frag == nil {
@@ -1170,6 +1261,9 @@ func doTypesOverlap(schema *Schema, t1 Type, t2 Type) bool {
 			}
 			return false
 		}
+		if t2, ok := t2.(Abstract); ok && (schema.IsSubType(t1, t2.(Type)) || schema.IsSubType(t2, t1.(Type))) {
+			return true
+		}
 		t1TypeNames := map[string]bool{}
 		for _, ttype := range schema.PossibleTypes(t1) {
 			t1TypeNames[ttype.Name()] = true
@@ -1268,7 +1362,8 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 							}
 							argASTMap[name] = arg
 						}
-						for _, argDef := range fieldDef.Args {
+						parentType, _ := context.ParentType().(Type)
+						for _, argDef := range context.Schema().visibleArguments(context.Context(), parentType, fieldDef.Args) {
 							argAST, _ := argASTMap[argDef.Name()]
 							if argAST == nil {
 								if argDefType, ok := argDef.Type.(*NonNull); ok {
@@ -1309,7 +1404,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 							argASTMap[name] = arg
 						}
 
-						for _, argDef := range directiveDef.Args {
+						for _, argDef := range context.Schema().visibleArguments(context.Context(), nil, directiveDef.Args) {
 							argAST, _ := argASTMap[argDef.Name()]
 							if argAST == nil {
 								if argDefType, ok := argDef.Type.(*NonNull); ok {
@@ -1429,6 +1524,130 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 	}
 }
 
+// UniqueDirectivesPerLocationRule Unique directive names per location
+//
+// A GraphQL document is only valid if all non-repeatable directives at a given
+// location are uniquely named. The definition of a type or of the schema and its
+// extensions are a single location.
+func UniqueDirectivesPerLocationRule(context *ValidationContext) *ValidationRuleInstance {
+	repeatable := map[string]bool{}
+	for _, directive := range schemaOrSpecifiedDirectives(context.Schema()) {
+		repeatable[directive.Name] = directive.IsRepeatable
+	}
+	if doc := context.Document(); doc != nil {
+		for _, def := range doc.Definitions {
+			if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+				repeatable[def.Name.Value] = def.Repeatable
+			}
+		}
+	}
+	// typeDirectives maps the names of types, and the empty name the schema, to the
+	// directives applied to their definition and extensions.
+	typeDirectives := map[string]map[string]*ast.Directive{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			knownDirectives := map[string]*ast.Directive{}
+			if typeName, ok := definedTypeName(node); ok {
+				if typeDirectives[typeName] == nil {
+					typeDirectives[typeName] = knownDirectives
+				}
+				knownDirectives = typeDirectives[typeName]
+			}
+			for _, directive := range directivesOf(node) {
+				if directive == nil || directive.Name == nil {
+					continue
+				}
+				directiveName := directive.Name.Value
+				if isRepeatable, ok := repeatable[directiveName]; !ok || isRepeatable {
+					continue
+				}
+				if seen, ok := knownDirectives[directiveName]; ok {
+					reportError(
+						context,
+						fmt.Sprintf(`The directive "@%v" can only be used once at this location.`, directiveName),
+						[]ast.Node{seen, directive},
+					)
+				} else {
+					knownDirectives[directiveName] = directive
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// definedTypeName returns the name of the type defined or extended by node, or the empty
+// name for the schema, if node is such a definition. Extensions are visited through
+// the definitions which they wrap.
+func definedTypeName(node ast.Node) (string, bool) {
+	var name *ast.Name
+	switch node := node.(type) {
+	case *ast.SchemaDefinition:
+		return "", true
+	case *ast.ScalarDefinition:
+		name = node.Name
+	case *ast.ObjectDefinition:
+		name = node.Name
+	case *ast.InterfaceDefinition:
+		name = node.Name
+	case *ast.UnionDefinition:
+		name = node.Name
+	case *ast.EnumDefinition:
+		name = node.Name
+	case *ast.InputObjectDefinition:
+		name = node.Name
+	}
+	if name == nil {
+		return "", false
+	}
+	return name.Value, true
+}
+
+// directivesOf returns the directives applied to node, if it accepts any.
+func directivesOf(node ast.Node) []*ast.Directive {
+	switch node := node.(type) {
+	case *ast.OperationDefinition:
+		return node.Directives
+	case *ast.FragmentDefinition:
+		return node.Directives
+	case *ast.Field:
+		return node.Directives
+	case *ast.FragmentSpread:
+		return node.Directives
+	case *ast.InlineFragment:
+		return node.Directives
+	case *ast.SchemaDefinition:
+		return node.Directives
+	case *ast.ScalarDefinition:
+		return node.Directives
+	case *ast.ObjectDefinition:
+		return node.Directives
+	case *ast.FieldDefinition:
+		return node.Directives
+	case *ast.InputValueDefinition:
+		return node.Directives
+	case *ast.InterfaceDefinition:
+		return node.Directives
+	case *ast.UnionDefinition:
+		return node.Directives
+	case *ast.EnumDefinition:
+		return node.Directives
+	case *ast.EnumValueDefinition:
+		return node.Directives
+	case *ast.InputObjectDefinition:
+		return node.Directives
+	}
+	return nil
+}
+
 // UniqueFragmentNamesRule Unique fragment names
 //
 // A GraphQL document is only valid if all defined fragments have unique names.
@@ -1683,6 +1902,9 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 								if err != nil {
 									varType = nil
 								}
+								if varType != nil && !context.Schema().IsVisible(context.Context(), varType) {
+									varType = nil
+								}
 								if varType != nil && !isTypeSubTypeOf(context.Schema(), effectiveType(varType, varDef), usage.Type) {
 									reportError(
 										context,
@@ -1723,8 +1945,9 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 // an input type.
 //
 // Note that this only validates literal values, variables are assumed to
-// provide values of the correct type.
-func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+// provide values of the correct type. Enum values and input fields for which
+// isVisible does not hold are unknown.
+func isValidLiteralValue(ttype Input, valueAST ast.Value, isVisible isVisibleFn) (bool, []string) {
 	if _, ok := ttype.(*NonNull); !ok {
 		if valueAST == nil {
 			return true, nil
@@ -1749,28 +1972,28 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			return false, []string{"Expected non-null value, found null."}
 		}
 		ofType, _ := ttype.OfType.(Input)
-		return isValidLiteralValue(ofType, valueAST)
+		return isValidLiteralValue(ofType, valueAST, isVisible)
 	case *List:
 		// Lists accept a non-list value as a list of one.
 		itemType, _ := ttype.OfType.(Input)
 		if valueAST, ok := valueAST.(*ast.ListValue); ok {
 			messagesReduce := []string{}
 			for _, value := range valueAST.Values {
-				_, messages := isValidLiteralValue(itemType, value)
+				_, messages := isValidLiteralValue(itemType, value, isVisible)
 				for idx, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
 				}
 			}
 			return (len(messagesReduce) == 0), messagesReduce
 		}
-		return isValidLiteralValue(itemType, valueAST)
+		return isValidLiteralValue(itemType, valueAST, isVisible)
 	case *InputObject:
 		// Input objects check each defined field and look for undefined fields.
 		valueAST, ok := valueAST.(*ast.ObjectValue)
 		if !ok {
 			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
 		}
-		fields := ttype.Fields()
+		fields := visibleInputFields(ttype, isVisible)
 		messagesReduce := []string{}
 
 		// Ensure every provided field is defined.
@@ -1789,7 +2012,7 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			if fieldAST := fieldASTMap[fieldName]; fieldAST != nil {
 				fieldASTValue = fieldAST.Value
 			}
-			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue); !isValid {
+			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue, isVisible); !isValid {
 				for _, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf("In field \"%v\": %v", fieldName, message))
 				}
@@ -1801,7 +2024,11 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	case *Enum:
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		var enumValue *EnumValueDefinition
+		if valueAST, ok := valueAST.(*ast.EnumValue); ok {
+			enumValue = enumValueNamed(ttype, valueAST.Value)
+		}
+		if isNullish(ttype.ParseLiteral(valueAST)) || !isVisible(enumValue) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	}
@@ -163,3 +163,27 @@ func TestValidate_KnownDirectives_WithinSchemaLanguage_WithMisplacedDirectives(t
 		testutil.RuleError(`Directive "onObject" may not be used on SCHEMA.`, 22, 16),
 	})
 }
+func TestValidate_KnownDirectives_SDL_WithDirectivesDefinedInDocument(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.KnownDirectivesRule, `
+      type Query {
+        foo: String @test @deprecated
+      }
+
+      directive @test on FIELD_DEFINITION
+    `)
+}
+func TestValidate_KnownDirectives_SDL_WithUnknownAndMisplacedDirectives(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.KnownDirectivesRule, `
+      type Query @test {
+        foo: String @unknown
+      }
+
+      extend type Query @skip(if: true)
+
+      directive @test on FIELD_DEFINITION
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "test" may not be used on OBJECT.`, 2, 18),
+		testutil.RuleError(`Unknown directive "unknown".`, 3, 21),
+		testutil.RuleError(`Directive "skip" may not be used on OBJECT.`, 6, 25),
+	})
+}
@@ -0,0 +1,62 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_KnownTypeNamesInSDL_KnownTypeNames(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.KnownTypeNamesInSDLRule, `
+      schema {
+        query: Query
+      }
+
+      type Query implements Node {
+        id: ID!
+        pets(first: Int, after: String): [Pet]
+        ratio: Float
+        ok: Boolean
+      }
+
+      interface Node {
+        id: ID!
+      }
+
+      union Pet = Dog | Cat
+
+      type Dog { name: String }
+
+      type Cat { name: String }
+
+      directive @limit(max: Int) on FIELD_DEFINITION
+    `)
+}
+func TestValidate_KnownTypeNamesInSDL_UnknownTypeNames(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.KnownTypeNamesInSDLRule, `
+      type Query implements Nod {
+        pet(filter: PetFilter): Pett
+      }
+
+      type Pet {
+        name: String
+      }
+
+      union Animal = Pet | Human
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown type "Nod".`, 2, 29),
+		testutil.RuleError(`Unknown type "PetFilter".`, 3, 21),
+		testutil.RuleError(`Unknown type "Pett". Did you mean "Pet"?`, 3, 33),
+		testutil.RuleError(`Unknown type "Human".`, 10, 28),
+	})
+}
+func TestValidate_KnownTypeNamesInSDL_TypesOfExtendedSchema(t *testing.T) {
+	testutil.ExpectPassesSDLRuleWithSchema(t, testutil.TestSchema, graphql.KnownTypeNamesInSDLRule, `
+      extend type Dog {
+        friend: Cat
+        owner: Human
+      }
+    `)
+}
@@ -0,0 +1,70 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_OneOfInputObjects_ExactlyOneField(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.OneOfInputObjectsRule, `
+      {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { stringField: "abc" })
+        }
+      }
+    `)
+}
+func TestValidate_OneOfInputObjects_ExactlyOneNonNullableVariable(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.OneOfInputObjectsRule, `
+      query ($string: String!) {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { stringField: $string })
+        }
+      }
+    `)
+}
+func TestValidate_OneOfInputObjects_WholeObjectAsVariable(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.OneOfInputObjectsRule, `
+      query ($input: OneOfInput) {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: $input)
+        }
+      }
+    `)
+}
+func TestValidate_OneOfInputObjects_MoreThanOneField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.OneOfInputObjectsRule, `
+      {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { stringField: "abc", intField: 123 })
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`OneOf Input Object "OneOfInput" must specify exactly one key.`, 4, 35),
+	})
+}
+func TestValidate_OneOfInputObjects_NoField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.OneOfInputObjectsRule, `
+      {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: {})
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`OneOf Input Object "OneOfInput" must specify exactly one key.`, 4, 35),
+	})
+}
+func TestValidate_OneOfInputObjects_NullableVariable(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.OneOfInputObjectsRule, `
+      query ($string: String) {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { stringField: $string })
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "string" must be non-nullable to be used for OneOf Input Object "OneOfInput".`, 4, 50),
+	})
+}
@@ -180,3 +180,55 @@ func TestValidate_PossibleFragmentSpreads_InterfaceIntoNonOverlappingUnion(t *te
 			`type "HumanOrAlien" can never be of type "Pet".`, 2, 62),
 	})
 }
+
+func interfaceHierarchySchema(t *testing.T) *graphql.Schema {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	resourceInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Resource",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id":  &graphql.Field{Type: graphql.ID},
+			"url": &graphql.Field{Type: graphql.String},
+		},
+	})
+	namedInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Named",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node":  &graphql.Field{Type: nodeInterface},
+				"named": &graphql.Field{Type: namedInterface},
+			},
+		}),
+		Types: []graphql.Type{resourceInterface},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+func TestValidate_PossibleFragmentSpreads_InterfaceIntoImplementedInterface(t *testing.T) {
+	testutil.ExpectPassesRuleWithSchema(t, interfaceHierarchySchema(t), graphql.PossibleFragmentSpreadsRule, `
+      fragment interfaceWithinParent on Node { ...resourceFragment }
+      fragment interfaceWithinChild on Resource { ... on Node { id } }
+      fragment resourceFragment on Resource { url }
+    `)
+}
+func TestValidate_PossibleFragmentSpreads_InterfaceIntoNonImplementedInterface(t *testing.T) {
+	testutil.ExpectFailsRuleWithSchema(t, interfaceHierarchySchema(t), graphql.PossibleFragmentSpreadsRule, `
+      fragment interfaceWithinUnrelated on Named { ... on Resource { url } }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Fragment cannot be spread here as objects of `+
+			`type "Named" can never be of type "Resource".`, 2, 52),
+	})
+}
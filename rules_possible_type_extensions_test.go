@@ -0,0 +1,64 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_PossibleTypeExtensions_ExtensionsOfDefinedTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      scalar FooScalar
+      type FooObject { foo: String }
+      interface FooInterface { foo: String }
+      union FooUnion = FooObject
+      enum FooEnum { FOO }
+      input FooInputObject { foo: String }
+
+      extend scalar FooScalar @dummy
+      extend type FooObject @dummy
+      extend interface FooInterface @dummy
+      extend union FooUnion @dummy
+      extend enum FooEnum @dummy
+      extend input FooInputObject @dummy
+    `)
+}
+func TestValidate_PossibleTypeExtensions_ExtensionBeforeDefinition(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      extend type Foo @dummy
+      type Foo { foo: String }
+    `)
+}
+func TestValidate_PossibleTypeExtensions_ExtensionsOfUndefinedTypes(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      type Known { foo: String }
+
+      extend scalar Missing @dummy
+      extend type Knwn @dummy
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend type "Missing" because it is not defined.`, 4, 21),
+		testutil.RuleError(`Cannot extend type "Knwn" because it is not defined. Did you mean "Known"?`, 5, 19),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtensionsOfIncorrectKind(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      type FooObject { foo: String }
+      scalar FooScalar
+
+      extend scalar FooObject @dummy
+      extend input FooScalar @dummy
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-scalar type "FooObject".`, 5, 21),
+		testutil.RuleError(`Cannot extend non-input object type "FooScalar".`, 6, 20),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtensionsOfSchemaTypes(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.PossibleTypeExtensionsRule, `
+      extend type Dog @dummy
+      extend enum Cat @dummy
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-enum type "Cat".`, 3, 19),
+	})
+}
@@ -0,0 +1,452 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/kinds"
+	"github.com/tailor-inc/graphql/language/visitor"
+)
+
+// SpecifiedSDLRules set includes all validation rules defined by the GraphQL spec
+// for type system documents, see ValidateSDL.
+var SpecifiedSDLRules = []ValidationRuleFn{
+	KnownDirectivesRule,
+	KnownTypeNamesInSDLRule,
+	LoneSchemaDefinitionRule,
+	PossibleTypeExtensionsRule,
+	UniqueArgumentDefinitionNamesRule,
+	UniqueDirectiveNamesRule,
+	UniqueDirectivesPerLocationRule,
+	UniqueEnumValueNamesRule,
+	UniqueFieldDefinitionNamesRule,
+	UniqueTypeNamesRule,
+}
+
+// typeSystemDefinition returns the type definition carried by node, which is either a
+// type definition or a type extension, along with its name.
+func typeSystemDefinition(node ast.Node) (definition ast.Node, name *ast.Name, isExtension bool) {
+	switch node := node.(type) {
+	case *ast.ScalarDefinition:
+		return node, node.Name, false
+	case *ast.ObjectDefinition:
+		return node, node.Name, false
+	case *ast.InterfaceDefinition:
+		return node, node.Name, false
+	case *ast.UnionDefinition:
+		return node, node.Name, false
+	case *ast.EnumDefinition:
+		return node, node.Name, false
+	case *ast.InputObjectDefinition:
+		return node, node.Name, false
+	case *ast.ScalarExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	case *ast.TypeExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	case *ast.InterfaceExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	case *ast.UnionExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	case *ast.EnumExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	case *ast.InputObjectExtensionDefinition:
+		return node.Definition, node.Definition.Name, true
+	}
+	return nil, nil, false
+}
+
+// typeSystemDocumentRule builds a rule instance checking the definitions of the whole
+// document at once.
+func typeSystemDocumentRule(check func(definitions []ast.Node)) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Document: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Document); ok {
+						check(node.Definitions)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// KnownTypeNamesInSDLRule Known type names in type system documents
+//
+// A type system document is only valid if referenced types are specified scalars,
+// types defined by the document or types of the extended schema.
+func KnownTypeNamesInSDLRule(context *ValidationContext) *ValidationRuleInstance {
+	knownTypeNames := map[string]bool{}
+	for _, ttype := range []Type{String, Int, Float, Boolean, ID} {
+		knownTypeNames[ttype.Name()] = true
+	}
+	if context.Schema() != nil {
+		for name := range context.Schema().TypeMap() {
+			knownTypeNames[name] = true
+		}
+	}
+	if doc := context.Document(); doc != nil {
+		for _, def := range doc.Definitions {
+			if _, name, isExtension := typeSystemDefinition(def); name != nil && !isExtension {
+				knownTypeNames[name.Value] = true
+			}
+		}
+	}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Named: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Named); ok && node.Name != nil {
+						typeName := node.Name.Value
+						if !knownTypeNames[typeName] {
+							suggestedTypes := []string{}
+							for name := range knownTypeNames {
+								suggestedTypes = append(suggestedTypes, name)
+							}
+							reportError(
+								context,
+								unknownTypeMessage(typeName, suggestionList(typeName, suggestedTypes)),
+								[]ast.Node{node},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// LoneSchemaDefinitionRule Lone schema definition
+//
+// A type system document is only valid if it contains at most one schema definition,
+// and none when extending a schema.
+func LoneSchemaDefinitionRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		schemaDefinitionsCount := 0
+		for _, def := range definitions {
+			def, ok := def.(*ast.SchemaDefinition)
+			if !ok {
+				continue
+			}
+			if context.Schema() != nil {
+				reportError(context, "Cannot define a new schema within a schema extension.", []ast.Node{def})
+			} else if schemaDefinitionsCount > 0 {
+				reportError(context, "Must provide only one schema definition.", []ast.Node{def})
+			}
+			schemaDefinitionsCount++
+		}
+	})
+}
+
+// PossibleTypeExtensionsRule Possible type extensions
+//
+// A type extension is only valid if the type is defined and has the same kind.
+func PossibleTypeExtensionsRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		definedKinds := map[string]string{}
+		for _, def := range definitions {
+			if def, name, isExtension := typeSystemDefinition(def); name != nil && !isExtension {
+				definedKinds[name.Value] = def.GetKind()
+			}
+		}
+		for _, def := range definitions {
+			extension, name, isExtension := typeSystemDefinition(def)
+			if name == nil || !isExtension {
+				continue
+			}
+			definedKind, ok := definedKinds[name.Value]
+			if !ok && context.Schema() != nil {
+				if ttype := context.Schema().Type(name.Value); ttype != nil {
+					definedKind, ok = typeDefinitionKind(ttype), true
+				}
+			}
+			if !ok {
+				suggestedTypes := []string{}
+				for typeName := range definedKinds {
+					suggestedTypes = append(suggestedTypes, typeName)
+				}
+				message := fmt.Sprintf(`Cannot extend type "%v" because it is not defined.`, name.Value)
+				if suggestions := suggestionList(name.Value, suggestedTypes); len(suggestions) > 0 {
+					message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestions))
+				}
+				reportError(context, message, []ast.Node{name})
+			} else if definedKind != extension.GetKind() {
+				reportError(
+					context,
+					fmt.Sprintf(`Cannot extend non-%v type "%v".`, typeKindDescription(extension.GetKind()), name.Value),
+					[]ast.Node{name},
+				)
+			}
+		}
+	})
+}
+
+func typeDefinitionKind(ttype Type) string {
+	switch ttype.(type) {
+	case *Scalar:
+		return kinds.ScalarDefinition
+	case *Object:
+		return kinds.ObjectDefinition
+	case *Interface:
+		return kinds.InterfaceDefinition
+	case *Union:
+		return kinds.UnionDefinition
+	case *Enum:
+		return kinds.EnumDefinition
+	case *InputObject:
+		return kinds.InputObjectDefinition
+	}
+	return ""
+}
+
+func typeKindDescription(kind string) string {
+	switch kind {
+	case kinds.ScalarDefinition:
+		return "scalar"
+	case kinds.ObjectDefinition:
+		return "object"
+	case kinds.InterfaceDefinition:
+		return "interface"
+	case kinds.UnionDefinition:
+		return "union"
+	case kinds.EnumDefinition:
+		return "enum"
+	case kinds.InputObjectDefinition:
+		return "input object"
+	}
+	return kind
+}
+
+// UniqueArgumentDefinitionNamesRule Unique argument definition names
+//
+// A GraphQL object, interface field or directive definition is only valid if all its
+// arguments are uniquely named.
+func UniqueArgumentDefinitionNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	checkArguments := func(parentName string, arguments []*ast.InputValueDefinition) {
+		knownArgNames := map[string]*ast.Name{}
+		for _, arg := range arguments {
+			if arg.Name == nil {
+				continue
+			}
+			if seen, ok := knownArgNames[arg.Name.Value]; ok {
+				reportError(
+					context,
+					fmt.Sprintf(`Argument "%v(%v:)" can only be defined once.`, parentName, arg.Name.Value),
+					[]ast.Node{seen, arg.Name},
+				)
+			} else {
+				knownArgNames[arg.Name.Value] = arg.Name
+			}
+		}
+	}
+	checkFields := func(typeName string, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			if field.Name != nil {
+				checkArguments(typeName+"."+field.Name.Value, field.Arguments)
+			}
+		}
+	}
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		for _, def := range definitions {
+			if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+				checkArguments("@"+def.Name.Value, def.Arguments)
+				continue
+			}
+			switch def, name, _ := typeSystemDefinition(def); def := def.(type) {
+			case *ast.ObjectDefinition:
+				checkFields(name.Value, def.Fields)
+			case *ast.InterfaceDefinition:
+				checkFields(name.Value, def.Fields)
+			}
+		}
+	})
+}
+
+// UniqueDirectiveNamesRule Unique directive names
+//
+// A GraphQL document is only valid if all defined directives have unique names.
+func UniqueDirectiveNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		knownDirectiveNames := map[string]*ast.Name{}
+		for _, def := range definitions {
+			def, ok := def.(*ast.DirectiveDefinition)
+			if !ok || def.Name == nil {
+				continue
+			}
+			directiveName := def.Name.Value
+			if context.Schema() != nil && context.Schema().Directive(directiveName) != nil {
+				reportError(
+					context,
+					fmt.Sprintf(`Directive "@%v" already exists in the schema. It cannot be redefined.`, directiveName),
+					[]ast.Node{def.Name},
+				)
+			} else if seen, ok := knownDirectiveNames[directiveName]; ok {
+				reportError(
+					context,
+					fmt.Sprintf(`There can be only one directive named "@%v".`, directiveName),
+					[]ast.Node{seen, def.Name},
+				)
+			} else {
+				knownDirectiveNames[directiveName] = def.Name
+			}
+		}
+	})
+}
+
+// UniqueEnumValueNamesRule Unique enum value names
+//
+// A GraphQL enum type is only valid if all its values are uniquely named, including
+// the values added by its extensions.
+func UniqueEnumValueNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		knownValueNames := map[string]map[string]*ast.Name{}
+		for _, def := range definitions {
+			def, name, _ := typeSystemDefinition(def)
+			enumDef, ok := def.(*ast.EnumDefinition)
+			if !ok {
+				continue
+			}
+			typeName := name.Value
+			if knownValueNames[typeName] == nil {
+				knownValueNames[typeName] = map[string]*ast.Name{}
+			}
+			var existingType *Enum
+			if context.Schema() != nil {
+				existingType, _ = context.Schema().Type(typeName).(*Enum)
+			}
+			for _, value := range enumDef.Values {
+				if value.Name == nil {
+					continue
+				}
+				valueName := value.Name.Value
+				if existingType != nil && existingType.getNameLookup()[valueName] != nil {
+					reportError(
+						context,
+						fmt.Sprintf(`Enum value "%v.%v" already exists in the schema. It cannot also be defined in this type extension.`, typeName, valueName),
+						[]ast.Node{value.Name},
+					)
+				} else if seen, ok := knownValueNames[typeName][valueName]; ok {
+					reportError(
+						context,
+						fmt.Sprintf(`Enum value "%v.%v" can only be defined once.`, typeName, valueName),
+						[]ast.Node{seen, value.Name},
+					)
+				} else {
+					knownValueNames[typeName][valueName] = value.Name
+				}
+			}
+		}
+	})
+}
+
+// UniqueFieldDefinitionNamesRule Unique field definition names
+//
+// A GraphQL complex type is only valid if all its fields are uniquely named, including
+// the fields added by its extensions.
+func UniqueFieldDefinitionNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		knownFieldNames := map[string]map[string]*ast.Name{}
+		for _, def := range definitions {
+			def, name, _ := typeSystemDefinition(def)
+			var fieldNames []*ast.Name
+			switch def := def.(type) {
+			case *ast.ObjectDefinition:
+				for _, field := range def.Fields {
+					fieldNames = append(fieldNames, field.Name)
+				}
+			case *ast.InterfaceDefinition:
+				for _, field := range def.Fields {
+					fieldNames = append(fieldNames, field.Name)
+				}
+			case *ast.InputObjectDefinition:
+				for _, field := range def.Fields {
+					fieldNames = append(fieldNames, field.Name)
+				}
+			default:
+				continue
+			}
+			typeName := name.Value
+			if knownFieldNames[typeName] == nil {
+				knownFieldNames[typeName] = map[string]*ast.Name{}
+			}
+			for _, fieldName := range fieldNames {
+				if fieldName == nil {
+					continue
+				}
+				if hasSchemaField(context.Schema(), typeName, fieldName.Value) {
+					reportError(
+						context,
+						fmt.Sprintf(`Field "%v.%v" already exists in the schema. It cannot also be defined in this type extension.`, typeName, fieldName.Value),
+						[]ast.Node{fieldName},
+					)
+				} else if seen, ok := knownFieldNames[typeName][fieldName.Value]; ok {
+					reportError(
+						context,
+						fmt.Sprintf(`Field "%v.%v" can only be defined once.`, typeName, fieldName.Value),
+						[]ast.Node{seen, fieldName},
+					)
+				} else {
+					knownFieldNames[typeName][fieldName.Value] = fieldName
+				}
+			}
+		}
+	})
+}
+
+func hasSchemaField(schema *Schema, typeName string, fieldName string) bool {
+	if schema == nil {
+		return false
+	}
+	switch ttype := schema.Type(typeName).(type) {
+	case *Object:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	case *Interface:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	case *InputObject:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	}
+	return false
+}
+
+// UniqueTypeNamesRule Unique type names
+//
+// A GraphQL document is only valid if all defined types have unique names.
+func UniqueTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	return typeSystemDocumentRule(func(definitions []ast.Node) {
+		knownTypeNames := map[string]*ast.Name{}
+		for _, def := range definitions {
+			_, name, isExtension := typeSystemDefinition(def)
+			if name == nil || isExtension {
+				continue
+			}
+			typeName := name.Value
+			if context.Schema() != nil && context.Schema().Type(typeName) != nil {
+				reportError(
+					context,
+					fmt.Sprintf(`Type "%v" already exists in the schema. It cannot also be defined in this type definition.`, typeName),
+					[]ast.Node{name},
+				)
+			} else if seen, ok := knownTypeNames[typeName]; ok {
+				reportError(
+					context,
+					fmt.Sprintf(`There can be only one type named "%v".`, typeName),
+					[]ast.Node{seen, name},
+				)
+			} else {
+				knownTypeNames[typeName] = name
+			}
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueArgumentDefinitionNames_UniqueArguments(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      type SomeObject {
+        someField(foo: String, bar: String): String
+        otherField(foo: String): String
+      }
+
+      directive @someDirective(foo: String, bar: String) on QUERY
+    `)
+}
+func TestValidate_UniqueArgumentDefinitionNames_DuplicateFieldArguments(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      type SomeObject {
+        someField(foo: String, bar: String, foo: String): String
+      }
+
+      extend interface SomeInterface {
+        someField(foo: String, foo: String): String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Argument "SomeObject.someField(foo:)" can only be defined once.`, 3, 19, 3, 45),
+		testutil.RuleError(`Argument "SomeInterface.someField(foo:)" can only be defined once.`, 7, 19, 7, 32),
+	})
+}
+func TestValidate_UniqueArgumentDefinitionNames_DuplicateDirectiveArguments(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      directive @someDirective(foo: String, foo: String) on QUERY
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Argument "@someDirective(foo:)" can only be defined once.`, 2, 32, 2, 45),
+	})
+}
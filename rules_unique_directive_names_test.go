@@ -0,0 +1,32 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueDirectiveNames_UniqueDirectives(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      directive @foo on SCHEMA
+      directive @bar on SCHEMA
+    `)
+}
+func TestValidate_UniqueDirectiveNames_DuplicateDirectives(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      directive @foo on SCHEMA
+      directive @foo on SCHEMA
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one directive named "@foo".`, 2, 18, 3, 18),
+	})
+}
+func TestValidate_UniqueDirectiveNames_DirectiveAlreadyInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueDirectiveNamesRule, `
+      directive @onQuery on QUERY
+      directive @newDirective on QUERY
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "@onQuery" already exists in the schema. It cannot be redefined.`, 2, 18),
+	})
+}
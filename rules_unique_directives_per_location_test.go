@@ -0,0 +1,153 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueDirectivesPerLocation_NoDirectives(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @include(if: true) {
+        field @skip(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInSameLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @include(if: true) @skip(if: true) {
+        field @skip(if: true) @include(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) {
+        field @skip(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInSimilarLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true)
+        field @skip(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_RepeatableDirectivesInSameLocation(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @repeatable @repeatable {
+        field @repeatable @repeatable
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UnknownDirectivesMustBeIgnored(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      type Test @unknown @unknown {
+        field: String! @unknown @unknown
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true) @skip(if: false)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 3, 15, 3, 31),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_ManyDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true) @skip(if: true) @skip(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 3, 15, 3, 31),
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 3, 15, 3, 47),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DifferentDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @include(if: true) @skip(if: true) @include(if: true) @skip(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@include" can only be used once at this location.`, 3, 15, 3, 50),
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 3, 34, 3, 69),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInManyLocations(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) @skip(if: true) {
+        field @skip(if: true) @skip(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 2, 29, 2, 45),
+		testutil.RuleError(`The directive "@skip" can only be used once at this location.`, 3, 15, 3, 31),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_RepeatableDefinedInDocument(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      directive @tag(name: String!) repeatable on OBJECT
+
+      type Test @tag(name: "a") @tag(name: "b") {
+        field: String
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_NonRepeatableDefinedInDocument(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      directive @key(fields: String!) on OBJECT
+
+      type Test @key(fields: "a") @key(fields: "b") {
+        field: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@key" can only be used once at this location.`, 4, 17, 4, 35),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_SDL_DuplicateDirectives(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      directive @key(fields: String!) repeatable on OBJECT
+
+      type Query @key(fields: "a") @key(fields: "b") {
+        foo: String @deprecated @deprecated
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@deprecated" can only be used once at this location.`, 5, 21, 5, 33),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_SDL_DuplicateDirectivesInExtensions(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      directive @d on OBJECT | SCHEMA
+
+      schema @d { query: Query }
+      extend schema @d
+
+      type Query @d { a: Int }
+      extend type Query @d
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@d" can only be used once at this location.`, 4, 14, 5, 21),
+		testutil.RuleError(`The directive "@d" can only be used once at this location.`, 7, 18, 8, 25),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_SDL_DirectivesOfDifferentTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      directive @d on OBJECT
+
+      type Query @d { a: Int }
+      type Other @d { a: Int }
+      extend type Other { b: Int }
+    `)
+}
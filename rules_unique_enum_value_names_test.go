@@ -0,0 +1,48 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueEnumValueNames_UniqueValues(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueEnumValueNamesRule, `
+      enum SomeEnum {
+        FOO
+        BAR
+      }
+
+      extend enum SomeEnum {
+        BAZ
+      }
+    `)
+}
+func TestValidate_UniqueEnumValueNames_DuplicateValues(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueEnumValueNamesRule, `
+      enum SomeEnum {
+        FOO
+        BAR
+        FOO
+      }
+
+      extend enum SomeEnum {
+        BAR
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Enum value "SomeEnum.FOO" can only be defined once.`, 3, 9, 5, 9),
+		testutil.RuleError(`Enum value "SomeEnum.BAR" can only be defined once.`, 4, 9, 9, 9),
+	})
+}
+func TestValidate_UniqueEnumValueNames_ValueAlreadyInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueEnumValueNamesRule, `
+      extend enum FurColor {
+        BROWN
+        PURPLE
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Enum value "FurColor.BROWN" already exists in the schema. It cannot also be defined in this type extension.`, 3, 9),
+	})
+}
@@ -0,0 +1,73 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueFieldDefinitionNames_UniqueFields(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+        bar: String
+      }
+
+      extend type SomeObject {
+        baz: String
+      }
+
+      input SomeInputObject {
+        foo: String
+      }
+    `)
+}
+func TestValidate_UniqueFieldDefinitionNames_DuplicateFields(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+        foo: String
+      }
+
+      interface SomeInterface {
+        foo: String
+        foo: String
+      }
+
+      input SomeInputObject {
+        foo: String
+        foo: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "SomeObject.foo" can only be defined once.`, 3, 9, 4, 9),
+		testutil.RuleError(`Field "SomeInterface.foo" can only be defined once.`, 8, 9, 9, 9),
+		testutil.RuleError(`Field "SomeInputObject.foo" can only be defined once.`, 13, 9, 14, 9),
+	})
+}
+func TestValidate_UniqueFieldDefinitionNames_DuplicateFieldsInExtension(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      extend type SomeObject {
+        foo: String
+      }
+
+      type SomeObject {
+        foo: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "SomeObject.foo" can only be defined once.`, 3, 9, 7, 9),
+	})
+}
+func TestValidate_UniqueFieldDefinitionNames_FieldAlreadyInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueFieldDefinitionNamesRule, `
+      extend type Dog {
+        name: String
+        nickname: String
+        owner: Human
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "Dog.name" already exists in the schema. It cannot also be defined in this type extension.`, 3, 9),
+		testutil.RuleError(`Field "Dog.nickname" already exists in the schema. It cannot also be defined in this type extension.`, 4, 9),
+	})
+}
@@ -0,0 +1,45 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func TestValidate_UniqueTypeNames_NoTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      directive @test on SCHEMA
+    `)
+}
+func TestValidate_UniqueTypeNames_OneOfEachKind(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo { foo: String }
+      scalar Bar
+      interface Baz { foo: String }
+      union Qux = Foo
+      enum Quux { FOO }
+      input Corge { foo: String }
+
+      extend type Foo @test
+    `)
+}
+func TestValidate_UniqueTypeNames_DuplicateTypeNames(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo { foo: String }
+      scalar Foo
+      interface Foo { foo: String }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 3, 14),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 4, 17),
+	})
+}
+func TestValidate_UniqueTypeNames_TypeAlreadyInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueTypeNamesRule, `
+      type Dog { foo: String }
+      type NewType { foo: String }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Type "Dog" already exists in the schema. It cannot also be defined in this type definition.`, 2, 12),
+	})
+}
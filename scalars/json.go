@@ -0,0 +1,90 @@
+package scalars
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+func serializeJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.RawMessage:
+		var v interface{}
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil
+		}
+		return v
+	case *json.RawMessage:
+		if value == nil {
+			return nil
+		}
+		return serializeJSON(*value)
+	default:
+		return value
+	}
+}
+
+func unserializeJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.RawMessage:
+		return serializeJSON(value)
+	default:
+		return value
+	}
+}
+
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+			return int(i)
+		}
+		if f, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return f
+		}
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return f
+		}
+	case *ast.ListValue:
+		values := make([]interface{}, 0, len(valueAST.Values))
+		for _, v := range valueAST.Values {
+			parsed := parseJSONLiteral(v)
+			if parsed == nil {
+				return nil
+			}
+			values = append(values, parsed)
+		}
+		return values
+	case *ast.ObjectValue:
+		obj := make(map[string]interface{}, len(valueAST.Fields))
+		for _, field := range valueAST.Fields {
+			parsed := parseJSONLiteral(field.Value)
+			if parsed == nil {
+				return nil
+			}
+			obj[field.Name.Value] = parsed
+		}
+		return obj
+	}
+	return nil
+}
+
+// JSON is an arbitrary JSON value. Object and list literals in documents are parsed into
+// map[string]interface{} and []interface{}, and json.RawMessage values are decoded.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "JSON",
+	Description:    "The `JSON` scalar type represents an arbitrary JSON value as specified by ECMA-404.",
+	SpecifiedByURL: "https://www.ecma-international.org/publications-and-standards/standards/ecma-404/",
+	Serialize:      serializeJSON,
+	ParseValue:     unserializeJSON,
+	ParseLiteral:   parseJSONLiteral,
+})
@@ -0,0 +1,236 @@
+package scalars
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+var integerRegExp = regexp.MustCompile(`^[+-]?\d+$`)
+
+func toBigInt(value interface{}) *big.Int {
+	switch value := value.(type) {
+	case *big.Int:
+		return value
+	case big.Int:
+		return &value
+	case int:
+		return big.NewInt(int64(value))
+	case int8:
+		return big.NewInt(int64(value))
+	case int16:
+		return big.NewInt(int64(value))
+	case int32:
+		return big.NewInt(int64(value))
+	case int64:
+		return big.NewInt(value)
+	case uint:
+		return new(big.Int).SetUint64(uint64(value))
+	case uint8:
+		return new(big.Int).SetUint64(uint64(value))
+	case uint16:
+		return new(big.Int).SetUint64(uint64(value))
+	case uint32:
+		return new(big.Int).SetUint64(uint64(value))
+	case uint64:
+		return new(big.Int).SetUint64(value)
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) || value != math.Trunc(value) {
+			return nil
+		}
+		i, _ := big.NewFloat(value).Int(nil)
+		return i
+	case json.Number:
+		return toBigInt(string(value))
+	case string:
+		if !integerRegExp.MatchString(value) {
+			return nil
+		}
+		i, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil
+		}
+		return i
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return toBigInt(*value)
+	}
+	return nil
+}
+
+func serializeBigInt(value interface{}) interface{} {
+	if i := toBigInt(value); i != nil {
+		return i.String()
+	}
+	return nil
+}
+
+func unserializeBigInt(value interface{}) interface{} {
+	if i := toBigInt(value); i != nil {
+		return i
+	}
+	return nil
+}
+
+func parseIntegerLiteral(parse graphql.ParseValueFn) graphql.ParseLiteralFn {
+	return func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return parse(valueAST.Value)
+		case *ast.StringValue:
+			return parse(valueAST.Value)
+		}
+		return nil
+	}
+}
+
+// BigInt is an arbitrary precision integer parsed into *big.Int. It is serialized as a
+// string since JSON numbers cannot carry the full precision; integer literals and
+// numeric strings are both accepted as input.
+var BigInt = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "BigInt",
+	Description: "The `BigInt` scalar type represents an arbitrary precision signed integer." +
+		" The BigInt is serialized as a quoted string of decimal digits",
+	SpecifiedByURL: "https://pkg.go.dev/math/big#Int.SetString",
+	Serialize:      serializeBigInt,
+	ParseValue:     unserializeBigInt,
+	ParseLiteral:   parseIntegerLiteral(unserializeBigInt),
+})
+
+// decimalPrecision is the mantissa precision in bits used for Decimal values.
+const decimalPrecision = 256
+
+// decimalMaxLength and decimalMaxExponent bound the size of Decimal values, whose
+// positional notation grows with their exponent. Larger values are rejected.
+const (
+	decimalMaxLength   = 1024
+	decimalMaxExponent = 1024
+)
+
+// decimalMaxBinaryExponent is the binary exponent of 10^decimalMaxExponent, rounded up.
+const decimalMaxBinaryExponent = decimalMaxExponent*10/3 + 1
+
+var decimalRegExp = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)(?:[eE]([+-]?\d+))?$`)
+
+func toBigFloat(value interface{}) *big.Float {
+	f := parseBigFloat(value)
+	if f == nil || f.IsInf() {
+		return nil
+	}
+	if exp := f.MantExp(nil); exp > decimalMaxBinaryExponent || exp < -decimalMaxBinaryExponent {
+		return nil
+	}
+	return f
+}
+
+func parseBigFloat(value interface{}) *big.Float {
+	switch value := value.(type) {
+	case *big.Float:
+		return value
+	case big.Float:
+		return &value
+	case *big.Rat:
+		return new(big.Float).SetPrec(decimalPrecision).SetRat(value)
+	case float32:
+		return toBigFloat(float64(value))
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil
+		}
+		return toBigFloat(strconv.FormatFloat(value, 'g', -1, 64))
+	case json.Number:
+		return toBigFloat(string(value))
+	case string:
+		if len(value) > decimalMaxLength {
+			return nil
+		}
+		match := decimalRegExp.FindStringSubmatch(value)
+		if match == nil {
+			return nil
+		}
+		// The exponent is checked before parsing, which takes time growing with it.
+		if match[3] != "" {
+			if exp, err := strconv.Atoi(match[3]); err != nil || exp > decimalMaxExponent || exp < -decimalMaxExponent {
+				return nil
+			}
+		}
+		f, _, err := big.ParseFloat(value, 10, decimalPrecision, big.ToNearestEven)
+		if err != nil {
+			return nil
+		}
+		return f
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return toBigFloat(*value)
+	}
+	if i := toBigInt(value); i != nil {
+		return new(big.Float).SetPrec(decimalPrecision).SetInt(i)
+	}
+	return nil
+}
+
+func serializeDecimal(value interface{}) interface{} {
+	if f := toBigFloat(value); f != nil {
+		return f.Text('f', -1)
+	}
+	return nil
+}
+
+func unserializeDecimal(value interface{}) interface{} {
+	if f := toBigFloat(value); f != nil {
+		return f
+	}
+	return nil
+}
+
+// Decimal is an arbitrary precision decimal number parsed into *big.Float. Like BigInt it is
+// serialized as a string; float, integer and string literals are accepted as input.
+// Values with an exponent beyond ±1024 are rejected, since their string grows with it.
+var Decimal = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Decimal",
+	Description: "The `Decimal` scalar type represents an arbitrary precision decimal number." +
+		" The Decimal is serialized as a quoted string in positional notation",
+	SpecifiedByURL: "https://pkg.go.dev/math/big#ParseFloat",
+	Serialize:      serializeDecimal,
+	ParseValue:     unserializeDecimal,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return unserializeDecimal(valueAST.Value)
+		case *ast.FloatValue:
+			return unserializeDecimal(valueAST.Value)
+		case *ast.StringValue:
+			return unserializeDecimal(valueAST.Value)
+		}
+		return nil
+	},
+})
+
+func coerceInt64(value interface{}) interface{} {
+	i := toBigInt(value)
+	if i == nil || !i.IsInt64() {
+		return nil
+	}
+	return i.Int64()
+}
+
+// Int64 is a signed 64-bit integer parsed into int64. Unlike the built-in Int it is not
+// limited to 32 bits; it is serialized as a JSON number and also accepts numeric strings.
+var Int64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Int64",
+	Description: "The `Int64` scalar type represents a signed 64-bit numeric non-fractional value." +
+		" Int64 can represent values between -(2^63) and 2^63 - 1.",
+	SpecifiedByURL: "https://pkg.go.dev/builtin#int64",
+	Serialize:      coerceInt64,
+	ParseValue:     coerceInt64,
+	ParseLiteral:   parseIntegerLiteral(coerceInt64),
+})
@@ -0,0 +1,53 @@
+// Package scalars provides commonly used custom scalar types which are not part of the
+// GraphQL specification. Importing the package does not change any schema; the scalars
+// are opt-in and have to be referenced from a type definition or registered into
+// graphql.ParseSDL through Lookup or With.
+package scalars
+
+import (
+	"github.com/tailor-inc/graphql"
+)
+
+// All returns every scalar provided by this package.
+func All() []*graphql.Scalar {
+	return []*graphql.Scalar{
+		Date,
+		Time,
+		Duration,
+		JSON,
+		BigInt,
+		Decimal,
+		UUID,
+		Email,
+		URL,
+		Int64,
+		Byte,
+		Upload,
+	}
+}
+
+// Lookup is a graphql.TypeNameMapOption resolving every scalar of this package by name.
+//
+//	schema, err := graphql.ParseSDL(sdl, resolver, scalars.Lookup)
+func Lookup(name string) graphql.Type {
+	for _, s := range All() {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// With returns a graphql.TypeNameMapOption resolving only the given scalars by name.
+//
+//	schema, err := graphql.ParseSDL(sdl, resolver, scalars.With(scalars.UUID, scalars.Date))
+func With(scalars ...*graphql.Scalar) graphql.TypeNameMapOption {
+	return func(name string) graphql.Type {
+		for _, s := range scalars {
+			if s.Name() == name {
+				return s
+			}
+		}
+		return nil
+	}
+}
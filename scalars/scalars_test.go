@@ -0,0 +1,252 @@
+package scalars_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/scalars"
+)
+
+func TestScalars_AreValid(t *testing.T) {
+	for _, s := range scalars.All() {
+		assert.NoError(t, s.Error(), s.Name())
+		assert.NotEmpty(t, s.SpecifiedByURL(), s.Name())
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2024-02-29", scalars.Date.Serialize(d))
+	assert.Equal(t, "2024-02-29", scalars.Date.Serialize(&d))
+	assert.Equal(t, d, scalars.Date.ParseValue("2024-02-29"))
+	assert.Equal(t, d, scalars.Date.ParseLiteral(&ast.StringValue{Value: "2024-02-29"}))
+	assert.Nil(t, scalars.Date.ParseValue("2023-02-29"))
+	assert.Nil(t, scalars.Date.ParseValue("2024-02-29T00:00:00Z"))
+	assert.Nil(t, scalars.Date.ParseLiteral(&ast.IntValue{Value: "20240229"}))
+}
+
+func TestTime(t *testing.T) {
+	v := time.Date(0, 1, 1, 13, 4, 5, 500000000, time.UTC)
+	assert.Equal(t, "13:04:05.5", scalars.Time.Serialize(v))
+	assert.Equal(t, "13:04:05", scalars.Time.Serialize("13:04:05"))
+	assert.Equal(t, v, scalars.Time.ParseValue("13:04:05.5"))
+	assert.Equal(t, v, scalars.Time.ParseLiteral(&ast.StringValue{Value: "13:04:05.500"}))
+	assert.Nil(t, scalars.Time.ParseValue("25:00:00"))
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"PT0S", 0},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"PT1.5S", 1500 * time.Millisecond},
+		{"-PT10M", -10 * time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			assert.Equal(t, test.out, scalars.Duration.ParseValue(test.in))
+			assert.Equal(t, test.out, scalars.Duration.ParseLiteral(&ast.StringValue{Value: test.in}))
+		})
+	}
+	assert.Equal(t, "PT36H", scalars.Duration.Serialize(36*time.Hour))
+	assert.Equal(t, "PT1M1.5S", scalars.Duration.Serialize(61500*time.Millisecond))
+	assert.Equal(t, "-PT10M", scalars.Duration.Serialize(-10*time.Minute))
+	for _, invalid := range []string{"P", "PT", "P1Y", "P1M", "1h", "P1DT",
+		"P99999999999W", "PT9999999999999H", "PT99999999999999999999S", "P15250W1DT23H47M16.9S"} {
+		assert.Nil(t, scalars.Duration.ParseValue(invalid), invalid)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	literal := &ast.ObjectValue{Fields: []*ast.ObjectField{
+		{Name: &ast.Name{Value: "s"}, Value: &ast.StringValue{Value: "str"}},
+		{Name: &ast.Name{Value: "i"}, Value: &ast.IntValue{Value: "1"}},
+		{Name: &ast.Name{Value: "f"}, Value: &ast.FloatValue{Value: "1.5"}},
+		{Name: &ast.Name{Value: "b"}, Value: &ast.BooleanValue{Value: true}},
+		{Name: &ast.Name{Value: "l"}, Value: &ast.ListValue{Values: []ast.Value{
+			&ast.IntValue{Value: "1"},
+			&ast.ObjectValue{Fields: []*ast.ObjectField{
+				{Name: &ast.Name{Value: "e"}, Value: &ast.EnumValue{Value: "RED"}},
+			}},
+		}}},
+	}}
+	expected := map[string]interface{}{
+		"s": "str",
+		"i": 1,
+		"f": 1.5,
+		"b": true,
+		"l": []interface{}{1, map[string]interface{}{"e": "RED"}},
+	}
+	assert.Equal(t, expected, scalars.JSON.ParseLiteral(literal))
+	assert.Nil(t, scalars.JSON.ParseLiteral(&ast.ListValue{Values: []ast.Value{&ast.Variable{Name: &ast.Name{Value: "v"}}}}))
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, scalars.JSON.Serialize(json.RawMessage(`{"a":1}`)))
+	assert.Equal(t, []string{"a"}, scalars.JSON.Serialize([]string{"a"}))
+}
+
+func TestBigInt(t *testing.T) {
+	n, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	assert.Equal(t, "123456789012345678901234567890", scalars.BigInt.Serialize(n))
+	assert.Equal(t, "42", scalars.BigInt.Serialize(42))
+	assert.Equal(t, n, scalars.BigInt.ParseValue("123456789012345678901234567890"))
+	assert.Equal(t, n, scalars.BigInt.ParseLiteral(&ast.IntValue{Value: "123456789012345678901234567890"}))
+	assert.Equal(t, big.NewInt(3), scalars.BigInt.ParseValue(float64(3)))
+	assert.Nil(t, scalars.BigInt.ParseValue(1.5))
+	assert.Nil(t, scalars.BigInt.ParseValue("0x10"))
+	assert.Nil(t, scalars.BigInt.ParseLiteral(&ast.FloatValue{Value: "1.0"}))
+}
+
+func TestDecimal(t *testing.T) {
+	assert.Equal(t, "0.1", scalars.Decimal.Serialize("0.1"))
+	assert.Equal(t, "1.25", scalars.Decimal.Serialize(1.25))
+	assert.Equal(t, "100", scalars.Decimal.Serialize(100))
+	assert.Equal(t, "1500", scalars.Decimal.Serialize("1.5e3"))
+	assert.Equal(t, "0.333", scalars.Decimal.Serialize(big.NewRat(333, 1000)))
+	parsed := scalars.Decimal.ParseLiteral(&ast.FloatValue{Value: "12345678901234567890.123456789"})
+	if assert.IsType(t, &big.Float{}, parsed) {
+		assert.Equal(t, "12345678901234567890.123456789", parsed.(*big.Float).Text('f', -1))
+	}
+	assert.NotNil(t, scalars.Decimal.ParseLiteral(&ast.IntValue{Value: "3"}))
+	assert.Nil(t, scalars.Decimal.ParseValue("Inf"))
+	assert.Nil(t, scalars.Decimal.ParseValue("1/3"))
+}
+
+func TestDecimal_RejectsHugeValues(t *testing.T) {
+	start := time.Now()
+	assert.Nil(t, scalars.Decimal.ParseValue("1e10000000"))
+	assert.Nil(t, scalars.Decimal.ParseLiteral(&ast.FloatValue{Value: "1e-10000000"}))
+	assert.Nil(t, scalars.Decimal.Serialize("1e99999999999999999999"))
+	assert.Nil(t, scalars.Decimal.ParseValue(strings.Repeat("9", 2000)))
+	assert.Nil(t, scalars.Decimal.Serialize(new(big.Float).SetMantExp(big.NewFloat(1), 1<<20)))
+	assert.Less(t, time.Since(start), time.Second)
+
+	assert.Equal(t, "1"+strings.Repeat("0", 1024), scalars.Decimal.Serialize("1e1024"))
+	assert.NotNil(t, scalars.Decimal.ParseValue("1e-1024"))
+}
+
+func TestInt64(t *testing.T) {
+	assert.Equal(t, int64(9223372036854775807), scalars.Int64.Serialize(uint64(9223372036854775807)))
+	assert.Nil(t, scalars.Int64.Serialize(uint64(9223372036854775808)))
+	assert.Equal(t, int64(-9223372036854775808), scalars.Int64.ParseLiteral(&ast.IntValue{Value: "-9223372036854775808"}))
+	assert.Nil(t, scalars.Int64.ParseLiteral(&ast.IntValue{Value: "9223372036854775808"}))
+	assert.Equal(t, int64(10), scalars.Int64.ParseValue(float64(10)))
+	assert.Equal(t, int64(10), scalars.Int64.ParseValue("10"))
+	assert.Nil(t, scalars.Int64.ParseValue(10.5))
+}
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestUUID(t *testing.T) {
+	const id = "123e4567-e89b-12d3-a456-426614174000"
+	assert.Equal(t, id, scalars.UUID.Serialize(strings.ToUpper(id)))
+	assert.Equal(t, id, scalars.UUID.Serialize(stringer(id)))
+	assert.Equal(t, id, scalars.UUID.Serialize([16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}))
+	assert.Equal(t, id, scalars.UUID.ParseLiteral(&ast.StringValue{Value: id}))
+	assert.Nil(t, scalars.UUID.ParseValue("123e4567e89b12d3a456426614174000"))
+}
+
+func TestEmail(t *testing.T) {
+	assert.Equal(t, "user@example.com", scalars.Email.ParseValue("user@example.com"))
+	assert.Equal(t, "user@example.com", scalars.Email.ParseLiteral(&ast.StringValue{Value: "user@example.com"}))
+	assert.Nil(t, scalars.Email.ParseValue("User <user@example.com>"))
+	assert.Nil(t, scalars.Email.ParseValue("example.com"))
+}
+
+func TestURL(t *testing.T) {
+	u, _ := url.Parse("https://example.com/path?q=1")
+	assert.Equal(t, u, scalars.URL.ParseValue("https://example.com/path?q=1"))
+	assert.Equal(t, u, scalars.URL.ParseLiteral(&ast.StringValue{Value: "https://example.com/path?q=1"}))
+	assert.Equal(t, "https://example.com/path?q=1", scalars.URL.Serialize(u))
+	assert.Nil(t, scalars.URL.ParseValue("/relative"))
+}
+
+func TestByte(t *testing.T) {
+	assert.Equal(t, "aGVsbG8=", scalars.Byte.Serialize([]byte("hello")))
+	assert.Equal(t, []byte("hello"), scalars.Byte.ParseValue("aGVsbG8="))
+	assert.Equal(t, []byte("hello"), scalars.Byte.ParseLiteral(&ast.StringValue{Value: "aGVsbG8="}))
+	assert.Nil(t, scalars.Byte.ParseValue("not base64!"))
+}
+
+func TestLookup(t *testing.T) {
+	assert.Equal(t, scalars.UUID, scalars.Lookup("UUID"))
+	assert.Nil(t, scalars.Lookup("Unknown"))
+
+	opt := scalars.With(scalars.Date)
+	assert.Equal(t, scalars.Date, opt("Date"))
+	assert.Nil(t, opt("UUID"))
+}
+
+func TestParseSDL_WithScalars(t *testing.T) {
+	sdl := `
+scalar Date
+scalar JSON
+scalar UUID
+
+type Query {
+	echo(id: UUID!, on: Date!, meta: JSON): JSON
+}
+`
+	schema, err := graphql.ParseSDL(sdl, func(typeName, fieldName string) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			return map[string]interface{}{
+				"id":   p.Args["id"],
+				"on":   p.Args["on"].(time.Time).Format("Jan 2 2006"),
+				"meta": p.Args["meta"],
+			}, nil
+		}
+	}, scalars.Lookup)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, scalars.UUID, schema.Type("UUID"))
+
+	result := graphql.Do(graphql.Params{
+		Schema: *schema,
+		RequestString: `{
+			echo(id: "123E4567-E89B-12D3-A456-426614174000", on: "2024-01-02", meta: {tags: ["a", "b"], n: 1})
+		}`,
+	})
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, map[string]interface{}{
+		"echo": map[string]interface{}{
+			"id":   "123e4567-e89b-12d3-a456-426614174000",
+			"on":   "Jan 2 2024",
+			"meta": map[string]interface{}{"tags": []interface{}{"a", "b"}, "n": 1},
+		},
+	}, result.DataMap())
+
+	result = graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ echo(id: "not-a-uuid", on: "2024-01-02") }`,
+	})
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestBuildSDL_SpecifiedBy(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: scalars.UUID},
+			},
+		}),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	sdl := graphql.BuildSDL(schema, nil)
+	assert.Contains(t, sdl, `scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")`)
+}
@@ -0,0 +1,183 @@
+package scalars
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/tailor-inc/graphql"
+)
+
+var uuidRegExp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func coerceUUID(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if !uuidRegExp.MatchString(value) {
+			return nil
+		}
+		return strings.ToLower(value)
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return coerceUUID(*value)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", value[0:4], value[4:6], value[6:8], value[8:10], value[10:16])
+	case fmt.Stringer:
+		return coerceUUID(value.String())
+	}
+	return nil
+}
+
+// UUID is an RFC 4122 UUID in its canonical textual form. Values are normalized to lower
+// case; [16]byte and fmt.Stringer values (e.g. uuid.UUID) are serialized as well.
+var UUID = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "UUID",
+	Description:    "The `UUID` scalar type represents a universally unique identifier as specified by RFC 4122.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc4122",
+	Serialize:      coerceUUID,
+	ParseValue:     coerceUUID,
+	ParseLiteral:   parseStringLiteral(coerceUUID),
+})
+
+func coerceEmail(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		addr, err := mail.ParseAddress(value)
+		if err != nil || addr.Address != value {
+			return nil
+		}
+		return value
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return coerceEmail(*value)
+	case mail.Address:
+		return value.Address
+	case *mail.Address:
+		if value == nil {
+			return nil
+		}
+		return value.Address
+	}
+	return nil
+}
+
+// Email is a bare RFC 5322 addr-spec such as `user@example.com`; display names are rejected.
+var Email = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Email",
+	Description:    "The `Email` scalar type represents an email address as specified by RFC 5322 addr-spec.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc5322#section-3.4.1",
+	Serialize:      coerceEmail,
+	ParseValue:     coerceEmail,
+	ParseLiteral:   parseStringLiteral(coerceEmail),
+})
+
+func parseAbsoluteURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
+
+func serializeURL(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *url.URL:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	case url.URL:
+		return value.String()
+	case string:
+		if u := parseAbsoluteURL(value); u != nil {
+			return u.String()
+		}
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return serializeURL(*value)
+	}
+	return nil
+}
+
+func unserializeURL(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		if u := parseAbsoluteURL(value); u != nil {
+			return u
+		}
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return unserializeURL(*value)
+	case *url.URL:
+		return value
+	}
+	return nil
+}
+
+// URL is an absolute RFC 3986 URL parsed into *url.URL.
+var URL = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "URL",
+	Description:    "The `URL` scalar type represents an absolute URL as specified by RFC 3986.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc3986",
+	Serialize:      serializeURL,
+	ParseValue:     unserializeURL,
+	ParseLiteral:   parseStringLiteral(unserializeURL),
+})
+
+func serializeByte(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value)
+	case *[]byte:
+		if value == nil {
+			return nil
+		}
+		return serializeByte(*value)
+	case string:
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return nil
+		}
+		return value
+	}
+	return nil
+}
+
+func unserializeByte(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil
+		}
+		return b
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return unserializeByte(*value)
+	case []byte:
+		return value
+	}
+	return nil
+}
+
+// Byte is binary data parsed into []byte and serialized with standard base64 encoding.
+var Byte = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Byte",
+	Description:    "The `Byte` scalar type represents binary data as a base64 encoded string.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc4648#section-4",
+	Serialize:      serializeByte,
+	ParseValue:     unserializeByte,
+	ParseLiteral:   parseStringLiteral(unserializeByte),
+})
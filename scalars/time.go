@@ -0,0 +1,234 @@
+package scalars
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+func serializeLayout(layout string) graphql.SerializeFn {
+	var serialize graphql.SerializeFn
+	serialize = func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case time.Time:
+			return value.Format(layout)
+		case *time.Time:
+			if value == nil {
+				return nil
+			}
+			return serialize(*value)
+		case string:
+			t, err := time.Parse(layout, value)
+			if err != nil {
+				return nil
+			}
+			return t.Format(layout)
+		case *string:
+			if value == nil {
+				return nil
+			}
+			return serialize(*value)
+		default:
+			return nil
+		}
+	}
+	return serialize
+}
+
+func parseLayout(layout string) graphql.ParseValueFn {
+	var parse graphql.ParseValueFn
+	parse = func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case string:
+			t, err := time.Parse(layout, value)
+			if err != nil {
+				return nil
+			}
+			return t
+		case *string:
+			if value == nil {
+				return nil
+			}
+			return parse(*value)
+		case time.Time:
+			return value
+		default:
+			return nil
+		}
+	}
+	return parse
+}
+
+func parseStringLiteral(parse graphql.ParseValueFn) graphql.ParseLiteralFn {
+	return func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.StringValue:
+			return parse(valueAST.Value)
+		}
+		return nil
+	}
+}
+
+var parseDate = parseLayout(dateLayout)
+
+// Date is a calendar date without time and time zone, serialized as `YYYY-MM-DD`.
+// Values are parsed into time.Time at midnight UTC.
+var Date = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Date",
+	Description: "The `Date` scalar type represents a calendar date without time and time zone." +
+		" The Date is serialized as an RFC 3339 full-date quoted string (YYYY-MM-DD)",
+	SpecifiedByURL: "https://scalars.graphql.org/andimarek/local-date.html",
+	Serialize:      serializeLayout(dateLayout),
+	ParseValue:     parseDate,
+	ParseLiteral:   parseStringLiteral(parseDate),
+})
+
+var parseTime = parseLayout(timeLayout)
+
+// Time is a time of day without date and time zone, serialized as `HH:MM:SS` with optional
+// fractional seconds. Values are parsed into time.Time on January 1, year 0, UTC.
+var Time = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Time",
+	Description: "The `Time` scalar type represents a time of day without date and time zone." +
+		" The Time is serialized as an RFC 3339 partial-time quoted string (HH:MM:SS[.fraction])",
+	SpecifiedByURL: "https://scalars.graphql.org/andimarek/local-time.html",
+	Serialize:      serializeLayout(timeLayout),
+	ParseValue:     parseTime,
+	ParseLiteral:   parseStringLiteral(parseTime),
+})
+
+var durationRegExp = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// parseISODuration parses the week, day and time parts of an ISO 8601 duration.
+// Years and months are rejected since they have no fixed length, and so are durations
+// out of the range of time.Duration.
+func parseISODuration(s string) (time.Duration, error) {
+	m := durationRegExp.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "-P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	errOutOfRange := fmt.Errorf("duration %q is out of range", s)
+	var d time.Duration
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[i+2], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n > int64(math.MaxInt64-d)/int64(unit) {
+			return 0, errOutOfRange
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[6] != "" {
+		sec, err := strconv.ParseFloat(strings.Replace(m[6], ",", ".", 1), 64)
+		if err != nil {
+			return 0, err
+		}
+		// float64(math.MaxInt64-d) may round up, so the bound itself is out of range.
+		if sec*float64(time.Second) >= float64(math.MaxInt64-d) {
+			return 0, errOutOfRange
+		}
+		d += time.Duration(sec * float64(time.Second))
+	}
+	if m[1] != "" {
+		d = -d
+	}
+	return d, nil
+}
+
+// formatISODuration formats d as an ISO 8601 duration using hours, minutes and seconds.
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+func serializeDuration(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Duration:
+		return formatISODuration(value)
+	case *time.Duration:
+		if value == nil {
+			return nil
+		}
+		return formatISODuration(*value)
+	case string:
+		d, err := parseISODuration(value)
+		if err != nil {
+			return nil
+		}
+		return formatISODuration(d)
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return serializeDuration(*value)
+	default:
+		return nil
+	}
+}
+
+func unserializeDuration(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		d, err := parseISODuration(value)
+		if err != nil {
+			return nil
+		}
+		return d
+	case *string:
+		if value == nil {
+			return nil
+		}
+		return unserializeDuration(*value)
+	case time.Duration:
+		return value
+	default:
+		return nil
+	}
+}
+
+// Duration is an ISO 8601 duration such as `PT1H30M` or `P1DT12H`, parsed into time.Duration.
+// Years and months are not accepted because they have no fixed length.
+var Duration = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Duration",
+	Description: "The `Duration` scalar type represents an amount of time." +
+		" The Duration is serialized as an ISO 8601 duration quoted string, e.g. PT1H30M",
+	SpecifiedByURL: "https://en.wikipedia.org/wiki/ISO_8601#Durations",
+	Serialize:      serializeDuration,
+	ParseValue:     unserializeDuration,
+	ParseLiteral:   parseStringLiteral(unserializeDuration),
+})
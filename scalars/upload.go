@@ -0,0 +1,52 @@
+package scalars
+
+import (
+	"io"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+// File is the value of an Upload variable. It is only valid while the request which
+// carried it is being executed; the handler releases the underlying storage afterwards.
+type File struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Reader      io.ReadSeeker
+}
+
+// Read reads from the file contents, so a *File can be passed wherever an io.Reader is expected.
+func (f *File) Read(p []byte) (int, error) {
+	return f.Reader.Read(p)
+}
+
+// Seek implements io.Seeker on the file contents.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	return f.Reader.Seek(offset, whence)
+}
+
+func unserializeUpload(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *File:
+		return value
+	case File:
+		return &value
+	}
+	return nil
+}
+
+// Upload is a file sent with a graphql multipart request. It is an input-only scalar: the
+// handler package injects *File values into the variables, and literals are rejected.
+var Upload = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Upload",
+	Description:    "The `Upload` scalar type represents a file upload sent with a multipart request.",
+	SpecifiedByURL: "https://github.com/jaydenseric/graphql-multipart-request-spec",
+	Serialize: func(value interface{}) interface{} {
+		return nil
+	},
+	ParseValue: unserializeUpload,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return nil
+	},
+})
@@ -7,6 +7,14 @@ type SchemaConfig struct {
 	Types        []Type
 	Directives   []*Directive
 	Extensions   []Extension
+
+	// RequireImplementations reports interfaces which no object type implements. Leave it
+	// unset for schemas whose implementations are appended later with AppendType or are
+	// provided by other services.
+	RequireImplementations bool
+
+	// Visibility hides members of the schema from requests, based on their context.
+	Visibility VisibilityFn
 }
 
 type TypeMap map[string]Type
@@ -43,6 +51,7 @@ type Schema struct {
 	implementations  map[string][]*Object
 	possibleTypeMap  map[string]map[string]bool
 	extensions       []Extension
+	visibility       VisibilityFn
 }
 
 func NewSchema(config SchemaConfig) (Schema, error) {
@@ -99,50 +108,36 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		initialTypes = append(initialTypes, ttype)
 	}
 
+	var errs SchemaErrors
+	reported := map[string]bool{}
 	for _, ttype := range initialTypes {
-		if ttype.Error() != nil {
-			return schema, ttype.Error()
+		err := ttype.Error()
+		if err == nil {
+			typeMap, err = typeMapReducer(&schema, typeMap, ttype)
 		}
-		if typeMap, err = typeMapReducer(&schema, typeMap, ttype); err != nil {
-			return schema, err
+		// A broken type reachable from several initial types is reported once.
+		if err != nil && !reported[err.Error()] {
+			reported[err.Error()] = true
+			errs = append(errs, err)
 		}
 	}
+	if len(errs) > 0 {
+		return schema, errs
+	}
 
 	schema.typeMap = typeMap
+	schema.collectImplementations()
 
-	// Keep track of all implementations by interface name.
-	if schema.implementations == nil {
-		schema.implementations = map[string][]*Object{}
-	}
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
-			for _, iface := range ttype.Interfaces() {
-				impls, ok := schema.implementations[iface.Name()]
-				if impls == nil || !ok {
-					impls = []*Object{}
-				}
-				impls = append(impls, ttype)
-				schema.implementations[iface.Name()] = impls
-			}
-		}
-	}
-
-	// Enforce correct interface implementations
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
-			for _, iface := range ttype.Interfaces() {
-				err := assertObjectImplementsInterface(&schema, ttype, iface)
-				if err != nil {
-					return schema, err
-				}
-			}
-		}
+	// Validate the whole schema at once, so that every problem gets reported.
+	if errs := validateSchema(&schema, config.RequireImplementations); len(errs) > 0 {
+		return schema, SchemaErrors(errs)
 	}
 
 	// Add extensions from config
 	if len(config.Extensions) != 0 {
 		schema.extensions = config.Extensions
 	}
+	schema.visibility = config.Visibility
 
 	return schema, nil
 }
@@ -150,8 +145,25 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 // Added Check implementation of interfaces at runtime..
 // Add Implementations at Runtime..
 func (gq *Schema) AddImplementation() error {
+	gq.collectImplementations()
 
-	// Keep track of all implementations by interface name.
+	// Enforce correct interface implementations
+	for _, ttype := range gq.typeMap {
+		if ttype, ok := ttype.(implementingType); ok {
+			for _, iface := range ttype.Interfaces() {
+				err := assertTypeImplementsInterface(gq, ttype, iface)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectImplementations keeps track of all implementations by interface name.
+func (gq *Schema) collectImplementations() {
 	if gq.implementations == nil {
 		gq.implementations = map[string][]*Object{}
 	}
@@ -167,20 +179,6 @@ func (gq *Schema) AddImplementation() error {
 			}
 		}
 	}
-
-	// Enforce correct interface implementations
-	for _, ttype := range gq.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
-			for _, iface := range ttype.Interfaces() {
-				err := assertObjectImplementsInterface(gq, ttype, iface)
-				if err != nil {
-					return err
-				}
-			}
-		}
-	}
-
-	return nil
 }
 
 // Edited. To check add Types at RunTime..
@@ -264,6 +262,22 @@ func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bo
 	return false
 }
 
+// IsSubType returns true if maybeSubType is a possible object type of abstractType
+// or an interface implementing it.
+func (gq *Schema) IsSubType(abstractType Abstract, maybeSubType Type) bool {
+	switch maybeSubType := maybeSubType.(type) {
+	case *Object:
+		return gq.IsPossibleType(abstractType, maybeSubType)
+	case *Interface:
+		for _, iface := range maybeSubType.Interfaces() {
+			if iface == abstractType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // AddExtensions can be used to add additional extensions to the schema
 func (gq *Schema) AddExtensions(e ...Extension) {
 	gq.extensions = append(gq.extensions, e...)
@@ -349,6 +363,18 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 			}
 		}
 	case *Interface:
+		interfaces := objectType.Interfaces()
+		if objectType.err != nil {
+			return typeMap, objectType.err
+		}
+		for _, innerObjectType := range interfaces {
+			if innerObjectType.err != nil {
+				return typeMap, innerObjectType.err
+			}
+			if typeMap, err = typeMapReducer(schema, typeMap, innerObjectType); err != nil {
+				return typeMap, err
+			}
+		}
 		fieldMap := objectType.Fields()
 		if objectType.err != nil {
 			return typeMap, objectType.err
@@ -380,7 +406,56 @@ func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap,
 	return typeMap, nil
 }
 
-func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Interface) error {
+// implementingType is an object or interface type which may implement interfaces.
+type implementingType interface {
+	Type
+	Fields() FieldDefinitionMap
+	Interfaces() []*Interface
+}
+
+var _ implementingType = (*Object)(nil)
+var _ implementingType = (*Interface)(nil)
+
+func assertTypeImplementsInterface(schema *Schema, object implementingType, iface *Interface) error {
+	err := invariantf(
+		Type(iface) != Type(object),
+		`Type %v cannot implement itself because it would create a circular reference.`, object)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, i := range object.Interfaces() {
+		if i == iface {
+			count++
+		}
+	}
+	err = invariantf(count == 1, `Type %v can only implement %v once.`, object, iface)
+	if err != nil {
+		return err
+	}
+
+	// Assert the interfaces implemented by iface are implemented as well.
+	for _, transitive := range iface.Interfaces() {
+		implemented := false
+		for _, i := range object.Interfaces() {
+			if i == transitive {
+				implemented = true
+				break
+			}
+		}
+		if Type(transitive) == Type(object) {
+			err = invariantf(implemented,
+				`Type %v cannot implement %v because it would create a circular reference.`, object, iface)
+		} else {
+			err = invariantf(implemented,
+				`Type %v must implement %v because it is implemented by %v.`, object, transitive, iface)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	objectFieldMap := object.Fields()
 	ifaceFieldMap := iface.Fields()
 
@@ -390,7 +465,7 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 		ifaceField := ifaceFieldMap[fieldName]
 
 		// Assert interface field exists on object.
-		err := invariantf(
+		err = invariantf(
 			objectField != nil,
 			`"%v" expects field "%v" but "%v" does not `+
 				`provide it.`, iface, fieldName, object)
@@ -530,11 +605,9 @@ func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 	}
 
 	// If superType type is an abstract type, maybeSubType type may be a currently
-	// possible object type.
-	if superType, ok := superType.(*Interface); ok {
-		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
-			return true
-		}
+	// possible object type or an interface implementing it.
+	if superType, ok := superType.(*Interface); ok && schema.IsSubType(superType, maybeSubType) {
+		return true
 	}
 	if superType, ok := superType.(*Union); ok {
 		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
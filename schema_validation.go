@@ -0,0 +1,321 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaErrors holds every problem found while validating a schema.
+type SchemaErrors []error
+
+func (errs SchemaErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// schemaValidationContext collects the errors reported by validateSchema.
+type schemaValidationContext struct {
+	schema                 *Schema
+	requireImplementations bool
+	errors                 []error
+}
+
+func (c *schemaValidationContext) reportError(format string, a ...interface{}) {
+	c.errors = append(c.errors, invariantf(false, format, a...))
+}
+
+// validateSchema implements the type system validation of the specification and
+// returns every problem found, in a stable order.
+func validateSchema(schema *Schema, requireImplementations bool) []error {
+	context := &schemaValidationContext{schema: schema, requireImplementations: requireImplementations}
+	validateRootTypes(context)
+	validateDirectives(context)
+	validateTypes(context)
+	return context.errors
+}
+
+func validateRootTypes(context *schemaValidationContext) {
+	rootTypes := []struct {
+		operation string
+		ttype     *Object
+	}{
+		{"query", context.schema.QueryType()},
+		{"mutation", context.schema.MutationType()},
+		{"subscription", context.schema.SubscriptionType()},
+	}
+	for i, root := range rootTypes {
+		if root.ttype == nil {
+			continue
+		}
+		for _, other := range rootTypes[:i] {
+			if other.ttype == root.ttype {
+				context.reportError(`All root types must be different, "%v" type is used as %v and %v root types.`,
+					root.ttype, other.operation, root.operation)
+			}
+		}
+	}
+}
+
+func validateDirectives(context *schemaValidationContext) {
+	// The name lookup of enums is filled lazily, so it is not used on the shared
+	// __DirectiveLocation type while schemas may be created concurrently.
+	validLocations := map[string]bool{}
+	for _, location := range DirectiveLocationEnumType.Values() {
+		validLocations[location.Name] = true
+	}
+	for _, directive := range context.schema.Directives() {
+		validateName(context, directive.Name, "@"+directive.Name)
+
+		seen := map[string]bool{}
+		for _, location := range directive.Locations {
+			if !validLocations[location] {
+				context.reportError(`Directive @%v has an invalid location "%v".`, directive.Name, location)
+			} else if seen[location] {
+				context.reportError(`Directive @%v has the location "%v" more than once.`, directive.Name, location)
+			}
+			seen[location] = true
+		}
+		for _, arg := range sortedArguments(directive.Args) {
+			validateArgument(context, arg, fmt.Sprintf("@%v(%v:)", directive.Name, arg.Name()))
+		}
+	}
+}
+
+func validateTypes(context *schemaValidationContext) {
+	typeMap := context.schema.TypeMap()
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checkedInputObjects := map[*InputObject]bool{}
+	for _, name := range names {
+		ttype := typeMap[name]
+		if isIntrospectionType(ttype) {
+			continue
+		}
+		validateName(context, name, name)
+
+		switch ttype := ttype.(type) {
+		case *Object:
+			validateFields(context, ttype)
+			validateInterfaces(context, ttype)
+		case *Interface:
+			validateFields(context, ttype)
+			validateInterfaces(context, ttype)
+			if context.requireImplementations && len(context.schema.PossibleTypes(ttype)) == 0 {
+				context.reportError(`Interface %v must be implemented by at least one Object type.`, ttype)
+			}
+		case *Union:
+			types := ttype.Types()
+			if len(types) == 0 {
+				context.reportError(`Union type %v must define one or more member types.`, ttype)
+			}
+			seen := map[*Object]bool{}
+			for _, member := range types {
+				if member == nil {
+					context.reportError(`Union type %v can only include Object types.`, ttype)
+					continue
+				}
+				if seen[member] {
+					context.reportError(`Union type %v can only include type %v once.`, ttype, member)
+				}
+				seen[member] = true
+			}
+		case *Enum:
+			values := ttype.Values()
+			if len(values) == 0 {
+				context.reportError(`Enum type %v must define one or more values.`, ttype)
+			}
+			for _, value := range values {
+				validateName(context, value.Name, fmt.Sprintf("%v.%v", ttype, value.Name))
+			}
+		case *InputObject:
+			fields := ttype.Fields()
+			if len(fields) == 0 {
+				context.reportError(`Input Object type %v must define one or more fields.`, ttype)
+			}
+			for _, fieldName := range sortedInputFieldNames(fields) {
+				field := fields[fieldName]
+				path := fmt.Sprintf("%v.%v", ttype, fieldName)
+				validateName(context, fieldName, path)
+				if ok, messages := isValidDefaultValue(field.DefaultValue, field.Type); !ok {
+					context.reportError(`%v has an invalid default value: %v`, path, strings.Join(messages, " "))
+				}
+			}
+			validateInputObjectCycles(context, ttype, checkedInputObjects)
+		}
+	}
+}
+
+func validateFields(context *schemaValidationContext, ttype implementingType) {
+	fields := ttype.Fields()
+	if len(fields) == 0 {
+		context.reportError(`Type %v must define one or more fields.`, ttype)
+	}
+	fieldNames := make([]string, 0, len(fields))
+	for fieldName := range fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		field := fields[fieldName]
+		validateName(context, fieldName, fmt.Sprintf("%v.%v", ttype, fieldName))
+		for _, arg := range sortedArguments(field.Args) {
+			validateArgument(context, arg, fmt.Sprintf("%v.%v(%v:)", ttype, fieldName, arg.Name()))
+		}
+	}
+}
+
+func validateInterfaces(context *schemaValidationContext, ttype implementingType) {
+	for _, iface := range ttype.Interfaces() {
+		if err := assertTypeImplementsInterface(context.schema, ttype, iface); err != nil {
+			context.errors = append(context.errors, err)
+		}
+	}
+}
+
+func validateArgument(context *schemaValidationContext, arg *Argument, path string) {
+	validateName(context, arg.Name(), path)
+	if ok, messages := isValidDefaultValue(arg.DefaultValue, arg.Type); !ok {
+		context.reportError(`%v has an invalid default value: %v`, path, strings.Join(messages, " "))
+	}
+}
+
+// validateName reports names starting with "__", which are reserved for introspection.
+func validateName(context *schemaValidationContext, name string, path string) {
+	if strings.HasPrefix(name, "__") {
+		context.reportError(`Name "%v" of %v must not begin with "__", which is reserved by GraphQL introspection.`, name, path)
+	}
+}
+
+// validateInputObjectCycles reports input objects referencing themselves through a chain
+// of non-null fields, for which no finite value could ever be provided.
+func validateInputObjectCycles(context *schemaValidationContext, inputObject *InputObject, checked map[*InputObject]bool) {
+	var (
+		path  []string
+		stack []*InputObject
+	)
+	var detect func(ttype *InputObject)
+	detect = func(ttype *InputObject) {
+		if checked[ttype] {
+			return
+		}
+		checked[ttype] = true
+		stack = append(stack, ttype)
+		fields := ttype.Fields()
+		for _, fieldName := range sortedInputFieldNames(fields) {
+			nonNull, ok := fields[fieldName].Type.(*NonNull)
+			if !ok {
+				continue
+			}
+			fieldType, ok := nonNull.OfType.(*InputObject)
+			if !ok {
+				continue
+			}
+			path = append(path, fieldName)
+			cycleStart := -1
+			for i, t := range stack {
+				if t == fieldType {
+					cycleStart = i
+				}
+			}
+			if cycleStart == -1 {
+				detect(fieldType)
+			} else {
+				context.reportError(`Cannot reference Input Object "%v" within itself through a series of non-null fields: "%v".`,
+					fieldType, strings.Join(path[cycleStart:], "."))
+			}
+			path = path[:len(path)-1]
+		}
+		stack = stack[:len(stack)-1]
+	}
+	detect(inputObject)
+}
+
+// isValidDefaultValue accepts both the external name and the internal value of enums,
+// since either is used as a default value in configs.
+func isValidDefaultValue(value interface{}, ttype Input) (bool, []string) {
+	if value == nil {
+		return true, nil
+	}
+	return isValidInputValue(enumNamesOf(value, ttype), ttype, allVisible)
+}
+
+// enumNamesOf replaces the internal values of enums within value by their names.
+func enumNamesOf(value interface{}, ttype Input) interface{} {
+	if isNullish(value) {
+		return value
+	}
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return enumNamesOf(value, ttype.OfType)
+	case *List:
+		valueVal := reflect.ValueOf(value)
+		if valueVal.Kind() != reflect.Slice {
+			return enumNamesOf(value, ttype.OfType)
+		}
+		values := make([]interface{}, valueVal.Len())
+		for i := range values {
+			values[i] = enumNamesOf(valueVal.Index(i).Interface(), ttype.OfType)
+		}
+		return values
+	case *InputObject:
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		fields := ttype.Fields()
+		result := make(map[string]interface{}, len(valueMap))
+		for name, fieldValue := range valueMap {
+			if field, ok := fields[name]; ok {
+				fieldValue = enumNamesOf(fieldValue, field.Type)
+			}
+			result[name] = fieldValue
+		}
+		return result
+	case *Enum:
+		// The values are searched rather than serialized, as Serialize builds its
+		// lookup lazily and schemas are validated concurrently.
+		if reflect.TypeOf(value).Comparable() {
+			for _, enumValue := range ttype.Values() {
+				if enumValue.Value == value {
+					return enumValue.Name
+				}
+			}
+		}
+	}
+	return value
+}
+
+func isIntrospectionType(ttype Type) bool {
+	switch ttype {
+	case SchemaType, DirectiveType, TypeType, FieldType, InputValueType, EnumValueType,
+		TypeKindEnumType, DirectiveLocationEnumType:
+		return true
+	}
+	return false
+}
+
+func sortedArguments(args []*Argument) []*Argument {
+	sorted := append([]*Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	return sorted
+}
+
+func sortedInputFieldNames(fields InputObjectFieldMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
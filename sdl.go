@@ -22,23 +22,43 @@ func argumentAsNode(args []*Argument) (arguments []*ast.InputValueDefinition) {
 			}),
 			Type:        typeAsNode(arg.Type),
 			Description: ast.NewStringValue(&ast.StringValue{Value: arg.Description()}),
+			Directives:  deprecatedAsNode(arg.DeprecationReason),
 		}))
 	}
 	return arguments
 }
 
+func deprecatedAsNode(reason string) []*ast.Directive {
+	if reason == "" {
+		return nil
+	}
+	directive := &ObjectDirective{Directive: DeprecatedDirective}
+	if reason != DefaultDeprecationReason {
+		directive.Args = []ObjectDirectiveArg{{Name: "reason", Value: reason}}
+	}
+	return directivesAsNode([]*ObjectDirective{directive})
+}
+
 func directivesAsNode(directives []*ObjectDirective) []*ast.Directive {
 	var dirs []*ast.Directive
 	for _, directive := range directives {
 		var args []*ast.Argument
 		for _, arg := range directive.Args {
+			var argType Type
+			for _, def := range directive.Directive.Args {
+				if def.Name() == arg.Name {
+					argType = def.Type
+				}
+			}
+			value := astFromValue(arg.Value, argType)
+			if value == nil {
+				continue
+			}
 			args = append(args, ast.NewArgument(&ast.Argument{
 				Name: ast.NewName(&ast.Name{
 					Value: arg.Name,
 				}),
-				Value: ast.NewStringValue(&ast.StringValue{
-					Value: arg.Value.(string),
-				}),
+				Value: value,
 			}))
 		}
 		dirs = append(dirs, ast.NewDirective(&ast.Directive{
@@ -58,27 +78,8 @@ func objectAsNode(o *Object, options *SDLExportOptions) ast.Node {
 			o.Name() == "Query" && name == "_service" {
 			continue
 		}
-		var directives []*ast.Directive
-		for _, d := range object.Directives {
-			var args []*ast.Argument
-			for _, arg := range d.Args {
-				args = append(args, ast.NewArgument(&ast.Argument{
-					Name: ast.NewName(&ast.Name{
-						Value: arg.Name,
-					}),
-					Value: ast.NewStringValue(ast.NewStringValue(&ast.StringValue{
-						Value: arg.Value.(string),
-					})),
-				}))
-			}
-
-			directives = append(directives, ast.NewDirective(&ast.Directive{
-				Name: ast.NewName(&ast.Name{
-					Value: d.Directive.Name,
-				}),
-				Arguments: args,
-			}))
-		}
+		directives := directivesAsNode(object.Directives)
+		directives = append(directives, deprecatedAsNode(object.DeprecationReason)...)
 
 		fields = append(fields, ast.NewFieldDefinition(&ast.FieldDefinition{
 			Name: ast.NewName(&ast.Name{
@@ -113,15 +114,20 @@ func inputObjectAsNode(o *InputObject) *ast.InputObjectDefinition {
 				Value: name,
 			}),
 			Description: ast.NewStringValue(&ast.StringValue{Value: object.Description()}),
-			Directives:  []*ast.Directive{},
+			Directives:  deprecatedAsNode(object.DeprecationReason),
 			Type:        typeAsNode(object.Type),
 		}))
 	}
+	var directives []*ast.Directive
+	if o.IsOneOf() {
+		directives = directivesAsNode([]*ObjectDirective{{Directive: OneOfDirective}})
+	}
 	return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
 		Name: ast.NewName(&ast.Name{
 			Value: o.Name(),
 		}),
 		Description: ast.NewStringValue(&ast.StringValue{Value: o.Description()}),
+		Directives:  append(directives, directivesAsNode(o.Directives())...),
 		Fields:      fields,
 	})
 }
@@ -134,6 +140,7 @@ func enumAsNode(o *Enum) *ast.EnumDefinition {
 				Value: v.Name,
 			}),
 			Description: ast.NewStringValue(&ast.StringValue{Value: v.Description}),
+			Directives:  deprecatedAsNode(v.DeprecationReason),
 		}))
 	}
 	return ast.NewEnumDefinition(&ast.EnumDefinition{
@@ -141,16 +148,25 @@ func enumAsNode(o *Enum) *ast.EnumDefinition {
 			Value: o.Name(),
 		}),
 		Description: ast.NewStringValue(&ast.StringValue{Value: o.Description()}),
+		Directives:  directivesAsNode(o.Directives()),
 		Values:      enumValues,
 	})
 }
 
 func scalarAsNode(o *Scalar) *ast.ScalarDefinition {
+	var directives []*ast.Directive
+	if url := o.SpecifiedByURL(); url != "" {
+		directives = directivesAsNode([]*ObjectDirective{{
+			Directive: SpecifiedByDirective,
+			Args:      []ObjectDirectiveArg{{Name: "url", Value: url}},
+		}})
+	}
 	return ast.NewScalarDefinition(&ast.ScalarDefinition{
 		Name: ast.NewName(&ast.Name{
 			Value: o.Name(),
 		}),
 		Description: ast.NewStringValue(&ast.StringValue{Value: o.Description()}),
+		Directives:  append(directives, directivesAsNode(o.Directives())...),
 	})
 }
 
@@ -169,7 +185,8 @@ func unionAsNode(o *Union) *ast.UnionDefinition {
 		Name: ast.NewName(&ast.Name{
 			Value: o.Name(),
 		}),
-		Types: types,
+		Directives: directivesAsNode(o.directives),
+		Types:      types,
 	})
 }
 
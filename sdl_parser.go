@@ -27,6 +27,19 @@ type GraphqlParser struct {
 	fieldDirectiveMap map[string]FieldDirectives
 	directiveMap      map[string]*Directive
 	sdlResolver       SDLResolver
+	// values are the default values of arguments and the arguments of applied
+	// directives, and inputFieldValues the default values of the input fields of each
+	// input object, which are coerced by coerceValues.
+	values           []sdlValue
+	inputFieldValues map[string][]sdlValue
+}
+
+// sdlValue is a value read from SDL. It is coerced to its type once every input object
+// has its fields, since it may refer to input objects declared later.
+type sdlValue struct {
+	value ast.Value
+	ttype Type
+	set   func(value interface{})
 }
 
 func NewGraphqlParser(sdlResolver SDLResolver) GraphqlParser {
@@ -39,6 +52,7 @@ func NewGraphqlParser(sdlResolver SDLResolver) GraphqlParser {
 		fieldDirectiveMap: make(map[string]FieldDirectives),
 		directiveMap:      make(map[string]*Directive),
 		sdlResolver:       sdlResolver,
+		inputFieldValues:  make(map[string][]sdlValue),
 	}
 }
 
@@ -98,17 +112,76 @@ func asString(value *ast.StringValue) string {
 func (g *GraphqlParser) asObjectDirectives(directives []*ast.Directive) (FieldDirectives, error) {
 	var fieldDirectives FieldDirectives
 	for _, d := range directives {
+		switch d.Name.Value {
+		case DeprecatedDirective.Name, OneOfDirective.Name, SpecifiedByDirective.Name:
+			// @deprecated, @oneOf and @specifiedBy are kept as DeprecationReason, IsOneOf
+			// and SpecifiedByURL
+			continue
+		}
 		if directive, ok := g.directiveMap[d.Name.Value]; ok {
 			fieldDirectives = append(fieldDirectives, &ObjectDirective{
 				Directive: directive,
+				Args:      g.asObjectDirectiveArgs(directive, d.Arguments),
 			})
 		} else {
-			return nil, fmt.Errorf("directive %s is not found", directive.Name)
+			return nil, fmt.Errorf("directive %s is not found", d.Name.Value)
 		}
 	}
 	return fieldDirectives, nil
 }
 
+// asObjectDirectiveArgs returns the arguments of an applied directive, which are
+// coerced using the argument types of its definition by coerceValues.
+func (g *GraphqlParser) asObjectDirectiveArgs(directive *Directive, arguments []*ast.Argument) []ObjectDirectiveArg {
+	args := make([]ObjectDirectiveArg, len(arguments))
+	for i, arg := range arguments {
+		args[i] = ObjectDirectiveArg{Name: arg.Name.Value, Value: arg.Value.GetValue()}
+		for _, def := range directive.Args {
+			if def.Name() == arg.Name.Value {
+				value := &args[i].Value
+				g.values = append(g.values, sdlValue{
+					value: arg.Value,
+					ttype: def.Type,
+					set: func(coerced interface{}) {
+						if coerced != nil {
+							*value = coerced
+						}
+					},
+				})
+			}
+		}
+	}
+	return args
+}
+
+func hasDirective(directives []*ast.Directive, name string) bool {
+	for _, d := range directives {
+		if d.Name != nil && d.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// asDeprecationReason returns the reason given by a @deprecated directive, or an empty
+// string if the element is not deprecated.
+func asDeprecationReason(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name == nil || d.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if arg.Name != nil && arg.Name.Value == "reason" {
+				if v, ok := arg.Value.(*ast.StringValue); ok {
+					return v.Value
+				}
+			}
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
 func (g *GraphqlParser) asFieldConfigArgs(args []*ast.InputValueDefinition) (FieldConfigArgument, error) {
 	fieldConfigArg := make(FieldConfigArgument)
 	for _, arg := range args {
@@ -116,19 +189,61 @@ func (g *GraphqlParser) asFieldConfigArgs(args []*ast.InputValueDefinition) (Fie
 		if err != nil {
 			return nil, err
 		}
-		var defaultValue interface{}
-		if arg.DefaultValue != nil {
-			defaultValue = arg.DefaultValue.GetValue()
+		config := &ArgumentConfig{
+			Type:              type_,
+			Description:       asString(arg.Description),
+			DeprecationReason: asDeprecationReason(arg.Directives),
 		}
-		fieldConfigArg[arg.Name.Value] = &ArgumentConfig{
-			Type:         type_,
-			DefaultValue: defaultValue,
-			Description:  asString(arg.Description),
+		if arg.DefaultValue != nil {
+			// The AST stands for the value until coerceValues.
+			config.DefaultValue = arg.DefaultValue
+			g.values = append(g.values, sdlValue{
+				value: arg.DefaultValue,
+				ttype: type_,
+				set:   func(value interface{}) { config.DefaultValue = value },
+			})
 		}
+		fieldConfigArg[arg.Name.Value] = config
 	}
 	return fieldConfigArg, nil
 }
 
+// coerceValues coerces the values read from SDL, once every type has its fields.
+func (g *GraphqlParser) coerceValues() {
+	// The fields of an input object are built from their configs when first read, so the
+	// default values of its input fields, and of those of the input objects they refer
+	// to, are coerced first.
+	coerced := map[string]bool{}
+	var coerceInputObject func(ttype Type)
+	coerceInputObject = func(ttype Type) {
+		inputObject, ok := GetNamed(ttype).(*InputObject)
+		if !ok || coerced[inputObject.Name()] {
+			return
+		}
+		coerced[inputObject.Name()] = true
+		for _, field := range g.inputFieldMap[inputObject.Name()] {
+			coerceInputObject(field.Type)
+		}
+		for _, value := range g.inputFieldValues[inputObject.Name()] {
+			value.set(valueFromAST(value.value, value.ttype, nil))
+		}
+	}
+	for _, ttype := range g.typeMap {
+		coerceInputObject(ttype)
+	}
+	for _, value := range g.values {
+		value.set(valueFromAST(value.value, value.ttype, nil))
+	}
+	// Directives are built from their arguments before the default values are coerced.
+	for name, directive := range g.directiveMap {
+		for _, arg := range directive.Args {
+			if config, ok := g.fieldConfigArgMap[name][arg.Name()]; ok {
+				arg.DefaultValue = config.DefaultValue
+			}
+		}
+	}
+}
+
 func (g *GraphqlParser) asType(type_ ast.Type) (Type, error) {
 	switch t := type_.(type) {
 	case *ast.Named:
@@ -168,21 +283,233 @@ func (g *GraphqlParser) asType(type_ ast.Type) (Type, error) {
 }
 
 func (g *GraphqlParser) asLocationStrins(names []*ast.Name) []string {
-	locations := make([]string, len(names))
+	locations := make([]string, 0, len(names))
 	for _, name := range names {
 		locations = append(locations, name.Value)
 	}
 	return locations
 }
 
+func asSpecifiedByURL(directives []*ast.Directive) string {
+	for _, d := range directives {
+		if d.Name == nil || d.Name.Value != SpecifiedByDirective.Name {
+			continue
+		}
+		for _, arg := range d.Arguments {
+			if arg.Name != nil && arg.Name.Value == "url" {
+				if v, ok := arg.Value.(*ast.StringValue); ok {
+					return v.Value
+				}
+			}
+		}
+	}
+	return ""
+}
+
 func unisonResolver(p ResolveTypeParams) *Object {
 	return nil
 }
 
+// interfacesThunk resolves the implemented interfaces once all types are declared.
+func (g *GraphqlParser) interfacesThunk(names []*ast.Named) InterfacesThunk {
+	return func() []*Interface {
+		var interfaces []*Interface
+		for _, name := range names {
+			if iface, ok := g.typeMap[name.Name.Value].(*Interface); ok {
+				interfaces = append(interfaces, iface)
+			}
+		}
+		return interfaces
+	}
+}
+
+func (g *GraphqlParser) assertInterfaces(names []*ast.Named) error {
+	for _, name := range names {
+		if _, ok := g.typeMap[name.Name.Value].(*Interface); !ok {
+			return fmt.Errorf("interface %s is not found", name.Name.Value)
+		}
+	}
+	return nil
+}
+
+// mergeExtensions folds the type and schema extensions into the definitions they extend,
+// so split definitions load as if they were declared in one place. Extensions may
+// precede their definition. The given nodes are left untouched.
+func mergeExtensions(nodes []ast.Node) ([]ast.Node, error) {
+	var (
+		merged    []ast.Node
+		schemaDef *ast.SchemaDefinition
+	)
+	definitions := map[string]ast.Node{}
+	for _, node := range nodes {
+		switch def := node.(type) {
+		case *ast.SchemaDefinition:
+			d := *def
+			schemaDef = &d
+			node = schemaDef
+		case *ast.ScalarDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.ObjectDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.InterfaceDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.UnionDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.EnumDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.InputObjectDefinition:
+			d := *def
+			node, definitions[d.Name.Value] = &d, &d
+		case *ast.SchemaExtensionDefinition, *ast.ScalarExtensionDefinition, *ast.TypeExtensionDefinition,
+			*ast.InterfaceExtensionDefinition, *ast.UnionExtensionDefinition, *ast.EnumExtensionDefinition,
+			*ast.InputObjectExtensionDefinition:
+			continue
+		}
+		merged = append(merged, node)
+	}
+
+	extensionError := func(name *ast.Name, kind string) error {
+		if _, ok := definitions[name.Value]; ok {
+			return fmt.Errorf("type %s cannot be extended as %s", name.Value, kind)
+		}
+		return fmt.Errorf("type %s is not found", name.Value)
+	}
+	for _, node := range nodes {
+		switch ext := node.(type) {
+		case *ast.SchemaExtensionDefinition:
+			// The schema keeps no applied directives, so those of extensions would be lost.
+			if len(ext.Definition.Directives) > 0 {
+				return nil, fmt.Errorf("schema extensions cannot apply directives")
+			}
+			if schemaDef == nil {
+				schemaDef = ast.NewSchemaDefinition(&ast.SchemaDefinition{Loc: ext.Loc})
+				merged = append(merged, schemaDef)
+			}
+			schemaDef.OperationTypes = append(schemaDef.OperationTypes, ext.Definition.OperationTypes...)
+		case *ast.ScalarExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.ScalarDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "scalar")
+			}
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+		case *ast.TypeExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.ObjectDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "type")
+			}
+			if err := checkDuplicates(def.Name, def.Fields, ext.Definition.Fields); err != nil {
+				return nil, err
+			}
+			def.Interfaces = append(def.Interfaces, ext.Definition.Interfaces...)
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+			def.Fields = append(def.Fields, ext.Definition.Fields...)
+		case *ast.InterfaceExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.InterfaceDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "interface")
+			}
+			if err := checkDuplicates(def.Name, def.Fields, ext.Definition.Fields); err != nil {
+				return nil, err
+			}
+			def.Interfaces = append(def.Interfaces, ext.Definition.Interfaces...)
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+			def.Fields = append(def.Fields, ext.Definition.Fields...)
+		case *ast.UnionExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.UnionDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "union")
+			}
+			if err := checkDuplicates(def.Name, def.Types, ext.Definition.Types); err != nil {
+				return nil, err
+			}
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+			def.Types = append(def.Types, ext.Definition.Types...)
+		case *ast.EnumExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.EnumDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "enum")
+			}
+			if err := checkDuplicates(def.Name, def.Values, ext.Definition.Values); err != nil {
+				return nil, err
+			}
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+			def.Values = append(def.Values, ext.Definition.Values...)
+		case *ast.InputObjectExtensionDefinition:
+			def, ok := definitions[ext.Definition.Name.Value].(*ast.InputObjectDefinition)
+			if !ok {
+				return nil, extensionError(ext.Definition.Name, "input")
+			}
+			if err := checkDuplicates(def.Name, def.Fields, ext.Definition.Fields); err != nil {
+				return nil, err
+			}
+			def.Directives = append(def.Directives, ext.Definition.Directives...)
+			def.Fields = append(def.Fields, ext.Definition.Fields...)
+		}
+	}
+	return merged, nil
+}
+
+// checkDuplicates reports the fields, values or members which an extension of the type
+// typeName adds although the type already has them, instead of letting the last one win.
+func checkDuplicates(typeName *ast.Name, members, added interface{}) error {
+	seen := map[string]bool{}
+	for _, name := range memberNames(members) {
+		seen[name.Value] = true
+	}
+	for _, name := range memberNames(added) {
+		if seen[name.Value] {
+			if _, ok := added.([]*ast.Named); ok {
+				return fmt.Errorf("union %s already has the member %s", typeName.Value, name.Value)
+			}
+			return fmt.Errorf("%s.%s is already defined", typeName.Value, name.Value)
+		}
+		seen[name.Value] = true
+	}
+	return nil
+}
+
+func memberNames(members interface{}) []*ast.Name {
+	var names []*ast.Name
+	switch members := members.(type) {
+	case []*ast.FieldDefinition:
+		for _, member := range members {
+			names = append(names, member.Name)
+		}
+	case []*ast.InputValueDefinition:
+		for _, member := range members {
+			names = append(names, member.Name)
+		}
+	case []*ast.EnumValueDefinition:
+		for _, member := range members {
+			names = append(names, member.Name)
+		}
+	case []*ast.Named:
+		for _, member := range members {
+			names = append(names, member.Name)
+		}
+	}
+	return names
+}
+
 func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapOption) (*SchemaConfig, error) {
-	var checkHasFields []ast.Node
+	nodes, err := mergeExtensions(nodes)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		checkHasFields []ast.Node
+		directiveDefs  []*ast.DirectiveDefinition
+		schemaDef      *ast.SchemaDefinition
+	)
 	for _, def := range nodes {
 		switch o := def.(type) {
+		case *ast.SchemaDefinition:
+			schemaDef = o
 		case *ast.ScalarDefinition:
 			name := o.Name.Value
 			for _, opt := range opts {
@@ -192,19 +519,24 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 				}
 			}
 			g.typeMap[name] = NewScalar(ScalarConfig{
-				Name:        name,
-				Description: asString(o.Description),
+				Name:           name,
+				Description:    asString(o.Description),
+				SpecifiedByURL: asSpecifiedByURL(o.Directives),
 				Serialize: func(value interface{}) interface{} {
 					return nil
 				},
 			})
+			if len(o.Directives) > 0 {
+				checkHasFields = append(checkHasFields, o)
+			}
 		case *ast.EnumDefinition:
 			name := o.Name.Value
 			values := make(EnumValueConfigMap)
 			for _, v := range o.Values {
 				values[v.Name.Value] = &EnumValueConfig{
-					Value:       v.Name.Value,
-					Description: asString(v.Description),
+					Value:             v.Name.Value,
+					Description:       asString(v.Description),
+					DeprecationReason: asDeprecationReason(v.Directives),
 				}
 			}
 			g.typeMap[name] = NewEnum(EnumConfig{
@@ -212,6 +544,9 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 				Description: asString(o.Description),
 				Values:      values,
 			})
+			if len(o.Directives) > 0 {
+				checkHasFields = append(checkHasFields, o)
+			}
 		case *ast.UnionDefinition:
 			name := o.Name.Value
 			g.unionTypeMap[name] = make([]*Object, len(o.Types))
@@ -229,20 +564,24 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 			g.typeMap[name] = NewObject(ObjectConfig{
 				Name:        name,
 				Description: asString(o.Description),
+				Interfaces:  g.interfacesThunk(o.Interfaces),
 				Fields:      g.typeFieldMap[name],
 				Directives:  g.fieldDirectiveMap[name],
 			})
-			if len(o.Fields) > 0 {
+			if len(o.Fields) > 0 || len(o.Interfaces) > 0 || len(o.Directives) > 0 {
 				checkHasFields = append(checkHasFields, o)
 			}
-		case *ast.TypeExtensionDefinition:
-			name := o.Definition.Name.Value
-			if _, ok := g.typeMap[name]; !ok {
-				return nil, fmt.Errorf("type %s is not found", name)
-			}
-			if len(o.Definition.Fields) > 0 {
-				checkHasFields = append(checkHasFields, o.Definition)
-			}
+		case *ast.InterfaceDefinition:
+			name := o.Name.Value
+			g.typeFieldMap[name] = Fields{}
+			g.typeMap[name] = NewInterface(InterfaceConfig{
+				Name:        name,
+				Description: asString(o.Description),
+				Interfaces:  g.interfacesThunk(o.Interfaces),
+				Fields:      g.typeFieldMap[name],
+				ResolveType: unisonResolver,
+			})
+			checkHasFields = append(checkHasFields, o)
 		case *ast.InputObjectDefinition:
 			name := o.Name.Value
 			g.inputFieldMap[name] = InputObjectConfigFieldMap{}
@@ -250,30 +589,45 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 				Name:        o.Name.Value,
 				Fields:      g.inputFieldMap[name],
 				Description: asString(o.Description),
+				IsOneOf:     hasDirective(o.Directives, OneOfDirective.Name),
 			})
-			if len(o.Fields) > 0 {
+			if len(o.Fields) > 0 || len(o.Directives) > 0 {
 				checkHasFields = append(checkHasFields, o)
 			}
 		case *ast.DirectiveDefinition:
-			name := o.Name.Value
-			g.fieldConfigArgMap[name] = FieldConfigArgument{}
-			locations := g.asLocationStrins(o.Locations)
-			g.directiveMap[name] = NewDirective(DirectiveConfig{
-				Name:        name,
-				Args:        g.fieldConfigArgMap[name],
-				Description: asString(o.Description),
-				Locations:   locations,
-			})
-			if len(o.Arguments) > 0 {
-				checkHasFields = append(checkHasFields, o)
-			}
+			directiveDefs = append(directiveDefs, o)
 		}
 	skip:
 	}
+	// Directives are built before the fields so that their applications can be
+	// resolved and their arguments coerced.
+	for _, o := range directiveDefs {
+		name := o.Name.Value
+		args, err := g.asFieldConfigArgs(o.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		g.fieldConfigArgMap[name] = args
+		g.directiveMap[name] = NewDirective(DirectiveConfig{
+			Name:         name,
+			Args:         args,
+			Description:  asString(o.Description),
+			Locations:    g.asLocationStrins(o.Locations),
+			IsRepeatable: o.Repeatable,
+		})
+	}
 	for _, def := range checkHasFields {
 		switch o := def.(type) {
 		case *ast.ObjectDefinition:
 			name := o.Name.Value
+			if err := g.assertInterfaces(o.Interfaces); err != nil {
+				return nil, err
+			}
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[name].(*Object).directives = directives
 			for _, field := range o.Fields {
 				fieldName := field.Name.Value
 				if t, ok := g.typeFieldMap[name]; ok {
@@ -290,19 +644,57 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 						return nil, err
 					}
 					t[fieldName] = &Field{
-						Name:        fieldName,
-						Args:        args,
-						Type:        type_,
-						Directives:  directives,
-						Description: asString(field.Description),
-						Resolve:     g.sdlResolver(name, fieldName),
+						Name:              fieldName,
+						Args:              args,
+						Type:              type_,
+						Directives:        directives,
+						Description:       asString(field.Description),
+						DeprecationReason: asDeprecationReason(field.Directives),
+						Resolve:           g.sdlResolver(name, fieldName),
 					}
 				} else {
 					return nil, fmt.Errorf("type %s is not found", fieldName)
 				}
 			}
+		case *ast.InterfaceDefinition:
+			name := o.Name.Value
+			if err := g.assertInterfaces(o.Interfaces); err != nil {
+				return nil, err
+			}
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[name].(*Interface).directives = directives
+			for _, field := range o.Fields {
+				type_, err := g.asType(field.Type)
+				if err != nil {
+					return nil, err
+				}
+				args, err := g.asFieldConfigArgs(field.Arguments)
+				if err != nil {
+					return nil, err
+				}
+				directives, err := g.asObjectDirectives(field.Directives)
+				if err != nil {
+					return nil, err
+				}
+				g.typeFieldMap[name][field.Name.Value] = &Field{
+					Name:              field.Name.Value,
+					Args:              args,
+					Type:              type_,
+					Directives:        directives,
+					Description:       asString(field.Description),
+					DeprecationReason: asDeprecationReason(field.Directives),
+				}
+			}
 		case *ast.UnionDefinition:
 			name := o.Name.Value
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[name].(*Union).directives = directives
 			for i, tp := range o.Types {
 				type_, err := g.asType(tp)
 				if err != nil {
@@ -310,8 +702,25 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 				}
 				g.unionTypeMap[name][i] = type_.(*Object)
 			}
+		case *ast.ScalarDefinition:
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[o.Name.Value].(*Scalar).scalarConfig.Directives = directives
+		case *ast.EnumDefinition:
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[o.Name.Value].(*Enum).enumConfig.Directives = directives
 		case *ast.InputObjectDefinition:
 			name := o.Name.Value
+			directives, err := g.asObjectDirectives(o.Directives)
+			if err != nil {
+				return nil, err
+			}
+			g.typeMap[name].(*InputObject).typeConfig.Directives = directives
 			for _, field := range o.Fields {
 				fieldName := field.Name.Value
 				if i, ok := g.inputFieldMap[name]; ok {
@@ -319,36 +728,30 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 					if err != nil {
 						return nil, err
 					}
-					i[fieldName] = &InputObjectFieldConfig{
-						Type:        type_,
-						Description: asString(field.Description),
+					config := &InputObjectFieldConfig{
+						Type:              type_,
+						Description:       asString(field.Description),
+						DeprecationReason: asDeprecationReason(field.Directives),
+					}
+					if field.DefaultValue != nil {
+						// The AST stands for the value until coerceValues.
+						config.DefaultValue = field.DefaultValue
+						g.inputFieldValues[name] = append(g.inputFieldValues[name], sdlValue{
+							value: field.DefaultValue,
+							ttype: type_,
+							set:   func(value interface{}) { config.DefaultValue = value },
+						})
 					}
+					i[fieldName] = config
 				} else {
 					return nil, fmt.Errorf("input type %s is not found", fieldName)
 				}
 			}
-		case *ast.DirectiveDefinition:
-			name := o.Name.Value
-			if f, ok := g.fieldConfigArgMap[name]; ok {
-				for _, arg := range o.Arguments {
-					argName := arg.Name.Value
-					type_, err := g.asType(arg.Type)
-					if err != nil {
-						return nil, err
-					}
-					f[argName] = &ArgumentConfig{
-						Type:         type_,
-						DefaultValue: arg.DefaultValue.GetValue(),
-						Description:  asString(arg.Description),
-					}
-				}
-			} else {
-				return nil, fmt.Errorf("directive %s is not found", name)
-			}
 		default:
 			return nil, fmt.Errorf("%+v", o)
 		}
 	}
+	g.coerceValues()
 	schemaConfig := SchemaConfig{}
 	for _, type_ := range g.typeMap {
 		schemaConfig.Types = append(schemaConfig.Types, type_)
@@ -366,12 +769,30 @@ func (g *GraphqlParser) AstAsSchemaConfig(nodes []ast.Node, opts ...TypeNameMapO
 	if subscription, ok := g.typeMap["Subscription"].(*Object); ok {
 		schemaConfig.Subscription = subscription
 	}
+	if schemaDef != nil {
+		for _, op := range schemaDef.OperationTypes {
+			object, ok := g.typeMap[op.Type.Name.Value].(*Object)
+			if !ok {
+				return nil, fmt.Errorf("type %s is not found", op.Type.Name.Value)
+			}
+			switch op.Operation {
+			case ast.OperationTypeQuery:
+				schemaConfig.Query = object
+			case ast.OperationTypeMutation:
+				schemaConfig.Mutation = object
+			case ast.OperationTypeSubscription:
+				schemaConfig.Subscription = object
+			}
+		}
+	}
 	return &schemaConfig, nil
 }
 
 type SDLResolver func(typeName string, fieldName string) FieldResolveFn
 
-func ParseSDL(sdl string, sdlResolver SDLResolver) (*Schema, error) {
+// ParseSDL builds a schema from the given SDL. Scalars declared in the SDL are
+// looked up through opts first, so custom implementations can be plugged in.
+func ParseSDL(sdl string, sdlResolver SDLResolver, opts ...TypeNameMapOption) (*Schema, error) {
 	doc, err := parser.Parse(parser.ParseParams{
 		Source: sdl,
 	})
@@ -379,7 +800,7 @@ func ParseSDL(sdl string, sdlResolver SDLResolver) (*Schema, error) {
 		return nil, err
 	}
 	g := NewGraphqlParser(sdlResolver)
-	schemaConfig, err := g.AstAsSchemaConfig(doc.Definitions)
+	schemaConfig, err := g.AstAsSchemaConfig(doc.Definitions, opts...)
 	if err != nil {
 		return nil, err
 	}
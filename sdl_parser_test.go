@@ -165,3 +165,329 @@ query Example {
 	t.Log(result)
 
 }
+
+func TestParseSDL_SpecifiedBy(t *testing.T) {
+	schema, err := ParseSDL(`
+scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")
+
+type Query {
+	id: UUID
+}
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "https://www.rfc-editor.org/rfc/rfc4122", schema.Type("UUID").(*Scalar).SpecifiedByURL())
+}
+
+func TestParseSDL_Deprecated(t *testing.T) {
+	schema, err := ParseSDL(`
+enum Color {
+	RED
+	GREEN @deprecated(reason: "Use RED.")
+}
+
+input Filter {
+	color: Color
+	legacy: String @deprecated
+}
+
+type Query {
+	items(filter: Filter, limit: Int @deprecated(reason: "Use filter.")): [String]
+	old: String @deprecated(reason: "Use items.")
+}
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	if !assert.NoError(t, err) {
+		return
+	}
+	query := schema.QueryType()
+	assert.Equal(t, "Use items.", query.Fields()["old"].DeprecationReason)
+	for _, arg := range query.Fields()["items"].Args {
+		if arg.Name() == "limit" {
+			assert.Equal(t, "Use filter.", arg.DeprecationReason)
+		}
+	}
+	assert.Equal(t, DefaultDeprecationReason, schema.Type("Filter").(*InputObject).Fields()["legacy"].DeprecationReason)
+	for _, v := range schema.Type("Color").(*Enum).Values() {
+		if v.Name == "GREEN" {
+			assert.Equal(t, "Use RED.", v.DeprecationReason)
+		}
+	}
+
+	sdl := BuildSDL(*schema, nil)
+	assert.Contains(t, sdl, `old: String @deprecated(reason: "Use items.")`)
+	assert.Contains(t, sdl, `limit: Int @deprecated(reason: "Use filter.")`)
+	assert.Contains(t, sdl, `legacy: String @deprecated`)
+	assert.Contains(t, sdl, `GREEN @deprecated(reason: "Use RED.")`)
+}
+
+func TestParseSDL_OneOf(t *testing.T) {
+	schema, err := ParseSDL(`
+input UserBy @oneOf {
+	id: ID
+	email: String
+}
+
+type Query {
+	user(by: UserBy!): String
+}
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, schema.Type("UserBy").(*InputObject).IsOneOf())
+	assert.Contains(t, BuildSDL(*schema, nil), "input UserBy @oneOf {")
+}
+
+func TestParseSDL_Extensions(t *testing.T) {
+	schema, err := ParseSDL(`
+extend enum Role {
+	ADMIN
+}
+
+enum Role {
+	USER
+}
+
+input UserFilter {
+	role: Role
+}
+
+extend input UserFilter {
+	name: String
+}
+
+extend input UserBy @oneOf
+
+input UserBy {
+	id: ID
+}
+
+extend input UserBy {
+	email: String
+}
+
+scalar Date
+
+extend scalar Date @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+interface Node {
+	id: ID!
+}
+
+interface Named {
+	name: String
+}
+
+extend interface Named implements Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	role: Role
+}
+
+extend type User implements Named {
+	name: String
+}
+
+type Group implements Node & Named {
+	id: ID!
+	name: String
+}
+
+union Member = User
+
+extend union Member = Group
+
+type RootQuery {
+	users(filter: UserFilter, by: UserBy): [User]
+	members: [Member]
+	named: Named
+	since: Date
+}
+
+type RootMutation {
+	noop: Boolean
+}
+
+schema {
+	query: RootQuery
+}
+
+extend schema {
+	mutation: RootMutation
+}
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "RootQuery", schema.QueryType().Name())
+	assert.Equal(t, "RootMutation", schema.MutationType().Name())
+
+	var roles []string
+	for _, value := range schema.Type("Role").(*Enum).Values() {
+		roles = append(roles, value.Name)
+	}
+	assert.ElementsMatch(t, []string{"ADMIN", "USER"}, roles)
+
+	filter := schema.Type("UserFilter").(*InputObject).Fields()
+	assert.Contains(t, filter, "role")
+	assert.Contains(t, filter, "name")
+	userBy := schema.Type("UserBy").(*InputObject)
+	assert.True(t, userBy.IsOneOf())
+	assert.Len(t, userBy.Fields(), 2)
+
+	assert.Equal(t, "https://tools.ietf.org/html/rfc3339", schema.Type("Date").(*Scalar).SpecifiedByURL())
+
+	named := schema.Type("Named").(*Interface)
+	assert.Equal(t, []*Interface{schema.Type("Node").(*Interface)}, named.Interfaces())
+	assert.Contains(t, named.Fields(), "id")
+
+	user := schema.Type("User").(*Object)
+	assert.Len(t, user.Interfaces(), 2)
+	assert.Contains(t, user.Fields(), "name")
+	assert.Len(t, schema.PossibleTypes(named), 2)
+
+	assert.Len(t, schema.Type("Member").(*Union).Types(), 2)
+}
+
+func TestParseSDL_ExtensionErrors(t *testing.T) {
+	resolver := func(typeName, fieldName string) FieldResolveFn { return nil }
+	_, err := ParseSDL(`
+type Query { a: String }
+extend enum Missing { A }
+`, resolver)
+	assert.EqualError(t, err, "type Missing is not found")
+
+	_, err = ParseSDL(`
+type Query { a: String }
+extend input Query { b: String }
+`, resolver)
+	assert.EqualError(t, err, "type Query cannot be extended as input")
+
+	_, err = ParseSDL(`
+directive @foo on SCHEMA
+type Query { a: String }
+extend schema @foo
+`, resolver)
+	assert.EqualError(t, err, "schema extensions cannot apply directives")
+
+	for sdl, message := range map[string]string{
+		`type Query { a: Int } extend type Query { a: String }`:                      "Query.a is already defined",
+		`type Query { a: Int } interface I { a: Int } extend interface I { a: Int }`: "I.a is already defined",
+		`type Query { a: Int } input I { a: Int } extend input I { b: Int a: Int }`:  "I.a is already defined",
+		`type Query { a: Int } enum E { A } extend enum E { B } extend enum E { B }`: "E.B is already defined",
+		`type Query { a: Int } union U = Query extend union U = Query`:               "union U already has the member Query",
+	} {
+		_, err = ParseSDL(sdl, resolver)
+		assert.EqualError(t, err, message, sdl)
+	}
+}
+
+func TestParseSDL_RepeatableDirectives(t *testing.T) {
+	schema, err := ParseSDL(`
+directive @key(fields: String!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE
+
+directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION
+
+type User @key(fields: "id") @key(fields: "email", resolvable: false) @tag(name: "public") {
+	id: ID!
+	email: String @tag(name: "private") @tag(name: "pii")
+}
+
+type Query {
+	me: User
+}
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	if !assert.NoError(t, err) {
+		return
+	}
+	key := schema.Directive("key")
+	if assert.NotNil(t, key) {
+		assert.True(t, key.IsRepeatable)
+		assert.Equal(t, []string{DirectiveLocationObject, DirectiveLocationInterface}, key.Locations)
+		assert.Len(t, key.Args, 2)
+	}
+
+	user := schema.Type("User").(*Object)
+	directives := user.Directives()
+	if assert.Len(t, directives, 3) {
+		assert.Equal(t, []ObjectDirectiveArg{{Name: "fields", Value: "id"}}, directives[0].Args)
+		assert.Equal(t, []ObjectDirectiveArg{{Name: "fields", Value: "email"}, {Name: "resolvable", Value: false}}, directives[1].Args)
+		assert.Equal(t, "tag", directives[2].Directive.Name)
+	}
+	assert.Len(t, user.Fields()["email"].Directives, 2)
+
+	sdl := BuildSDL(*schema, nil)
+	assert.Contains(t, sdl, `type User @key(fields: "id") @key(fields: "email", resolvable: false) @tag(name: "public") {`)
+	assert.Contains(t, sdl, `email: String @tag(name: "private") @tag(name: "pii")`)
+}
+
+func TestParseSDL_DefaultValues(t *testing.T) {
+	schema, err := ParseSDL(`
+input R { a: Int }
+type Query { f(r: R = {a: 1}, l: [Int] = [1, 2]): Int }
+`, func(typeName, fieldName string) FieldResolveFn { return nil })
+	if !assert.NoError(t, err) {
+		return
+	}
+	defaults := map[string]interface{}{}
+	for _, arg := range schema.QueryType().Fields()["f"].Args {
+		defaults[arg.Name()] = arg.DefaultValue
+	}
+	assert.Equal(t, map[string]interface{}{
+		"r": map[string]interface{}{"a": 1},
+		"l": []interface{}{1, 2},
+	}, defaults)
+}
+
+func TestParseSDL_DefaultValuesOfLaterTypes(t *testing.T) {
+	resolver := func(typeName, fieldName string) FieldResolveFn { return nil }
+	defaultOf := func(args []*Argument, name string) interface{} {
+		for _, arg := range args {
+			if arg.Name() == name {
+				return arg.DefaultValue
+			}
+		}
+		return nil
+	}
+	for _, sdl := range []string{
+		`type Query { f(r: R = {a: 1}): Int } input R { a: Int }`,
+		`input R { a: Int } type Query { f(r: R = {a: 1}): Int }`,
+	} {
+		schema, err := ParseSDL(sdl, resolver)
+		if !assert.NoError(t, err, sdl) {
+			continue
+		}
+		assert.Equal(t, map[string]interface{}{"a": 1}, defaultOf(schema.QueryType().Fields()["f"].Args, "r"), sdl)
+	}
+
+	schema, err := ParseSDL(`
+directive @d(r: R = {a: 2}) on FIELD_DEFINITION
+type Query { f(r: Filter = {byName: "x"}): Int @d }
+input Filter @oneOf { byName: String byID: ID }
+input R { a: Int }
+`, resolver)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]interface{}{"byName": "x"}, defaultOf(schema.QueryType().Fields()["f"].Args, "r"))
+	assert.Equal(t, map[string]interface{}{"a": 2}, defaultOf(schema.Directive("d").Args, "r"))
+}
+
+func TestParseSDL_InputFieldDefaultsOfLaterTypes(t *testing.T) {
+	resolver := func(typeName, fieldName string) FieldResolveFn { return nil }
+	for _, sdl := range []string{
+		`input R { o: S = {x: 1} } input S { x: Int y: Int = 2 } type Query { f(r: R = {}): Int }`,
+		`type Query { f(r: R = {}): Int } input S { x: Int y: Int = 2 } input R { o: S = {x: 1} }`,
+		`input S { x: Int y: Int = 2 } input R { o: S = {x: 1} } type Query { f(r: R = {}): Int }`,
+	} {
+		schema, err := ParseSDL(sdl, resolver)
+		if !assert.NoError(t, err, sdl) {
+			continue
+		}
+		o := map[string]interface{}{"x": 1, "y": 2}
+		assert.Equal(t, o, schema.Type("R").(*InputObject).Fields()["o"].DefaultValue, sdl)
+		assert.Equal(t, map[string]interface{}{"o": o}, schema.QueryType().Fields()["f"].Args[0].DefaultValue, sdl)
+	}
+}
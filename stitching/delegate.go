@@ -0,0 +1,568 @@
+package stitching
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/language/visitor"
+)
+
+const (
+	typenameField = "__typename"
+	// keyPrefix prefixes the aliases of the keys selected along with merged types.
+	keyPrefix = "__key_"
+	// keyVariable and mergeAlias are the variable and alias of the queries resolving
+	// merged objects by their key.
+	keyVariable = "__key"
+	mergeAlias  = "__merge"
+)
+
+// delegate returns the resolver of the root fields of operation delegated to sub.
+func (s *stitcher) delegate(sub *SubSchema, operation string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		d := &delegation{stitcher: s, sub: sub, info: p.Info}
+		field := d.field(rootOf(sub.Schema, operation), p.Info.FieldASTs)
+		data, failed := d.execute(p.Context, operation, field, nil, nil)
+		if failed != nil {
+			return nil, failed.error(p.Info.Path.Prev.AsArray(), p.Info.FieldASTs)
+		}
+		value, _ := get(data, responseKey(p.Info))
+		return s.resolved(value, p.Info)
+	}
+}
+
+// resolveMerged returns the resolver of the field fieldName of the merged type
+// typeName. Fields missing from the source object are fetched from the first subschema
+// merging the type which defines them, together with the other fields of the object
+// which the subschema resolves.
+func (s *stitcher) resolveMerged(typeName, fieldName string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if value, ok := get(p.Source, responseKey(p.Info)); ok {
+			return s.resolved(value, p.Info)
+		}
+		object, ok := p.Source.(*mergedObject)
+		sub := s.owner(typeName, fieldName)
+		if !ok || sub == nil {
+			return nil, nil
+		}
+		merged := object.fetch(sub, func() interface{} {
+			d := &delegation{stitcher: s, sub: sub, info: p.Info}
+			return d.fetchMerged(p.Context, typeName, object)
+		})
+		if failed, ok := merged.(*failure); ok {
+			// The fetch failed as a whole, which is reported once at the object.
+			return nil, failed.error(p.Info.Path.Prev.AsArray(), p.Info.FieldASTs)
+		}
+		value, _ := get(merged, responseKey(p.Info))
+		return s.resolved(value, p.Info)
+	}
+}
+
+// owner returns the first subschema merging the type typeName which defines its field
+// fieldName.
+func (s *stitcher) owner(typeName, fieldName string) *SubSchema {
+	for _, sub := range s.merging[typeName] {
+		if fieldDefinition(sub.Schema.Type(typeName), fieldName) != nil {
+			return sub
+		}
+	}
+	return nil
+}
+
+// resolveResult resolves the fields of the objects returned by subschemas.
+func (s *stitcher) resolveResult(p graphql.ResolveParams) (interface{}, error) {
+	value, _ := get(p.Source, responseKey(p.Info))
+	return s.resolved(value, p.Info)
+}
+
+// resolved returns the gateway value of value, the value returned by a subschema for
+// the field of info. Values which failed fail with the errors of the subschema.
+func (s *stitcher) resolved(value interface{}, info graphql.ResolveInfo) (interface{}, error) {
+	if failed, ok := value.(*failure); ok {
+		return nil, failed.error(info.Path.AsArray(), info.FieldASTs)
+	}
+	return s.gatewayValue(value, info, info.Path.AsArray()), nil
+}
+
+// gatewayValue keeps the fields selecting the objects of merged types within value,
+// and replaces the list items which failed by thunks failing with the errors of the
+// subschema. path is the gateway path of value.
+func (s *stitcher) gatewayValue(value interface{}, info graphql.ResolveInfo, path []interface{}) interface{} {
+	switch value := value.(type) {
+	case *failure:
+		return func() (interface{}, error) {
+			if err := value.error(path, info.FieldASTs); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = s.gatewayValue(item, info, append(path[:len(path):len(path)], i))
+		}
+		return items
+	case *graphql.OrderedMap:
+		if s.mayMerge(graphql.GetNamed(info.ReturnType)) {
+			return &mergedObject{source: value, fieldASTs: info.FieldASTs, fetched: map[*SubSchema]interface{}{}}
+		}
+	}
+	return value
+}
+
+// mayMerge reports whether the values of ttype may be objects of merged types.
+func (s *stitcher) mayMerge(ttype graphql.Named) bool {
+	switch ttype := ttype.(type) {
+	case *graphql.Object:
+		return len(s.merging[ttype.Name()]) > 0
+	case *graphql.Interface, *graphql.Union:
+		return len(s.merging) > 0
+	}
+	return false
+}
+
+// mergedObject is an object of a merged type returned by a subschema, with the fields
+// selecting it, so that the fields which it lacks are fetched from each subschema in a
+// single request.
+type mergedObject struct {
+	source    interface{}
+	fieldASTs []*ast.Field
+
+	mu      sync.Mutex
+	fetched map[*SubSchema]interface{}
+}
+
+// fetch returns the fields of the object fetched from sub, fetching them the first
+// time.
+func (o *mergedObject) fetch(sub *SubSchema, fetch func() interface{}) interface{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	merged, ok := o.fetched[sub]
+	if !ok {
+		merged = fetch()
+		o.fetched[sub] = merged
+	}
+	return merged
+}
+
+// fetchMerged fetches from the subschema the fields of object, an object of the merged
+// type typeName, which it lacks and which the subschema resolves.
+func (d *delegation) fetchMerged(ctx context.Context, typeName string, object *mergedObject) interface{} {
+	merge := d.sub.Merge[typeName]
+	key, _ := get(object.source, keyPrefix+merge.Key)
+	if key == nil {
+		return nil
+	}
+	subObject := d.sub.Schema.Type(typeName).(*graphql.Object)
+	keys, fields := d.selectedFields(typeName, object.fieldASTs)
+	var selections []ast.Selection
+	for _, responseKey := range keys {
+		fieldName := fields[responseKey][0].Name.Value
+		if _, ok := get(object.source, responseKey); ok || d.owner(typeName, fieldName) != d.sub {
+			continue
+		}
+		selections = append(selections, d.field(subObject, fields[responseKey]))
+	}
+	if len(selections) == 0 {
+		return nil
+	}
+
+	arg := argument(d.sub.Schema.QueryType().Fields()[merge.FieldName], merge.ArgName)
+	field := ast.NewField(&ast.Field{
+		Alias: name(mergeAlias),
+		Name:  name(merge.FieldName),
+		Arguments: []*ast.Argument{ast.NewArgument(&ast.Argument{
+			Name:  name(merge.ArgName),
+			Value: ast.NewVariable(&ast.Variable{Name: name(keyVariable)}),
+		})},
+		SelectionSet: ast.NewSelectionSet(&ast.SelectionSet{Selections: selections}),
+	})
+	definition := ast.NewVariableDefinition(&ast.VariableDefinition{
+		Variable: ast.NewVariable(&ast.Variable{Name: name(keyVariable)}),
+		Type:     typeAST(arg.Type),
+	})
+	data, failed := d.execute(ctx, ast.OperationTypeQuery, field, definition, key)
+	if failed != nil {
+		// The path of the object in the gateway stands for the alias of the merging field.
+		failed.depth = 1
+		return failed
+	}
+	merged, _ := get(data, mergeAlias)
+	return merged
+}
+
+// selectedFields returns the fields which the selection sets of fieldASTs select on an
+// object of the gateway type typeName, grouped by response key, and their response keys
+// in order.
+func (d *delegation) selectedFields(typeName string, fieldASTs []*ast.Field) ([]string, map[string][]*ast.Field) {
+	object, _ := d.info.Schema.Type(typeName).(*graphql.Object)
+	var keys []string
+	fields := map[string][]*ast.Field{}
+	var collect func(set *ast.SelectionSet)
+	collect = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name.Value == typenameField {
+					continue
+				}
+				responseKey := selection.Name.Value
+				if selection.Alias != nil {
+					responseKey = selection.Alias.Value
+				}
+				if _, ok := fields[responseKey]; !ok {
+					keys = append(keys, responseKey)
+				}
+				fields[responseKey] = append(fields[responseKey], selection)
+			case *ast.InlineFragment:
+				if selection.TypeCondition == nil || d.applies(selection.TypeCondition.Name.Value, object) {
+					collect(selection.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				fragment, ok := d.info.Fragments[selection.Name.Value].(*ast.FragmentDefinition)
+				if ok && d.applies(fragment.TypeCondition.Name.Value, object) {
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	for _, fieldAST := range fieldASTs {
+		collect(fieldAST.SelectionSet)
+	}
+	return keys, fields
+}
+
+// applies reports whether a fragment on the gateway type typeName applies to object.
+func (d *delegation) applies(typeName string, object *graphql.Object) bool {
+	if object == nil {
+		return false
+	}
+	switch condition := d.info.Schema.Type(typeName).(type) {
+	case *graphql.Object:
+		return condition == object
+	case graphql.Abstract:
+		return d.info.Schema.IsPossibleType(condition, object)
+	}
+	return false
+}
+
+// delegation rewrites the selection of a gateway field into a document of a subschema.
+type delegation struct {
+	*stitcher
+	sub  *SubSchema
+	info graphql.ResolveInfo
+}
+
+// execute executes the operation selecting field against the subschema, with the
+// variables of the gateway operation which it uses. The variable key is declared by
+// definition, when given.
+//
+// The errors of the subschema take the place of the values which failed in the
+// returned data, so that the gateway reports them at the same place in its response.
+// Those which have no such place are returned.
+func (d *delegation) execute(ctx context.Context, operation string, field *ast.Field, definition *ast.VariableDefinition, key interface{}) (interface{}, *failure) {
+	used := map[string]bool{}
+	visitor.Walk(field, &visitor.Walker{KindWalkFuncs: visitor.KindWalkFuncs{
+		EnterVariable: func(c *visitor.Cursor, variable *ast.Variable) string {
+			used[variable.Name.Value] = true
+			return visitor.ActionNoChange
+		},
+	}})
+	var definitions []*ast.VariableDefinition
+	args := map[string]interface{}{}
+	if gatewayOperation, ok := d.info.Operation.(*ast.OperationDefinition); ok {
+		for _, definition := range gatewayOperation.VariableDefinitions {
+			name := definition.Variable.Name.Value
+			if !used[name] {
+				continue
+			}
+			definitions = append(definitions, definition)
+			if value, ok := d.info.VariableValues[name]; ok {
+				args[name] = value
+			}
+		}
+	}
+	if definition != nil {
+		definitions = append(definitions, definition)
+		args[keyVariable] = key
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema: d.sub.Schema,
+		AST: ast.NewDocument(&ast.Document{
+			Definitions: []ast.Node{ast.NewOperationDefinition(&ast.OperationDefinition{
+				Operation:           operation,
+				VariableDefinitions: definitions,
+				SelectionSet:        ast.NewSelectionSet(&ast.SelectionSet{Selections: []ast.Selection{field}}),
+			})},
+		}),
+		Args:    args,
+		Context: ctx,
+	})
+	var unplaced []gqlerrors.FormattedError
+	for _, err := range result.Errors {
+		if !place(result.Data, err) {
+			unplaced = append(unplaced, err)
+		}
+	}
+	if len(unplaced) > 0 {
+		return nil, &failure{errs: unplaced}
+	}
+	return result.Data, nil
+}
+
+// failure takes the place of a value of the data of a subschema which failed, with the
+// errors reported by the subschema.
+type failure struct {
+	errs []gqlerrors.FormattedError
+	// depth is the length of the path of the value in the subschema data, which the
+	// paths of the errors start with.
+	depth int
+
+	mu       sync.Mutex
+	reported bool
+}
+
+// place puts err in data in place of the value at its path, or of the closest of its
+// ancestors which is null. It reports false when data has no such value.
+func place(data interface{}, err gqlerrors.FormattedError) bool {
+	parent := data
+	for i, key := range err.Path {
+		last := i == len(err.Path)-1
+		switch object := parent.(type) {
+		case *graphql.OrderedMap:
+			responseKey, ok := key.(string)
+			if !ok {
+				return false
+			}
+			value, _ := object.Get(responseKey)
+			if value == nil || last || isFailure(value) {
+				object.Set(responseKey, failed(value, err, i+1))
+				return true
+			}
+			parent = value
+		case []interface{}:
+			index, ok := key.(int)
+			if !ok || index < 0 || index >= len(object) {
+				return false
+			}
+			value := object[index]
+			if value == nil || last || isFailure(value) {
+				object[index] = failed(value, err, i+1)
+				return true
+			}
+			parent = value
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func failed(value interface{}, err gqlerrors.FormattedError, depth int) *failure {
+	if value, ok := value.(*failure); ok {
+		value.errs = append(value.errs, err)
+		return value
+	}
+	return &failure{errs: []gqlerrors.FormattedError{err}, depth: depth}
+}
+
+func isFailure(value interface{}) bool {
+	_, ok := value.(*failure)
+	return ok
+}
+
+// error returns the error of the failure for the gateway field of fieldASTs, at the
+// gateway path path of the failed value. The failure is reported once: error returns
+// nil afterwards. Several errors are combined into one.
+func (f *failure) error(path []interface{}, fieldASTs []*ast.Field) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reported {
+		return nil
+	}
+	f.reported = true
+
+	nodes := gqlerrors.FieldASTsToNodeASTs(fieldASTs)
+	if len(f.errs) == 1 {
+		err := f.errs[0]
+		if len(err.Path) > f.depth {
+			path = append(path[:len(path):len(path)], err.Path[f.depth:]...)
+		}
+		return gqlerrors.NewErrorWithPath(err.Message, nodes, "", nil, nil, path, remoteError{err})
+	}
+	messages := make([]string, len(f.errs))
+	for i, err := range f.errs {
+		messages[i] = err.Message
+	}
+	return gqlerrors.NewErrorWithPath(strings.Join(messages, "\n"), nodes, "", nil, nil, path, nil)
+}
+
+// remoteError is an error reported by a subschema, which keeps its extensions.
+type remoteError struct {
+	err gqlerrors.FormattedError
+}
+
+func (e remoteError) Error() string {
+	return e.err.Message
+}
+
+func (e remoteError) Extensions() map[string]interface{} {
+	return e.err.Extensions
+}
+
+// field rewrites the selections of a field of parentType, a type of the subschema,
+// into a single field.
+func (d *delegation) field(parentType graphql.Type, fieldASTs []*ast.Field) *ast.Field {
+	first := fieldASTs[0]
+	field := ast.NewField(&ast.Field{
+		Alias:      first.Alias,
+		Name:       first.Name,
+		Arguments:  first.Arguments,
+		Directives: first.Directives,
+	})
+	var sets []*ast.SelectionSet
+	for _, fieldAST := range fieldASTs {
+		if fieldAST.SelectionSet != nil {
+			sets = append(sets, fieldAST.SelectionSet)
+		}
+	}
+	if definition := fieldDefinition(parentType, first.Name.Value); definition != nil && len(sets) > 0 {
+		field.SelectionSet = d.selectionSet(graphql.GetNamed(definition.Type).(graphql.Type), sets)
+	}
+	return field
+}
+
+// selectionSet rewrites selection sets on ttype, a type of the subschema, leaving out
+// the fields and fragments unknown to the subschema. Abstract types select their
+// __typename, and merged types their keys.
+func (d *delegation) selectionSet(ttype graphql.Type, sets []*ast.SelectionSet) *ast.SelectionSet {
+	var selections []ast.Selection
+	for _, set := range sets {
+		for _, selection := range set.Selections {
+			if selection := d.selection(ttype, selection); selection != nil {
+				selections = append(selections, selection)
+			}
+		}
+	}
+	switch ttype.(type) {
+	case *graphql.Interface, *graphql.Union:
+		selections = append(selections, typename())
+	case *graphql.Object:
+		seen := map[string]bool{}
+		for _, sub := range d.merging[ttype.Name()] {
+			key := sub.Merge[ttype.Name()].Key
+			if seen[key] || fieldDefinition(ttype, key) == nil {
+				continue
+			}
+			seen[key] = true
+			selections = append(selections, ast.NewField(&ast.Field{
+				Alias: name(keyPrefix + key),
+				Name:  name(key),
+			}))
+		}
+	}
+	if len(selections) == 0 {
+		selections = append(selections, typename())
+	}
+	return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+}
+
+func (d *delegation) selection(ttype graphql.Type, selection ast.Selection) ast.Selection {
+	switch selection := selection.(type) {
+	case *ast.Field:
+		if selection.Name.Value == typenameField {
+			return selection
+		}
+		if fieldDefinition(ttype, selection.Name.Value) == nil {
+			return nil
+		}
+		return d.field(ttype, []*ast.Field{selection})
+	case *ast.InlineFragment:
+		condition := ttype
+		if selection.TypeCondition != nil {
+			if condition = d.sub.Schema.Type(selection.TypeCondition.Name.Value); condition == nil {
+				return nil
+			}
+		}
+		return ast.NewInlineFragment(&ast.InlineFragment{
+			TypeCondition: selection.TypeCondition,
+			Directives:    selection.Directives,
+			SelectionSet:  d.selectionSet(condition, []*ast.SelectionSet{selection.SelectionSet}),
+		})
+	case *ast.FragmentSpread:
+		// Fragments are inlined, so that only the types known to the subschema are kept.
+		fragment, ok := d.info.Fragments[selection.Name.Value].(*ast.FragmentDefinition)
+		if !ok {
+			return nil
+		}
+		condition := d.sub.Schema.Type(fragment.TypeCondition.Name.Value)
+		if condition == nil {
+			return nil
+		}
+		return ast.NewInlineFragment(&ast.InlineFragment{
+			TypeCondition: fragment.TypeCondition,
+			Directives:    selection.Directives,
+			SelectionSet:  d.selectionSet(condition, []*ast.SelectionSet{fragment.SelectionSet}),
+		})
+	}
+	return nil
+}
+
+func fieldDefinition(ttype graphql.Type, fieldName string) *graphql.FieldDefinition {
+	switch ttype := ttype.(type) {
+	case *graphql.Object:
+		return ttype.Fields()[fieldName]
+	case *graphql.Interface:
+		return ttype.Fields()[fieldName]
+	}
+	return nil
+}
+
+func typename() *ast.Field {
+	return ast.NewField(&ast.Field{Name: name(typenameField)})
+}
+
+func name(value string) *ast.Name {
+	return ast.NewName(&ast.Name{Value: value})
+}
+
+func typeAST(ttype graphql.Type) ast.Type {
+	switch ttype := ttype.(type) {
+	case *graphql.NonNull:
+		return ast.NewNonNull(&ast.NonNull{Type: typeAST(ttype.OfType)})
+	case *graphql.List:
+		return ast.NewList(&ast.List{Type: typeAST(ttype.OfType)})
+	}
+	return ast.NewNamed(&ast.Named{Name: name(ttype.Name())})
+}
+
+// responseKey returns the alias of the field of info, or its name, which subschemas
+// return its value under.
+func responseKey(info graphql.ResolveInfo) string {
+	if key, ok := info.Path.Key.(string); ok {
+		return key
+	}
+	return info.FieldName
+}
+
+// get returns the value of key in an object returned by a subschema.
+func get(object interface{}, key string) (interface{}, bool) {
+	switch object := object.(type) {
+	case *mergedObject:
+		return get(object.source, key)
+	case *graphql.OrderedMap:
+		return object.Get(key)
+	case map[string]interface{}:
+		value, ok := object[key]
+		return value, ok
+	}
+	return nil, false
+}
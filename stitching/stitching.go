@@ -0,0 +1,614 @@
+// Package stitching serves several schemas from a single one.
+//
+// Stitch merges the types of its subschemas into a gateway schema. The fields of the
+// root types are delegated to the subschema defining them: their selection is
+// rewritten into a new document, which is executed against the subschema. Types
+// defined by several subschemas are resolved by a ConflictResolver, unless some of
+// the subschemas declare how they are merged: the fields of a merged type are joined
+// across subschemas, those missing from an object being fetched from the subschema
+// which defines them by the key of the object.
+//
+// Subscriptions aren't stitched.
+package stitching
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+// SubSchema is a schema stitched into a gateway schema.
+type SubSchema struct {
+	// Name identifies the subschema in conflicts and errors.
+	Name   string
+	Schema graphql.Schema
+	// Merge maps the names of object types to the way the subschema resolves their
+	// objects, so that their fields are joined with those of the other subschemas.
+	// Every field of a merged type must be defined by a subschema merging it.
+	Merge map[string]*MergeConfig
+}
+
+// MergeConfig tells how a subschema resolves an object of a merged type from its key.
+//
+// For example, a subschema merging User with Key "id", FieldName "userById" and
+// ArgName "id" resolves the fields it adds to the users of other subschemas with
+// "{ userById(id: $key) { ... } }", the key being the id of each user.
+type MergeConfig struct {
+	// Key is the field identifying the objects of the type, which other subschemas
+	// select along with the type.
+	Key string
+	// FieldName is the query field of the subschema returning the object of a key.
+	FieldName string
+	// ArgName is the argument of FieldName which is given the key.
+	ArgName string
+}
+
+// Conflict is a type, or a field of a root type, defined by several subschemas.
+type Conflict struct {
+	TypeName string
+	// FieldName is empty for type conflicts.
+	FieldName  string
+	SubSchemas []*SubSchema
+}
+
+// String returns the name of the conflicting type or field, as in "Query.user".
+func (c Conflict) String() string {
+	if c.FieldName == "" {
+		return c.TypeName
+	}
+	return c.TypeName + "." + c.FieldName
+}
+
+// ConflictResolver chooses the subschema whose definition of a conflicting type or
+// root field is kept, or fails stitching with an error.
+type ConflictResolver func(conflict Conflict) (*SubSchema, error)
+
+// TakeFirst keeps the definition of the first of the conflicting subschemas.
+func TakeFirst(conflict Conflict) (*SubSchema, error) {
+	return conflict.SubSchemas[0], nil
+}
+
+// TakeLast keeps the definition of the last of the conflicting subschemas.
+func TakeLast(conflict Conflict) (*SubSchema, error) {
+	return conflict.SubSchemas[len(conflict.SubSchemas)-1], nil
+}
+
+// Config configures the stitching of subschemas.
+type Config struct {
+	SubSchemas []*SubSchema
+	// OnConflict resolves conflicts, TakeFirst being used when nil.
+	OnConflict ConflictResolver
+}
+
+// Stitch builds the gateway schema of the subschemas of config.
+//
+// The root types of the gateway are named after those of the first subschema. The
+// other types keep their names, as do the custom directives of the subschemas.
+func Stitch(config Config) (graphql.Schema, error) {
+	if len(config.SubSchemas) == 0 {
+		return graphql.Schema{}, fmt.Errorf("Stitching requires at least one subschema.")
+	}
+	s := &stitcher{
+		subschemas: config.SubSchemas,
+		onConflict: config.OnConflict,
+		candidates: map[string][]*SubSchema{},
+		merging:    map[string][]*SubSchema{},
+		types:      map[string]graphql.Type{},
+	}
+	if s.onConflict == nil {
+		s.onConflict = TakeFirst
+	}
+	for _, sub := range config.SubSchemas {
+		s.collect(sub)
+	}
+	if s.err != nil {
+		return graphql.Schema{}, s.err
+	}
+
+	schemaConfig := graphql.SchemaConfig{
+		Query:    s.rootType(ast.OperationTypeQuery),
+		Mutation: s.rootType(ast.OperationTypeMutation),
+	}
+	names := make([]string, 0, len(s.candidates))
+	for name := range s.candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schemaConfig.Types = append(schemaConfig.Types, s.namedType(name))
+	}
+	schemaConfig.Directives = append(schemaConfig.Directives, graphql.SpecifiedDirectives...)
+	schemaConfig.Directives = append(schemaConfig.Directives, s.directives()...)
+	if s.err != nil {
+		return graphql.Schema{}, s.err
+	}
+
+	schema, err := graphql.NewSchema(schemaConfig)
+	// Types are built lazily, so conflicts between some of them are only found here.
+	if s.err != nil {
+		return graphql.Schema{}, s.err
+	}
+	return schema, err
+}
+
+// stitcher builds the types of a gateway schema on demand. The first problem found is
+// kept in err, since type thunks cannot return errors.
+type stitcher struct {
+	subschemas []*SubSchema
+	onConflict ConflictResolver
+	// candidates maps the names of types to the subschemas defining them, and merging
+	// the names of merged types to the subschemas merging them, in order.
+	candidates map[string][]*SubSchema
+	merging    map[string][]*SubSchema
+	types      map[string]graphql.Type
+	err        error
+}
+
+func (s *stitcher) fail(format string, a ...interface{}) {
+	if s.err == nil {
+		s.err = fmt.Errorf(format, a...)
+	}
+}
+
+// collect records the types of sub, and checks its merge configs.
+func (s *stitcher) collect(sub *SubSchema) {
+	roots := map[graphql.Type]bool{}
+	for _, root := range []*graphql.Object{sub.Schema.QueryType(), sub.Schema.MutationType(), sub.Schema.SubscriptionType()} {
+		if root != nil {
+			roots[root] = true
+		}
+	}
+	for name, ttype := range sub.Schema.TypeMap() {
+		if !roots[ttype] && !isSpecifiedType(ttype) {
+			s.candidates[name] = append(s.candidates[name], sub)
+		}
+	}
+
+	for name, merge := range sub.Merge {
+		object, ok := sub.Schema.Type(name).(*graphql.Object)
+		if !ok {
+			s.fail("Subschema %v can only merge Object types, not %v.", sub.Name, name)
+			continue
+		}
+		if _, ok := object.Fields()[merge.Key]; !ok {
+			s.fail("Subschema %v merges %v by the unknown field %v.", sub.Name, name, merge.Key)
+		}
+		var field *graphql.FieldDefinition
+		if query := sub.Schema.QueryType(); query != nil {
+			field = query.Fields()[merge.FieldName]
+		}
+		if field == nil || argument(field, merge.ArgName) == nil {
+			s.fail("Subschema %v merges %v with the unknown field %v(%v:).", sub.Name, name, merge.FieldName, merge.ArgName)
+		}
+		s.merging[name] = append(s.merging[name], sub)
+	}
+}
+
+func isSpecifiedType(ttype graphql.Type) bool {
+	switch ttype {
+	case graphql.String, graphql.Int, graphql.Float, graphql.Boolean, graphql.ID:
+		return true
+	}
+	return strings.HasPrefix(ttype.Name(), "__")
+}
+
+func argument(field *graphql.FieldDefinition, name string) *graphql.Argument {
+	for _, arg := range field.Args {
+		if arg.Name() == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+// resolve returns the subschema keeping its definition of a conflicting type or root
+// field.
+func (s *stitcher) resolve(conflict Conflict) *SubSchema {
+	if len(conflict.SubSchemas) == 1 {
+		return conflict.SubSchemas[0]
+	}
+	sub, err := s.onConflict(conflict)
+	if err != nil {
+		s.fail("%v", err)
+		return nil
+	}
+	for _, candidate := range conflict.SubSchemas {
+		if sub == candidate {
+			return sub
+		}
+	}
+	s.fail("Conflict on %v was resolved with a subschema which does not define it.", conflict)
+	return nil
+}
+
+// rootType builds the gateway root type of operation, which has the root fields of
+// every subschema.
+func (s *stitcher) rootType(operation string) *graphql.Object {
+	var name string
+	var definers []string
+	definitions := map[string][]*SubSchema{}
+	for _, sub := range s.subschemas {
+		root := rootOf(sub.Schema, operation)
+		if root == nil {
+			continue
+		}
+		if name == "" {
+			name = root.Name()
+		}
+		for fieldName := range root.Fields() {
+			if definitions[fieldName] == nil {
+				definers = append(definers, fieldName)
+			}
+			definitions[fieldName] = append(definitions[fieldName], sub)
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	sort.Strings(definers)
+	owners := map[string]*SubSchema{}
+	for _, fieldName := range definers {
+		owners[fieldName] = s.resolve(Conflict{TypeName: name, FieldName: fieldName, SubSchemas: definitions[fieldName]})
+	}
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			for fieldName, sub := range owners {
+				if sub != nil {
+					field := s.field(rootOf(sub.Schema, operation).Fields()[fieldName])
+					field.Resolve = s.delegate(sub, operation)
+					fields[fieldName] = field
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func rootOf(schema graphql.Schema, operation string) *graphql.Object {
+	switch operation {
+	case ast.OperationTypeQuery:
+		return schema.QueryType()
+	case ast.OperationTypeMutation:
+		return schema.MutationType()
+	}
+	return nil
+}
+
+// namedType returns the gateway type name, building it on first use.
+func (s *stitcher) namedType(name string) graphql.Type {
+	if ttype, ok := s.types[name]; ok {
+		return ttype
+	}
+	candidates := s.candidates[name]
+	if len(candidates) == 0 {
+		s.fail("Unknown type %v.", name)
+		return nil
+	}
+	if len(s.merging[name]) > 0 {
+		ttype := s.mergedObject(name, candidates)
+		s.types[name] = ttype
+		return ttype
+	}
+
+	sub := s.resolve(Conflict{TypeName: name, SubSchemas: candidates})
+	if sub == nil {
+		return nil
+	}
+	var ttype graphql.Type
+	switch source := sub.Schema.Type(name).(type) {
+	case *graphql.Scalar:
+		ttype = graphql.NewScalar(graphql.ScalarConfig{
+			Name:           name,
+			Description:    source.Description(),
+			SpecifiedByURL: source.SpecifiedByURL(),
+			Serialize:      func(value interface{}) interface{} { return value },
+			ParseValue:     func(value interface{}) interface{} { return value },
+			ParseLiteral:   literal,
+		})
+	case *graphql.Object:
+		ttype = graphql.NewObject(graphql.ObjectConfig{
+			Name:        name,
+			Description: source.Description(),
+			Interfaces:  s.interfacesThunk(source),
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				fields := graphql.Fields{}
+				for fieldName, field := range source.Fields() {
+					fields[fieldName] = s.field(field)
+				}
+				return fields
+			}),
+		})
+	case *graphql.Interface:
+		ttype = graphql.NewInterface(graphql.InterfaceConfig{
+			Name:        name,
+			Description: source.Description(),
+			Interfaces:  s.interfacesThunk(source),
+			Fields: graphql.FieldsThunk(func() graphql.Fields {
+				fields := graphql.Fields{}
+				for fieldName, field := range source.Fields() {
+					fields[fieldName] = s.field(field)
+				}
+				return fields
+			}),
+			ResolveType: s.resolveType,
+		})
+	case *graphql.Union:
+		ttype = graphql.NewUnion(graphql.UnionConfig{
+			Name:        name,
+			Description: source.Description(),
+			Types: graphql.UnionTypesThunk(func() []*graphql.Object {
+				var types []*graphql.Object
+				for _, member := range source.Types() {
+					if object, ok := s.namedType(member.Name()).(*graphql.Object); ok {
+						types = append(types, object)
+					} else {
+						s.fail("Union %v can only include Object types.", name)
+					}
+				}
+				return types
+			}),
+			ResolveType: s.resolveType,
+		})
+	case *graphql.Enum:
+		// Results of subschemas hold the names of enum values, which are kept as is.
+		values := graphql.EnumValueConfigMap{}
+		for _, value := range source.Values() {
+			values[value.Name] = &graphql.EnumValueConfig{
+				Value:             value.Name,
+				Description:       value.Description,
+				DeprecationReason: value.DeprecationReason,
+			}
+		}
+		ttype = graphql.NewEnum(graphql.EnumConfig{
+			Name:        name,
+			Description: source.Description(),
+			Values:      values,
+		})
+	case *graphql.InputObject:
+		ttype = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name:        name,
+			Description: source.Description(),
+			IsOneOf:     source.IsOneOf(),
+			Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+				fields := graphql.InputObjectConfigFieldMap{}
+				for fieldName, field := range source.Fields() {
+					fields[fieldName] = &graphql.InputObjectFieldConfig{
+						Type:              s.inputTypeRef(field.Type),
+						DefaultValue:      external(field.DefaultValue, field.Type),
+						Description:       field.Description(),
+						DeprecationReason: field.DeprecationReason,
+					}
+				}
+				return fields
+			}),
+		})
+	}
+	s.types[name] = ttype
+	return ttype
+}
+
+// mergedObject builds the merged type name, which has the fields of every subschema
+// defining it.
+func (s *stitcher) mergedObject(name string, candidates []*SubSchema) graphql.Type {
+	var description string
+	var sources []*graphql.Object
+	for _, sub := range candidates {
+		source, ok := sub.Schema.Type(name).(*graphql.Object)
+		if !ok {
+			s.fail("Merged type %v must be an Object type in subschema %v.", name, sub.Name)
+			return nil
+		}
+		if description == "" {
+			description = source.Description()
+		}
+		sources = append(sources, source)
+	}
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: description,
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			var interfaces []*graphql.Interface
+			seen := map[string]bool{}
+			for _, source := range sources {
+				for _, iface := range s.interfacesThunk(source)() {
+					if !seen[iface.Name()] {
+						seen[iface.Name()] = true
+						interfaces = append(interfaces, iface)
+					}
+				}
+			}
+			return interfaces
+		}),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+			for _, source := range sources {
+				for fieldName, field := range source.Fields() {
+					if _, ok := fields[fieldName]; !ok {
+						// Fields missing from an object are only fetched from subschemas
+						// merging the type, so that the others' would always be null.
+						if s.owner(name, fieldName) == nil {
+							s.fail("Field %v.%v of merged type %v must be defined by a subschema merging it.", name, fieldName, name)
+						}
+						gatewayField := s.field(field)
+						gatewayField.Resolve = s.resolveMerged(name, fieldName)
+						fields[fieldName] = gatewayField
+					}
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func (s *stitcher) interfacesThunk(source interface{ Interfaces() []*graphql.Interface }) graphql.InterfacesThunk {
+	return func() []*graphql.Interface {
+		interfaces := []*graphql.Interface{}
+		for _, iface := range source.Interfaces() {
+			if gatewayInterface, ok := s.namedType(iface.Name()).(*graphql.Interface); ok {
+				interfaces = append(interfaces, gatewayInterface)
+			} else {
+				s.fail("Type %v can only implement Interface types.", iface.Name())
+			}
+		}
+		return interfaces
+	}
+}
+
+// field returns the gateway field of a subschema field, resolved from the results of
+// the subschema.
+func (s *stitcher) field(source *graphql.FieldDefinition) *graphql.Field {
+	fieldType, ok := s.typeRef(source.Type).(graphql.Output)
+	if !ok {
+		s.fail("Field %v must be an output type.", source.Name)
+	}
+	return &graphql.Field{
+		Name:              source.Name,
+		Type:              fieldType,
+		Args:              s.arguments(source.Args),
+		Resolve:           s.resolveResult,
+		DeprecationReason: source.DeprecationReason,
+		Description:       source.Description,
+	}
+}
+
+func (s *stitcher) arguments(args []*graphql.Argument) graphql.FieldConfigArgument {
+	config := graphql.FieldConfigArgument{}
+	for _, arg := range args {
+		config[arg.Name()] = &graphql.ArgumentConfig{
+			Type:              s.inputTypeRef(arg.Type),
+			DefaultValue:      external(arg.DefaultValue, arg.Type),
+			Description:       arg.Description(),
+			DeprecationReason: arg.DeprecationReason,
+		}
+	}
+	return config
+}
+
+// directives returns the custom directives of the subschemas, the first definition of
+// a directive being kept.
+func (s *stitcher) directives() []*graphql.Directive {
+	var directives []*graphql.Directive
+	seen := map[string]bool{}
+	for _, directive := range graphql.SpecifiedDirectives {
+		seen[directive.Name] = true
+	}
+	for _, sub := range s.subschemas {
+		for _, directive := range sub.Schema.Directives() {
+			if seen[directive.Name] {
+				continue
+			}
+			seen[directive.Name] = true
+			directives = append(directives, graphql.NewDirective(graphql.DirectiveConfig{
+				Name:         directive.Name,
+				Description:  directive.Description,
+				Locations:    directive.Locations,
+				Args:         s.arguments(directive.Args),
+				IsRepeatable: directive.IsRepeatable,
+			}))
+		}
+	}
+	return directives
+}
+
+// typeRef returns the gateway type of a subschema type.
+func (s *stitcher) typeRef(ttype graphql.Type) graphql.Type {
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		ofType := s.typeRef(ttype.OfType)
+		if ofType == nil {
+			return nil
+		}
+		return graphql.NewList(ofType)
+	case *graphql.NonNull:
+		ofType := s.typeRef(ttype.OfType)
+		if ofType == nil {
+			return nil
+		}
+		return graphql.NewNonNull(ofType)
+	}
+	if isSpecifiedType(ttype) {
+		return ttype
+	}
+	return s.namedType(ttype.Name())
+}
+
+func (s *stitcher) inputTypeRef(ttype graphql.Type) graphql.Input {
+	input, ok := s.typeRef(ttype).(graphql.Input)
+	if !ok {
+		s.fail("Type %v must be an input type.", ttype)
+	}
+	return input
+}
+
+// resolveType resolves the objects of abstract types by the __typename selected from
+// subschemas.
+func (s *stitcher) resolveType(p graphql.ResolveTypeParams) *graphql.Object {
+	typename, _ := get(p.Value, typenameField)
+	name, _ := typename.(string)
+	object, _ := s.types[name].(*graphql.Object)
+	return object
+}
+
+// external returns the default value of a subschema argument or input field with the
+// names of its enum values, which the gateway enums use as values.
+func external(value interface{}, ttype graphql.Type) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.NonNull:
+		return external(value, ttype.OfType)
+	case *graphql.List:
+		values, ok := value.([]interface{})
+		if !ok {
+			return external(value, ttype.OfType)
+		}
+		externals := make([]interface{}, len(values))
+		for i, value := range values {
+			externals[i] = external(value, ttype.OfType)
+		}
+		return externals
+	case *graphql.InputObject:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		externals := make(map[string]interface{}, len(fields))
+		for name, value := range fields {
+			if field, ok := ttype.Fields()[name]; ok {
+				value = external(value, field.Type)
+			}
+			externals[name] = value
+		}
+		return externals
+	case *graphql.Enum:
+		return ttype.Serialize(value)
+	}
+	return value
+}
+
+// literal parses the literals of custom scalars, which subschemas parse again.
+func literal(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ListValue:
+		values := make([]interface{}, len(valueAST.Values))
+		for i, value := range valueAST.Values {
+			values[i] = literal(value)
+		}
+		return values
+	case *ast.ObjectValue:
+		fields := make(map[string]interface{}, len(valueAST.Fields))
+		for _, field := range valueAST.Fields {
+			fields[field.Name.Value] = literal(field.Value)
+		}
+		return fields
+	case nil:
+		return nil
+	}
+	return valueAST.GetValue()
+}
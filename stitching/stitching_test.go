@@ -0,0 +1,519 @@
+package stitching_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/stitching"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+var users = map[string]map[string]interface{}{
+	"1": {"id": "1", "name": "Ada"},
+	"2": {"id": "2", "name": "Alan"},
+}
+
+var reviews = []map[string]interface{}{
+	{"body": "Great", "author": "2", "rating": 5},
+	{"body": "Poor", "author": "1", "rating": 1},
+}
+
+// accountsSchema owns the users and their names.
+func accountsSchema(t *testing.T) graphql.Schema {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userByID := func(p graphql.ResolveParams) (interface{}, error) {
+		return users[p.Args["id"].(string)], nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"me": &graphql.Field{
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return users["1"], nil
+					},
+				},
+				"userById": &graphql.Field{
+					Type:    user,
+					Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: userByID,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// reviewsSchema owns the reviews, which users have and which are written by users.
+func reviewsSchema(t *testing.T) graphql.Schema {
+	rating := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Rating",
+		Values: graphql.EnumValueConfigMap{
+			"ONE":  &graphql.EnumValueConfig{Value: 1},
+			"FIVE": &graphql.EnumValueConfig{Value: 5},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	review := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Review",
+		Fields: graphql.Fields{
+			"body":   &graphql.Field{Type: graphql.String},
+			"rating": &graphql.Field{Type: rating},
+			"author": &graphql.Field{
+				Type: user,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"id": p.Source.(map[string]interface{})["author"]}, nil
+				},
+			},
+		},
+	})
+	user.AddFieldConfig("reviews", &graphql.Field{
+		Type: graphql.NewList(review),
+		Args: graphql.FieldConfigArgument{"minRating": &graphql.ArgumentConfig{Type: rating, DefaultValue: 1}},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var written []map[string]interface{}
+			for _, review := range reviews {
+				if review["author"] == p.Source.(map[string]interface{})["id"] && review["rating"].(int) >= p.Args["minRating"].(int) {
+					written = append(written, review)
+				}
+			}
+			return written, nil
+		},
+	})
+	comment := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Comment",
+		Fields: graphql.Fields{"text": &graphql.Field{Type: graphql.String}},
+	})
+	content := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Content",
+		Types: []*graphql.Object{review, comment},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(map[string]interface{})["text"]; ok {
+				return comment
+			}
+			return review
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "ReviewsQuery",
+			Fields: graphql.Fields{
+				"topReviews": &graphql.Field{
+					Type: graphql.NewList(review),
+					Args: graphql.FieldConfigArgument{"first": &graphql.ArgumentConfig{Type: graphql.Int}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if first, ok := p.Args["first"].(int); ok && first < len(reviews) {
+							return reviews[:first], nil
+						}
+						return reviews, nil
+					},
+				},
+				"contents": &graphql.Field{
+					Type: graphql.NewList(content),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{reviews[0], map[string]interface{}{"text": "Agreed"}}, nil
+					},
+				},
+				"_user": &graphql.Field{
+					Type: user,
+					Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"id": p.Args["id"]}, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"addReview": &graphql.Field{
+					Type: review,
+					Args: graphql.FieldConfigArgument{
+						"body":   &graphql.ArgumentConfig{Type: graphql.String},
+						"rating": &graphql.ArgumentConfig{Type: rating},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"body": p.Args["body"], "rating": p.Args["rating"]}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func stitch(t *testing.T) graphql.Schema {
+	schema, err := stitching.Stitch(stitching.Config{
+		SubSchemas: []*stitching.SubSchema{
+			{
+				Name:   "accounts",
+				Schema: accountsSchema(t),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "userById", ArgName: "id"}},
+			},
+			{
+				Name:   "reviews",
+				Schema: reviewsSchema(t),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "_user", ArgName: "id"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func do(t *testing.T, schema graphql.Schema, query string, variables map[string]interface{}) string {
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, VariableValues: variables})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestStitch_DelegatesRootFields(t *testing.T) {
+	schema := stitch(t)
+
+	query := `
+		query Home($first: Int) {
+			viewer: me { ...Name }
+			topReviews(first: $first) { body stars: rating }
+			contents {
+				__typename
+				... on Review { body }
+				... on Comment { text }
+			}
+		}
+		fragment Name on User { name }
+	`
+	expected := `{"viewer":{"name":"Ada"},"topReviews":[{"body":"Great","stars":"FIVE"}],` +
+		`"contents":[{"__typename":"Review","body":"Great"},{"__typename":"Comment","text":"Agreed"}]}`
+	if result := do(t, schema, query, map[string]interface{}{"first": 1}); result != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	mutation := `mutation { addReview(body: "Fine", rating: FIVE) { body rating } }`
+	expected = `{"addReview":{"body":"Fine","rating":"FIVE"}}`
+	if result := do(t, schema, mutation, nil); result != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if name := schema.QueryType().Name(); name != "Query" {
+		t.Fatalf("expected the root type of the first subschema, got %v", name)
+	}
+}
+
+func TestStitch_JoinsMergedTypesAcrossSubSchemas(t *testing.T) {
+	schema := stitch(t)
+
+	query := `{
+		me {
+			name
+			reviews(minRating: ONE) { body author { name } }
+		}
+		topReviews { author { id name } }
+	}`
+	expected := `{"me":{"name":"Ada","reviews":[{"body":"Poor","author":{"name":"Ada"}}]},` +
+		`"topReviews":[{"author":{"id":"2","name":"Alan"}},{"author":{"id":"1","name":"Ada"}}]}`
+	if result := do(t, schema, query, nil); result != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	fields := schema.Type("User").(*graphql.Object).Fields()
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	if len(names) != 3 || fields["name"] == nil || fields["reviews"] == nil {
+		t.Fatalf("expected the fields of both subschemas, got %v", names)
+	}
+	if arg := fields["reviews"].Args[0]; arg.DefaultValue != "ONE" {
+		t.Fatalf("expected the default value to be the name of the enum value, got %v", arg.DefaultValue)
+	}
+}
+
+func TestStitch_ResolvesConflicts(t *testing.T) {
+	version := func(version string) graphql.Schema {
+		status := graphql.NewEnum(graphql.EnumConfig{
+			Name:   "Status",
+			Values: graphql.EnumValueConfigMap{strings.ToUpper(version): &graphql.EnumValueConfig{}},
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"version": &graphql.Field{
+						Type: graphql.String,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return version, nil
+						},
+					},
+					"status" + version: &graphql.Field{Type: status},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return schema
+	}
+	subschemas := []*stitching.SubSchema{
+		{Name: "v1", Schema: version("v1")},
+		{Name: "v2", Schema: version("v2")},
+	}
+
+	var conflicts []string
+	schema, err := stitching.Stitch(stitching.Config{
+		SubSchemas: subschemas,
+		OnConflict: func(conflict stitching.Conflict) (*stitching.SubSchema, error) {
+			conflicts = append(conflicts, conflict.String())
+			return stitching.TakeLast(conflict)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"Query.version", "Status"}; !reflect.DeepEqual(conflicts, expected) {
+		t.Fatalf("Unexpected conflicts, Diff: %v", testutil.Diff(expected, conflicts))
+	}
+	if result := do(t, schema, `{ version }`, nil); result != `{"version":"v2"}` {
+		t.Fatalf("expected the last definition, got %v", result)
+	}
+	if values := schema.Type("Status").(*graphql.Enum).Values(); values[0].Name != "V2" {
+		t.Fatalf("expected the last definition, got %v", values[0].Name)
+	}
+
+	_, err = stitching.Stitch(stitching.Config{
+		SubSchemas: subschemas,
+		OnConflict: func(conflict stitching.Conflict) (*stitching.SubSchema, error) {
+			return nil, errors.New("Conflicting definitions of " + conflict.String() + ".")
+		},
+	})
+	if err == nil || err.Error() != "Conflicting definitions of Query.version." {
+		t.Fatalf("expected the conflict to fail stitching, got %v", err)
+	}
+}
+
+func TestStitch_ChecksMergeConfigs(t *testing.T) {
+	_, err := stitching.Stitch(stitching.Config{
+		SubSchemas: []*stitching.SubSchema{{
+			Name:   "accounts",
+			Schema: accountsSchema(t),
+			Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "user", ArgName: "id"}},
+		}},
+	})
+	expected := "Subschema accounts merges User with the unknown field user(id:)."
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(expected, err))
+	}
+
+	_, err = stitching.Stitch(stitching.Config{
+		SubSchemas: []*stitching.SubSchema{
+			{Name: "accounts", Schema: accountsSchema(t)},
+			{
+				Name:   "reviews",
+				Schema: reviewsSchema(t),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "_user", ArgName: "id"}},
+			},
+		},
+	})
+	expected = "Field User.name of merged type User must be defined by a subschema merging it."
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(expected, err))
+	}
+}
+
+type codedError struct {
+	message string
+	code    string
+}
+
+func (e codedError) Error() string {
+	return e.message
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// profilesSchema owns the emails and bios of users, failing on the email of Alan.
+func profilesSchema(t *testing.T) graphql.Schema {
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"email": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if id := p.Source.(map[string]interface{})["id"]; id != "1" {
+						return nil, codedError{"Email of " + id.(string) + " is private.", "FORBIDDEN"}
+					}
+					return "ada@example.com", nil
+				},
+			},
+			"bio": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "Bio of " + p.Source.(map[string]interface{})["id"].(string), nil
+				},
+			},
+		},
+	})
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"label": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if label := p.Source.(string); label != "" {
+						return label, nil
+					}
+					return nil, errors.New("Item has no label.")
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(item),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{"first", "", "third"}, nil
+					},
+				},
+				"count": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, codedError{"Count is rate limited.", "LIMITED"}
+					},
+				},
+				"_profile": &graphql.Field{
+					Type: user,
+					Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"id": p.Args["id"]}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// countCalls counts the calls of the root query fields of schema.
+func countCalls(t *testing.T, schema graphql.Schema, calls map[string]int) graphql.Schema {
+	counted, err := graphql.TransformSchema(schema, graphql.WrapResolvers(
+		func(parentType graphql.Type, field *graphql.FieldDefinition, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+			if parentType != schema.QueryType() {
+				return resolve
+			}
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				calls[field.Name]++
+				return resolve(p)
+			}
+		},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return counted
+}
+
+func TestStitch_ForwardsErrorsWithPartialData(t *testing.T) {
+	calls := map[string]int{}
+	schema, err := stitching.Stitch(stitching.Config{
+		SubSchemas: []*stitching.SubSchema{
+			{
+				Name:   "reviews",
+				Schema: reviewsSchema(t),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "_user", ArgName: "id"}},
+			},
+			{
+				Name:   "accounts",
+				Schema: countCalls(t, accountsSchema(t), calls),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "userById", ArgName: "id"}},
+			},
+			{
+				Name:   "profiles",
+				Schema: countCalls(t, profilesSchema(t), calls),
+				Merge:  map[string]*stitching.MergeConfig{"User": {Key: "id", FieldName: "_profile", ArgName: "id"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{
+		items { label }
+		count
+		topReviews { author { name email bio } }
+	}`})
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"items":[{"label":"first"},null,{"label":"third"}],"count":null,"topReviews":[` +
+		`{"author":{"name":"Alan","email":null,"bio":"Bio of 2"}},` +
+		`{"author":{"name":"Ada","email":"ada@example.com","bio":"Bio of 1"}}]}`
+	if string(b) != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, string(b)))
+	}
+
+	type reportedError struct {
+		Message    string
+		Path       []interface{}
+		Extensions map[string]interface{}
+	}
+	var errs []reportedError
+	for _, err := range result.Errors {
+		if len(err.Locations) == 0 {
+			t.Fatalf("expected the error to be located in the gateway document, got %v", err)
+		}
+		errs = append(errs, reportedError{err.Message, err.Path, err.Extensions})
+	}
+	expectedErrs := []reportedError{
+		{"Count is rate limited.", []interface{}{"count"}, map[string]interface{}{"code": "LIMITED"}},
+		{"Email of 2 is private.", []interface{}{"topReviews", 0, "author", "email"}, map[string]interface{}{"code": "FORBIDDEN"}},
+		// Failed list items are completed last.
+		{"Item has no label.", []interface{}{"items", 1, "label"}, nil},
+	}
+	if !reflect.DeepEqual(errs, expectedErrs) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrs, errs))
+	}
+
+	// The fields of each author are fetched from each subschema at once.
+	if expected := map[string]int{"items": 1, "count": 1, "userById": 2, "_profile": 2}; !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("Unexpected calls, Diff: %v", testutil.Diff(expected, calls))
+	}
+}
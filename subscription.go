@@ -34,17 +34,17 @@ func Subscribe(p Params) chan *Result {
 	// TODO run extensions hooks
 
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	AST, err := parser.Parse(parser.ParseParams{Source: source, Options: p.ParseOptions})
 	if err != nil {
 
 		// merge the errors from extensions and the original error from parser
 		return sendOneResultAndClose(&Result{
-			Errors: gqlerrors.FormatErrors(err),
+			Errors: formatParseErrors(err),
 		})
 	}
 
 	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	validationResult := ValidateDocumentWithContext(p.Context, &p.Schema, AST, nil)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
@@ -136,17 +136,13 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			SelectionSet: exeContext.Operation.GetSelectionSet(),
 		})
 
-		responseNames := []string{}
-		for name := range fields {
-			responseNames = append(responseNames, name)
-		}
-		responseName := responseNames[0]
-		fieldNodes := fields[responseName]
+		responseName := fields.ordered[0].responseName
+		fieldNodes := fields.ordered[0].fieldASTs
 		fieldNode := fieldNodes[0]
 		fieldName := fieldNode.Name.Value
 		fieldDef := getFieldDef(p.Schema, operationType, fieldName)
 
-		if fieldDef == nil {
+		if fieldDef == nil || !p.Schema.isFieldVisible(p.Context, operationType, fieldDef) {
 			resultChannel <- &Result{
 				Errors: gqlerrors.FormatErrors(fmt.Errorf("the subscription field %q is not defined", fieldName)),
 			}
@@ -166,7 +162,8 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			Key: responseName,
 		}
 
-		args := getArgumentValues(fieldDef.Args, fieldNode.Arguments, exeContext.VariableValues)
+		args := getArgumentValues(p.Schema.visibleArguments(p.Context, operationType, fieldDef.Args),
+			fieldNode.Arguments, exeContext.VariableValues)
 		info := ResolveInfo{
 			FieldName:      fieldName,
 			FieldASTs:      fieldNodes,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tailor-inc/graphql"
 	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/language/ast"
 	"github.com/tailor-inc/graphql/language/location"
 	"github.com/tailor-inc/graphql/language/parser"
 	"github.com/tailor-inc/graphql/language/source"
@@ -28,7 +29,8 @@ func init() {
 		},
 	})
 	var petInterface = graphql.NewInterface(graphql.InterfaceConfig{
-		Name: "Pet",
+		Name:       "Pet",
+		Interfaces: []*graphql.Interface{beingInterface},
 		Fields: graphql.Fields{
 			"name": &graphql.Field{
 				Type: graphql.String,
@@ -41,7 +43,8 @@ func init() {
 		},
 	})
 	var canineInterface = graphql.NewInterface(graphql.InterfaceConfig{
-		Name: "Canine",
+		Name:       "Canine",
+		Interfaces: []*graphql.Interface{beingInterface, petInterface},
 		Fields: graphql.Fields{
 			"name": &graphql.Field{
 				Type: graphql.String,
@@ -294,6 +297,18 @@ func init() {
 			},
 		},
 	})
+	var oneOfInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "OneOfInput",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"stringField": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"intField": &graphql.InputObjectFieldConfig{
+				Type: graphql.Int,
+			},
+		},
+	})
 	var complicatedArgs = graphql.NewObject(graphql.ObjectConfig{
 		Name: "ComplicatedArgs",
 		// TODO List
@@ -383,6 +398,14 @@ func init() {
 					},
 				},
 			},
+			"oneOfArgField": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"oneOfArg": &graphql.ArgumentConfig{
+						Type: oneOfInputObject,
+					},
+				},
+			},
 			"multipleOpts": &graphql.Field{
 				Type: graphql.String,
 				Args: graphql.FieldConfigArgument{
@@ -531,6 +554,17 @@ func init() {
 				Name:      "onInputFieldDefinition",
 				Locations: []string{graphql.DirectiveLocationInputFieldDefinition},
 			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:         "repeatable",
+				IsRepeatable: true,
+				Locations: []string{
+					graphql.DirectiveLocationQuery,
+					graphql.DirectiveLocationField,
+					graphql.DirectiveLocationFragmentSpread,
+					graphql.DirectiveLocationInlineFragment,
+					graphql.DirectiveLocationFragmentDefinition,
+				},
+			}),
 		},
 		Types: []graphql.Type{
 			catType,
@@ -553,14 +587,15 @@ func expectValidRule(t *testing.T, schema *graphql.Schema, rules []graphql.Valid
 	if err != nil {
 		t.Fatal(err)
 	}
-	result := graphql.ValidateDocument(schema, AST, rules)
+	expectValidResult(t, graphql.ValidateDocument(schema, AST, rules))
+}
+func expectValidResult(t *testing.T, result graphql.ValidationResult) {
 	if len(result.Errors) > 0 {
 		t.Fatalf("Should validate, got %v", result.Errors)
 	}
 	if result.IsValid != true {
 		t.Fatalf("IsValid should be true, got %v", result.IsValid)
 	}
-
 }
 func expectInvalidRule(t *testing.T, schema *graphql.Schema, rules []graphql.ValidationRuleFn, queryString string, expectedErrors []gqlerrors.FormattedError) {
 	source := source.NewSource(&source.Source{
@@ -570,7 +605,9 @@ func expectInvalidRule(t *testing.T, schema *graphql.Schema, rules []graphql.Val
 	if err != nil {
 		t.Fatal(err)
 	}
-	result := graphql.ValidateDocument(schema, AST, rules)
+	expectInvalidResult(t, graphql.ValidateDocument(schema, AST, rules), expectedErrors)
+}
+func expectInvalidResult(t *testing.T, result graphql.ValidationResult, expectedErrors []gqlerrors.FormattedError) {
 	if len(result.Errors) != len(expectedErrors) {
 		t.Fatalf("Should have %v errors, got %v", len(expectedErrors), len(result.Errors))
 	}
@@ -603,6 +640,27 @@ func ExpectFailsRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphq
 func ExpectPassesRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, queryString string) {
 	expectValidRule(t, schema, []graphql.ValidationRuleFn{rule}, queryString)
 }
+func parseSDL(t *testing.T, sdl string) *ast.Document {
+	AST, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(sdl),
+	})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return AST
+}
+func ExpectPassesSDLRule(t *testing.T, rule graphql.ValidationRuleFn, sdl string) {
+	expectValidResult(t, graphql.ValidateSDL(parseSDL(t, sdl), nil, []graphql.ValidationRuleFn{rule}))
+}
+func ExpectFailsSDLRule(t *testing.T, rule graphql.ValidationRuleFn, sdl string, expectedErrors []gqlerrors.FormattedError) {
+	expectInvalidResult(t, graphql.ValidateSDL(parseSDL(t, sdl), nil, []graphql.ValidationRuleFn{rule}), expectedErrors)
+}
+func ExpectPassesSDLRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, sdl string) {
+	expectValidResult(t, graphql.ValidateSDL(parseSDL(t, sdl), schema, []graphql.ValidationRuleFn{rule}))
+}
+func ExpectFailsSDLRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, sdl string, expectedErrors []gqlerrors.FormattedError) {
+	expectInvalidResult(t, graphql.ValidateSDL(parseSDL(t, sdl), schema, []graphql.ValidationRuleFn{rule}), expectedErrors)
+}
 func RuleError(message string, locs ...int) gqlerrors.FormattedError {
 	locations := []location.SourceLocation{}
 	for i := 0; i < len(locs); i += 2 {
@@ -497,8 +497,21 @@ func EqualFormattedErrors(expected, actual []gqlerrors.FormattedError) bool {
 }
 
 func EqualResults(expected, result *graphql.Result) bool {
-	if !reflect.DeepEqual(expected.Data, result.Data) {
+	if !reflect.DeepEqual(expected.Data, PlainResult(result).Data) {
 		return false
 	}
 	return EqualFormattedErrors(expected.Errors, result.Errors)
 }
+
+// PlainResult returns a copy of result with the ordered objects of its data as maps, to
+// compare it with results written as map literals.
+func PlainResult(result *graphql.Result) *graphql.Result {
+	if result == nil {
+		return nil
+	}
+	plain := *result
+	if _, ok := result.Data.(*graphql.OrderedMap); ok {
+		plain.Data = result.DataMap()
+	}
+	return &plain
+}
@@ -0,0 +1,436 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tailor-inc/graphql/language/ast"
+)
+
+// SchemaTransform derives a new schema from a schema.
+//
+// The transforms of this package build new types, leaving those of the given schema
+// unchanged, and validate the schema they return. Renamed types are reported under
+// their new name by __typename, and the objects returned by the type resolvers of the
+// given schema are translated to their new types.
+type SchemaTransform func(schema Schema) (Schema, error)
+
+// TransformSchema applies transforms to schema in order.
+func TransformSchema(schema Schema, transforms ...SchemaTransform) (Schema, error) {
+	for _, transform := range transforms {
+		var err error
+		if schema, err = transform(schema); err != nil {
+			return Schema{}, err
+		}
+	}
+	return schema, nil
+}
+
+// FilterTypes keeps the named types for which keep returns true, root types being
+// always kept. Fields, arguments, input fields, union members and interfaces of the
+// removed types are removed as well, as are the fields with a required argument of a
+// removed type.
+func FilterTypes(keep func(ttype Type) bool) SchemaTransform {
+	return func(schema Schema) (Schema, error) {
+		return rebuildSchema(&schemaRebuilder{schema: schema, keepType: keep})
+	}
+}
+
+// FilterFields keeps the fields of object and interface types, root types included, for
+// which keep returns true.
+func FilterFields(keep func(parentType Type, field *FieldDefinition) bool) SchemaTransform {
+	return func(schema Schema) (Schema, error) {
+		return rebuildSchema(&schemaRebuilder{schema: schema, keepField: keep})
+	}
+}
+
+// RenameTypes renames the named types to the names rename returns for them, as in
+// adding a prefix. Root types, introspection types and specified scalars keep their
+// names.
+func RenameTypes(rename func(name string) string) SchemaTransform {
+	return func(schema Schema) (Schema, error) {
+		return rebuildSchema(&schemaRebuilder{schema: schema, renameType: rename})
+	}
+}
+
+// RenameRootFields renames the fields of the root types to the names rename returns
+// for them, operation being one of ast.OperationTypeQuery, ast.OperationTypeMutation
+// and ast.OperationTypeSubscription.
+func RenameRootFields(rename func(operation, name string) string) SchemaTransform {
+	return func(schema Schema) (Schema, error) {
+		return rebuildSchema(&schemaRebuilder{schema: schema, renameRootField: rename})
+	}
+}
+
+// WrapResolvers replaces the resolvers of the fields of object types with those wrap
+// returns for them. resolve is the resolver of the field, DefaultResolveFn when it has
+// none.
+func WrapResolvers(wrap func(parentType Type, field *FieldDefinition, resolve FieldResolveFn) FieldResolveFn) SchemaTransform {
+	return func(schema Schema) (Schema, error) {
+		return rebuildSchema(&schemaRebuilder{schema: schema, wrapResolver: wrap})
+	}
+}
+
+// schemaRebuilder builds the types of a transformed schema on demand. The functions
+// transforming the schema are given its members, never those being built. The first
+// problem found is kept in err, since type thunks cannot return errors.
+type schemaRebuilder struct {
+	schema          Schema
+	keepType        func(ttype Type) bool
+	keepField       func(parentType Type, field *FieldDefinition) bool
+	renameType      func(name string) string
+	renameRootField func(operation, name string) string
+	wrapResolver    func(parentType Type, field *FieldDefinition, resolve FieldResolveFn) FieldResolveFn
+	// wrapResolveType and wrapIsTypeOf replace the type resolvers of abstract types and
+	// the IsTypeOf functions of objects, either of which may be nil, and wrapSerialize
+	// the serialization of custom scalars.
+	wrapResolveType func(abstractType Abstract, resolve ResolveTypeFn) ResolveTypeFn
+	wrapIsTypeOf    func(object *Object, isTypeOf IsTypeOfFn) IsTypeOfFn
+	wrapSerialize   func(scalar *Scalar, serialize SerializeFn) SerializeFn
+
+	// types maps the names of the types of schema to the types built for them, nil for
+	// removed types, and names the new names to the original ones.
+	types map[string]Type
+	names map[string]string
+	err   error
+}
+
+func (r *schemaRebuilder) fail(format string, a ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf(format, a...)
+	}
+}
+
+func rebuildSchema(r *schemaRebuilder) (Schema, error) {
+	r.types = map[string]Type{}
+	r.names = map[string]string{}
+	config := SchemaConfig{
+		Query:        r.rootType(r.schema.QueryType(), ast.OperationTypeQuery),
+		Mutation:     r.rootType(r.schema.MutationType(), ast.OperationTypeMutation),
+		Subscription: r.rootType(r.schema.SubscriptionType(), ast.OperationTypeSubscription),
+		Extensions:   r.schema.extensions,
+		Visibility:   r.schema.visibility,
+	}
+	names := make([]string, 0, len(r.schema.TypeMap()))
+	for name := range r.schema.TypeMap() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ttype := r.schema.TypeMap()[name]
+		if isSpecifiedType(ttype) || r.isRootType(ttype) {
+			continue
+		}
+		if ttype := r.namedType(ttype); ttype != nil {
+			config.Types = append(config.Types, ttype)
+		}
+	}
+	for _, directive := range r.schema.Directives() {
+		config.Directives = append(config.Directives, r.directive(directive))
+	}
+	if r.err != nil {
+		return Schema{}, r.err
+	}
+
+	schema, err := NewSchema(config)
+	// Types are built lazily, so conflicting names are only found here.
+	if r.err != nil {
+		return Schema{}, r.err
+	}
+	return schema, err
+}
+
+func (r *schemaRebuilder) isRootType(ttype Type) bool {
+	return ttype != nil && (ttype == r.schema.QueryType() || ttype == r.schema.MutationType() ||
+		ttype == r.schema.SubscriptionType())
+}
+
+func (r *schemaRebuilder) rootType(root *Object, operation string) *Object {
+	if root == nil {
+		return nil
+	}
+	r.names[root.Name()] = root.Name()
+	return r.object(root, root.Name(), operation)
+}
+
+// typeRef returns the type built for ttype, a type of the schema, or nil when it is
+// removed.
+func (r *schemaRebuilder) typeRef(ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		if ofType := r.typeRef(ttype.OfType); ofType != nil {
+			return NewList(ofType)
+		}
+		return nil
+	case *NonNull:
+		if ofType := r.typeRef(ttype.OfType); ofType != nil {
+			return NewNonNull(ofType)
+		}
+		return nil
+	}
+	if isSpecifiedType(ttype) {
+		return ttype
+	}
+	return r.namedType(ttype)
+}
+
+func (r *schemaRebuilder) namedType(source Type) Type {
+	if ttype, ok := r.types[source.Name()]; ok {
+		return ttype
+	}
+	if r.keepType != nil && !r.keepType(source) {
+		r.types[source.Name()] = nil
+		return nil
+	}
+	name := source.Name()
+	if r.renameType != nil {
+		name = r.renameType(name)
+	}
+	if original, ok := r.names[name]; ok {
+		r.fail("Types %v and %v are both named %v.", original, source.Name(), name)
+		return nil
+	}
+	r.names[name] = source.Name()
+
+	var ttype Type
+	switch source := source.(type) {
+	case *Scalar:
+		serialize := SerializeFn(source.Serialize)
+		if r.wrapSerialize != nil {
+			serialize = r.wrapSerialize(source, serialize)
+		}
+		ttype = NewScalar(ScalarConfig{
+			Name:           name,
+			Description:    source.Description(),
+			SpecifiedByURL: source.SpecifiedByURL(),
+			Serialize:      serialize,
+			ParseValue:     source.ParseValue,
+			ParseLiteral:   source.ParseLiteral,
+			Directives:     source.Directives(),
+		})
+	case *Object:
+		ttype = r.object(source, name, "")
+	case *Interface:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: source.Description(),
+			Interfaces:  r.interfacesThunk(source.Interfaces),
+			Fields:      r.fieldsThunk(source, ""),
+			ResolveType: r.resolveType(source, source.ResolveType),
+			Directives:  source.Directives(),
+		})
+	case *Union:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: source.Description(),
+			Types: UnionTypesThunk(func() []*Object {
+				var types []*Object
+				for _, member := range source.Types() {
+					if object, ok := r.typeRef(member).(*Object); ok {
+						types = append(types, object)
+					}
+				}
+				return types
+			}),
+			ResolveType: r.resolveType(source, source.ResolveType),
+			Directives:  source.Directives(),
+		})
+	case *Enum:
+		values := EnumValueConfigMap{}
+		for _, value := range source.Values() {
+			values[value.Name] = &EnumValueConfig{
+				Value:             value.Value,
+				Description:       value.Description,
+				DeprecationReason: value.DeprecationReason,
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: source.Description(),
+			Values:      values,
+			Directives:  source.Directives(),
+		})
+	case *InputObject:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: source.Description(),
+			IsOneOf:     source.IsOneOf(),
+			Directives:  source.Directives(),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, field := range source.Fields() {
+					if fieldType, ok := r.typeRef(field.Type).(Input); ok {
+						fields[fieldName] = &InputObjectFieldConfig{
+							Type:              fieldType,
+							DefaultValue:      field.DefaultValue,
+							Description:       field.Description(),
+							DeprecationReason: field.DeprecationReason,
+						}
+					}
+				}
+				return fields
+			}),
+		})
+	default:
+		r.fail("Unknown type %v.", source)
+		return nil
+	}
+	r.types[source.Name()] = ttype
+	return ttype
+}
+
+// object builds the object type of source named name, operation being set for root
+// types.
+func (r *schemaRebuilder) object(source *Object, name, operation string) *Object {
+	sourceIsTypeOf := source.IsTypeOf
+	if r.wrapIsTypeOf != nil {
+		sourceIsTypeOf = r.wrapIsTypeOf(source, sourceIsTypeOf)
+	}
+	var isTypeOf IsTypeOfFn
+	if sourceIsTypeOf != nil {
+		isTypeOf = func(p IsTypeOfParams) bool {
+			p.Info.Schema = r.schema
+			return sourceIsTypeOf(p)
+		}
+	}
+	object := NewObject(ObjectConfig{
+		Name:        name,
+		Description: source.Description(),
+		Interfaces:  r.interfacesThunk(source.Interfaces),
+		Fields:      r.fieldsThunk(source, operation),
+		IsTypeOf:    isTypeOf,
+		Directives:  source.Directives(),
+	})
+	r.types[source.Name()] = object
+	return object
+}
+
+func (r *schemaRebuilder) interfacesThunk(interfaces func() []*Interface) InterfacesThunk {
+	return func() []*Interface {
+		kept := []*Interface{}
+		for _, iface := range interfaces() {
+			if iface, ok := r.typeRef(iface).(*Interface); ok {
+				kept = append(kept, iface)
+			}
+		}
+		return kept
+	}
+}
+
+func (r *schemaRebuilder) fieldsThunk(source interface {
+	Type
+	Fields() FieldDefinitionMap
+}, operation string) FieldsThunk {
+	return func() Fields {
+		fields := Fields{}
+		for fieldName, field := range source.Fields() {
+			if r.keepField != nil && !r.keepField(source, field) {
+				continue
+			}
+			fieldType, ok := r.typeRef(field.Type).(Output)
+			if !ok {
+				continue
+			}
+			args, ok := r.arguments(field.Args)
+			if !ok {
+				continue
+			}
+			resolve := field.Resolve
+			if operation != "" && r.renameRootField != nil {
+				if name := r.renameRootField(operation, fieldName); name != fieldName {
+					if resolve == nil {
+						resolve = resolveFieldAs(fieldName)
+					}
+					fieldName = name
+				}
+			}
+			if _, ok := source.(*Object); ok && r.wrapResolver != nil {
+				if resolve == nil {
+					resolve = DefaultResolveFn
+				}
+				resolve = r.wrapResolver(source, field, resolve)
+			}
+			if _, ok := fields[fieldName]; ok {
+				r.fail("Fields of %v are both named %v.", source.Name(), fieldName)
+			}
+			fields[fieldName] = &Field{
+				Name:              fieldName,
+				Type:              fieldType,
+				Args:              args,
+				Directives:        field.Directives,
+				Resolve:           resolve,
+				Subscribe:         field.Subscribe,
+				DeprecationReason: field.DeprecationReason,
+				Description:       field.Description,
+			}
+		}
+		return fields
+	}
+}
+
+// resolveFieldAs resolves a renamed root field without resolver from the root value as
+// the field name.
+func resolveFieldAs(name string) FieldResolveFn {
+	return func(p ResolveParams) (interface{}, error) {
+		p.Info.FieldName = name
+		return DefaultResolveFn(p)
+	}
+}
+
+// arguments returns the arguments of a field, leaving out those of removed types, or
+// false when one of them is required.
+func (r *schemaRebuilder) arguments(args []*Argument) (FieldConfigArgument, bool) {
+	config := FieldConfigArgument{}
+	for _, arg := range args {
+		argType, ok := r.typeRef(arg.Type).(Input)
+		if !ok {
+			if _, required := arg.Type.(*NonNull); required && arg.DefaultValue == nil {
+				return nil, false
+			}
+			continue
+		}
+		config[arg.Name()] = &ArgumentConfig{
+			Type:              argType,
+			DefaultValue:      arg.DefaultValue,
+			Description:       arg.Description(),
+			DeprecationReason: arg.DeprecationReason,
+		}
+	}
+	return config, true
+}
+
+// resolveType translates the objects resolve returns for the abstract types of the
+// schema to the types built for them.
+func (r *schemaRebuilder) resolveType(abstractType Abstract, resolve ResolveTypeFn) ResolveTypeFn {
+	if r.wrapResolveType != nil {
+		resolve = r.wrapResolveType(abstractType, resolve)
+	}
+	if resolve == nil {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		p.Info.Schema = r.schema
+		object := resolve(p)
+		if object == nil {
+			return nil
+		}
+		ttype, _ := r.types[object.Name()].(*Object)
+		return ttype
+	}
+}
+
+func (r *schemaRebuilder) directive(directive *Directive) *Directive {
+	for _, specified := range SpecifiedDirectives {
+		if directive == specified {
+			return directive
+		}
+	}
+	args, _ := r.arguments(directive.Args)
+	return NewDirective(DirectiveConfig{
+		Name:         directive.Name,
+		Description:  directive.Description,
+		Locations:    directive.Locations,
+		Args:         args,
+		IsRepeatable: directive.IsRepeatable,
+	})
+}
@@ -0,0 +1,230 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/language/ast"
+	"github.com/tailor-inc/graphql/testutil"
+)
+
+func internalSchema(t *testing.T) graphql.Schema {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:   "Node",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)}},
+	})
+	role := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Role",
+		Values: graphql.EnumValueConfigMap{
+			"ADMIN": &graphql.EnumValueConfig{Value: 1},
+			"USER":  &graphql.EnumValueConfig{Value: 2},
+		},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+			"role":  &graphql.Field{Type: role},
+		},
+	})
+	auditLog := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "AuditLog",
+		Interfaces: []*graphql.Interface{node},
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"entry": &graphql.Field{Type: graphql.String},
+		},
+	})
+	node.ResolveType = func(p graphql.ResolveTypeParams) *graphql.Object {
+		if _, ok := p.Value.(map[string]interface{})["entry"]; ok {
+			return auditLog
+		}
+		return user
+	}
+	users := []interface{}{
+		map[string]interface{}{"id": "1", "name": "Ada", "email": "ada@example.com", "role": 1},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": &graphql.Field{
+					Type: node,
+					Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return users[0], nil
+					},
+				},
+				"users": &graphql.Field{
+					Type: graphql.NewList(user),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return users, nil
+					},
+				},
+				"audit": &graphql.Field{Type: graphql.NewList(auditLog)},
+				"stats": &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Types: []graphql.Type{auditLog},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func doJSON(t *testing.T, schema graphql.Schema, query string) string {
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestTransformSchema_FiltersRenamesAndWraps(t *testing.T) {
+	internal := internalSchema(t)
+	public, err := graphql.TransformSchema(internal,
+		graphql.FilterTypes(func(ttype graphql.Type) bool {
+			return ttype.Name() != "AuditLog"
+		}),
+		graphql.FilterFields(func(parentType graphql.Type, field *graphql.FieldDefinition) bool {
+			return field.Name != "email" && field.Name != "stats"
+		}),
+		graphql.RenameTypes(func(name string) string {
+			return "Public" + name
+		}),
+		graphql.RenameRootFields(func(operation, name string) string {
+			if operation == ast.OperationTypeQuery && name == "users" {
+				return "allUsers"
+			}
+			return name
+		}),
+		graphql.WrapResolvers(func(parentType graphql.Type, field *graphql.FieldDefinition, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+			if parentType.Name() != "PublicUser" || field.Name != "name" {
+				return resolve
+			}
+			return func(p graphql.ResolveParams) (interface{}, error) {
+				name, err := resolve(p)
+				return strings.ToUpper(name.(string)), err
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := `{
+		allUsers { __typename name role }
+		node(id: "1") { __typename ... on PublicUser { id } }
+	}`
+	expected := `{"allUsers":[{"__typename":"PublicUser","name":"ADA","role":"ADMIN"}],` +
+		`"node":{"__typename":"PublicUser","id":"1"}}`
+	if result := doJSON(t, public, query); result != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	var types []string
+	for name := range public.TypeMap() {
+		if !strings.HasPrefix(name, "__") {
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	expectedTypes := []string{"Boolean", "ID", "PublicNode", "PublicRole", "PublicUser", "Query", "String"}
+	if !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("Unexpected types, Diff: %v", testutil.Diff(expectedTypes, types))
+	}
+	if fields := public.QueryType().Fields(); len(fields) != 2 || fields["audit"] != nil {
+		t.Fatalf("expected the fields of removed types to be removed, got %v", fields)
+	}
+	if fields := public.Type("PublicUser").(*graphql.Object).Fields(); fields["email"] != nil {
+		t.Fatalf("expected email to be removed")
+	}
+
+	// The internal schema is unchanged.
+	expected = `{"users":[{"__typename":"User","name":"Ada","email":"ada@example.com"}]}`
+	if result := doJSON(t, internal, `{ users { __typename name email } }`); result != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRenameTypes_FailsOnConflictingNames(t *testing.T) {
+	_, err := graphql.TransformSchema(internalSchema(t), graphql.RenameTypes(func(name string) string {
+		if name == "Role" {
+			return "User"
+		}
+		return name
+	}))
+	if err == nil || err.Error() != "Types Role and User are both named User." {
+		t.Fatalf("expected the conflicting names to be reported, got %v", err)
+	}
+}
+
+func TestTransformSchema_KeepsAppliedDirectives(t *testing.T) {
+	schema, err := graphql.ParseSDL(`
+		directive @tag(name: String!) on SCALAR | ENUM | INPUT_OBJECT
+
+		scalar Date @specifiedBy(url: "https://example.com/date") @tag(name: "date")
+
+		enum Role @tag(name: "role") {
+		  ADMIN
+		  USER @deprecated(reason: "Use ADMIN.")
+		}
+
+		input Filter @oneOf @tag(name: "filter") {
+		  id: ID
+		  name: String
+		}
+
+		type Query {
+		  users(filter: Filter, role: Role): [Date]
+		}
+	`, func(typeName, fieldName string) graphql.FieldResolveFn { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	renamed, err := graphql.TransformSchema(*schema,
+		graphql.FilterTypes(func(ttype graphql.Type) bool { return true }),
+		graphql.RenameTypes(func(name string) string { return "Public" + name }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := renamed.Type("PublicFilter").(*graphql.InputObject)
+	if !filter.IsOneOf() || len(filter.Directives()) != 1 || filter.Directives()[0].Directive.Name != "tag" {
+		t.Fatalf("expected the directives of Filter to be kept, got %v %v", filter.IsOneOf(), filter.Directives())
+	}
+	if date := renamed.Type("PublicDate").(*graphql.Scalar); date.SpecifiedByURL() == "" || len(date.Directives()) != 1 {
+		t.Fatalf("expected the directives of Date to be kept, got %v %v", date.SpecifiedByURL(), date.Directives())
+	}
+
+	// Renaming the types back gives the same schema.
+	restored, err := graphql.TransformSchema(renamed, graphql.RenameTypes(func(name string) string {
+		return strings.TrimPrefix(name, "Public")
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, result := graphql.IntrospectionFromSchema(*schema), graphql.IntrospectionFromSchema(restored)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected introspection, Diff: %v", testutil.Diff(expected, result))
+	}
+	if expected, result := graphql.PrintSchema(*schema), graphql.PrintSchema(restored); expected != result {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, result))
+	}
+	if printed := graphql.PrintSchema(restored); !strings.Contains(printed, `enum Role @tag(name: "role")`) ||
+		!strings.Contains(printed, `input Filter @oneOf @tag(name: "filter")`) {
+		t.Fatalf("expected the applied directives to be printed, got %v", printed)
+	}
+}
@@ -1,6 +1,8 @@
 package graphql
 
 import (
+	"context"
+
 	"github.com/tailor-inc/graphql/language/ast"
 	"github.com/tailor-inc/graphql/language/kinds"
 )
@@ -22,6 +24,7 @@ type TypeInfo struct {
 	directive       *Directive
 	argument        *Argument
 	getFieldDef     fieldDefFn
+	ctx             context.Context
 }
 
 type TypeInfoConfig struct {
@@ -31,6 +34,10 @@ type TypeInfoConfig struct {
 	// to support non-spec-compliant codebases. You should never need to use it.
 	// It may disappear in the future.
 	FieldDefFn fieldDefFn
+
+	// Context is the context of the request, which decides the visibility of the
+	// members of the schema.
+	Context context.Context
 }
 
 func NewTypeInfo(opts *TypeInfoConfig) *TypeInfo {
@@ -38,9 +45,14 @@ func NewTypeInfo(opts *TypeInfoConfig) *TypeInfo {
 	if getFieldDef == nil {
 		getFieldDef = DefaultTypeInfoFieldDef
 	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return &TypeInfo{
 		schema:      opts.Schema,
 		getFieldDef: getFieldDef,
+		ctx:         ctx,
 	}
 }
 
@@ -79,6 +91,12 @@ func (ti *TypeInfo) Argument() *Argument {
 	return ti.argument
 }
 
+// Context returns the context of the request, which decides the visibility of the
+// members of the schema.
+func (ti *TypeInfo) Context() context.Context {
+	return ti.ctx
+}
+
 func (ti *TypeInfo) Enter(node ast.Node) {
 
 	schema := ti.schema
@@ -96,6 +114,9 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		var fieldDef *FieldDefinition
 		if parentType != nil {
 			fieldDef = ti.getFieldDef(schema, parentType.(Type), node)
+			if fieldDef != nil && !schema.isFieldVisible(ti.ctx, parentType.(Type), fieldDef) {
+				fieldDef = nil
+			}
 		}
 		ti.fieldDefStack = append(ti.fieldDefStack, fieldDef)
 		if fieldDef != nil {
@@ -122,6 +143,9 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		typeConditionAST := node.TypeCondition
 		if typeConditionAST != nil {
 			ttype, _ = typeFromAST(*schema, node.TypeCondition)
+			if ttype != nil && !schema.IsVisible(ti.ctx, ttype) {
+				ttype = nil
+			}
 			ti.typeStack = append(ti.typeStack, ttype)
 		} else {
 			ti.typeStack = append(ti.typeStack, ti.Type())
@@ -130,12 +154,18 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		typeConditionAST := node.TypeCondition
 		if typeConditionAST != nil {
 			ttype, _ = typeFromAST(*schema, typeConditionAST)
+			if ttype != nil && !schema.IsVisible(ti.ctx, ttype) {
+				ttype = nil
+			}
 			ti.typeStack = append(ti.typeStack, ttype)
 		} else {
 			ti.typeStack = append(ti.typeStack, ti.Type())
 		}
 	case *ast.VariableDefinition:
 		ttype, _ = typeFromAST(*schema, node.Type)
+		if ttype != nil && !schema.IsVisible(ti.ctx, ttype) {
+			ttype = nil
+		}
 		ti.inputTypeStack = append(ti.inputTypeStack, ttype)
 	case *ast.Argument:
 		nameVal := ""
@@ -147,13 +177,13 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		directive := ti.Directive()
 		fieldDef := ti.FieldDef()
 		if directive != nil {
-			for _, arg := range directive.Args {
+			for _, arg := range schema.visibleArguments(ti.ctx, nil, directive.Args) {
 				if arg.Name() == nameVal {
 					argDef = arg
 				}
 			}
 		} else if fieldDef != nil {
-			for _, arg := range fieldDef.Args {
+			for _, arg := range schema.visibleArguments(ti.ctx, ti.ParentType(), fieldDef.Args) {
 				if arg.Name() == nameVal {
 					argDef = arg
 				}
@@ -180,7 +210,7 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 			if node.Name != nil {
 				nameVal = node.Name.Value
 			}
-			if inputField, ok := objectType.Fields()[nameVal]; ok {
+			if inputField, ok := objectType.Fields()[nameVal]; ok && schema.IsVisible(ti.ctx, inputField) {
 				fieldType = inputField.Type
 			}
 		}
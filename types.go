@@ -6,7 +6,9 @@ import (
 
 // type Schema interface{}
 
-// Result has the response, errors and extensions from the resolved schema
+// Result has the response, errors and extensions from the resolved schema.
+// The objects of Data are *OrderedMap values keeping the fields in selection order,
+// DataMap returns them as maps.
 type Result struct {
 	Data       interface{}                `json:"data"`
 	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
@@ -17,3 +19,9 @@ type Result struct {
 func (r *Result) HasErrors() bool {
 	return len(r.Errors) > 0
 }
+
+// DataMap returns Data with its objects as maps, or nil when Data is not an object.
+func (r *Result) DataMap() map[string]interface{} {
+	data, _ := plainValue(r.Data).(map[string]interface{})
+	return data
+}
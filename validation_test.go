@@ -1,6 +1,7 @@
 package graphql_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/tailor-inc/graphql"
@@ -1644,3 +1645,407 @@ func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectWit
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
 }
+
+func TestTypeSystem_DeprecatedInputValues_RejectsARequiredDeprecatedArgument(t *testing.T) {
+	_, err := schemaWithFieldType(graphql.NewObject(graphql.ObjectConfig{
+		Name: "BadObject",
+		Fields: graphql.Fields{
+			"badField": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"badArg": &graphql.ArgumentConfig{
+						Type:              graphql.NewNonNull(graphql.String),
+						DeprecationReason: "Use `goodArg`.",
+					},
+				},
+			},
+		},
+	}))
+	expectedError := `Required argument BadObject.badField(badArg:) cannot be deprecated.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_DeprecatedInputValues_AcceptsARequiredDeprecatedArgumentWithDefault(t *testing.T) {
+	_, err := schemaWithFieldType(graphql.NewObject(graphql.ObjectConfig{
+		Name: "GoodObject",
+		Fields: graphql.Fields{
+			"goodField": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"goodArg": &graphql.ArgumentConfig{
+						Type:              graphql.NewNonNull(graphql.String),
+						DefaultValue:      "default",
+						DeprecationReason: "No longer needed.",
+					},
+				},
+			},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTypeSystem_DeprecatedInputValues_RejectsARequiredDeprecatedInputField(t *testing.T) {
+	_, err := schemaWithArgOfType(graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BadInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"badField": &graphql.InputObjectFieldConfig{
+				Type:              graphql.NewNonNull(graphql.String),
+				DeprecationReason: "Use `goodField`.",
+			},
+		},
+	}))
+	expectedError := `Required input field BadInput.badField cannot be deprecated.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_OneOfInputObjects_RejectsANonNullField(t *testing.T) {
+	_, err := schemaWithArgOfType(graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "BadOneOf",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"badField": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	}))
+	expectedError := `OneOf input field BadOneOf.badField must be nullable.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_OneOfInputObjects_RejectsAFieldWithDefault(t *testing.T) {
+	_, err := schemaWithArgOfType(graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "BadOneOf",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"badField": &graphql.InputObjectFieldConfig{
+				Type:         graphql.String,
+				DefaultValue: "default",
+			},
+		},
+	}))
+	expectedError := `OneOf input field BadOneOf.badField cannot have a default value.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func interfaceHierarchy() (*graphql.Interface, *graphql.Interface) {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	resourceInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Resource",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"url": &graphql.Field{Type: graphql.String},
+		},
+	})
+	return nodeInterface, resourceInterface
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_AcceptsAnInterfaceHierarchy(t *testing.T) {
+	nodeInterface, resourceInterface := interfaceHierarchy()
+	imageObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Image",
+		Interfaces: []*graphql.Interface{resourceInterface, nodeInterface},
+		Fields: graphql.Fields{
+			"id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"url": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := schemaWithObjectFieldOfType(imageObject)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Type("Node") != nodeInterface || schema.Type("Resource") != resourceInterface {
+		t.Fatalf("expected implemented interfaces in the type map")
+	}
+	if !schema.IsSubType(nodeInterface, resourceInterface) || schema.IsSubType(resourceInterface, nodeInterface) {
+		t.Fatalf("expected Resource to be a sub type of Node")
+	}
+	possibleTypes := schema.PossibleTypes(nodeInterface)
+	if len(possibleTypes) != 1 || possibleTypes[0] != imageObject {
+		t.Fatalf("unexpected possible types of Node: %v", possibleTypes)
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_AcceptsASubtypedInterfaceField(t *testing.T) {
+	nodeInterface, resourceInterface := interfaceHierarchy()
+	parentInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Parent",
+		Fields: graphql.Fields{
+			"child": &graphql.Field{Type: nodeInterface},
+		},
+	})
+	childInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "Child",
+		Interfaces: []*graphql.Interface{parentInterface},
+		Fields: graphql.Fields{
+			"child": &graphql.Field{Type: resourceInterface},
+		},
+	})
+	_, err := schemaWithFieldType(childInterface)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_RejectsAnInterfaceMissingAnInterfaceField(t *testing.T) {
+	nodeInterface, _ := interfaceHierarchy()
+	badInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "BadResource",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"url": &graphql.Field{Type: graphql.String},
+		},
+	})
+	_, err := schemaWithFieldType(badInterface)
+	expectedError := `"Node" expects field "id" but "BadResource" does not provide it.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_RejectsAnObjectMissingATransitiveInterface(t *testing.T) {
+	_, resourceInterface := interfaceHierarchy()
+	imageObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Image",
+		Interfaces: []*graphql.Interface{resourceInterface},
+		Fields: graphql.Fields{
+			"id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"url": &graphql.Field{Type: graphql.String},
+		},
+	})
+	_, err := schemaWithObjectFieldOfType(imageObject)
+	expectedError := `Type Image must implement Node because it is implemented by Resource.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_RejectsAnInterfaceImplementingItself(t *testing.T) {
+	var selfInterface *graphql.Interface
+	selfInterface = graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Self",
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			return []*graphql.Interface{selfInterface}
+		}),
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	_, err := schemaWithFieldType(selfInterface)
+	expectedError := `Type Self cannot implement itself because it would create a circular reference.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_InterfacesMustAdhereToInterfaceTheyImplement_RejectsCircularInterfaces(t *testing.T) {
+	var aInterface, bInterface *graphql.Interface
+	aInterface = graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "A",
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			return []*graphql.Interface{bInterface}
+		}),
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	bInterface = graphql.NewInterface(graphql.InterfaceConfig{
+		Name:       "B",
+		Interfaces: []*graphql.Interface{aInterface},
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	_, err := schemaWithFieldType(aInterface)
+	if err == nil || !strings.HasSuffix(err.Error(), `because it would create a circular reference.`) {
+		t.Fatalf("Expected circular reference error, got %v", err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsReservedNames(t *testing.T) {
+	badObject := graphql.NewObject(graphql.ObjectConfig{
+		Name: "__BadObject",
+		Fields: graphql.Fields{
+			"__badField": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"__badArg": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+			},
+		},
+	})
+	_, err := schemaWithFieldType(badObject)
+	expectedError := `Name "__BadObject" of __BadObject must not begin with "__", which is reserved by GraphQL introspection.
+Name "__badField" of __BadObject.__badField must not begin with "__", which is reserved by GraphQL introspection.
+Name "__badArg" of __BadObject.__badField(__badArg:) must not begin with "__", which is reserved by GraphQL introspection.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsNonNullInputObjectCycles(t *testing.T) {
+	var aInput, bInput *graphql.InputObject
+	aInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "A",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"b":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(bInput)},
+				"self": &graphql.InputObjectFieldConfig{Type: aInput},
+				"list": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(aInput)))},
+			}
+		}),
+	})
+	bInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "B",
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return graphql.InputObjectConfigFieldMap{
+				"a": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(aInput)},
+			}
+		}),
+	})
+	_, err := schemaWithInputObject(aInput)
+	expectedError := `Cannot reference Input Object "A" within itself through a series of non-null fields: "b.a".`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsInvalidDefaultValues(t *testing.T) {
+	colorEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":  &graphql.EnumValueConfig{Value: 0},
+			"BLUE": &graphql.EnumValueConfig{Value: 1},
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"f": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"byName":  &graphql.ArgumentConfig{Type: colorEnum, DefaultValue: "RED"},
+						"byValue": &graphql.ArgumentConfig{Type: colorEnum, DefaultValue: 1},
+						"bad":     &graphql.ArgumentConfig{Type: colorEnum, DefaultValue: "GREEN"},
+						"list":    &graphql.ArgumentConfig{Type: graphql.NewList(graphql.Int), DefaultValue: []interface{}{1, "two"}},
+					},
+				},
+			},
+		}),
+	})
+	expectedError := `Query.f(bad:) has an invalid default value: Expected type "Color", found "GREEN".
+Query.f(list:) has an invalid default value: In element #1: Expected type "Int", found "two".`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsInvalidDirectiveLocations(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: someObjectType,
+		Directives: []*graphql.Directive{
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "bad",
+				Locations: []string{graphql.DirectiveLocationField, "NOWHERE", graphql.DirectiveLocationField},
+			}),
+		},
+	})
+	expectedError := `Directive @bad has an invalid location "NOWHERE".
+Directive @bad has the location "FIELD" more than once.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsSharedRootTypes(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:        someObjectType,
+		Mutation:     someObjectType,
+		Subscription: someObjectType,
+	})
+	expectedError := `All root types must be different, "SomeObject" type is used as query and mutation root types.
+All root types must be different, "SomeObject" type is used as query and subscription root types.
+All root types must be different, "SomeObject" type is used as mutation and subscription root types.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RequiresImplementationsWhenAsked(t *testing.T) {
+	unimplemented := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Unimplemented",
+		Fields: graphql.Fields{
+			"f": &graphql.Field{Type: graphql.String},
+		},
+	})
+	config := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"f": &graphql.Field{Type: unimplemented},
+			},
+		}),
+	}
+	if _, err := graphql.NewSchema(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config.RequireImplementations = true
+	_, err := graphql.NewSchema(config)
+	expectedError := `Interface Unimplemented must be implemented by at least one Object type.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+
+func TestTypeSystem_SchemaValidation_ReportsEveryProblemAtOnce(t *testing.T) {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+	badObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "BadObject",
+		Interfaces: []*graphql.Interface{nodeInterface},
+		Fields: graphql.Fields{
+			"__typename2": &graphql.Field{Type: graphql.String},
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    badObject,
+		Mutation: badObject,
+	})
+	errs, ok := err.(graphql.SchemaErrors)
+	if !ok {
+		t.Fatalf("Expected SchemaErrors, got %T: %v", err, err)
+	}
+	expected := []string{
+		`All root types must be different, "BadObject" type is used as query and mutation root types.`,
+		`Name "__typename2" of BadObject.__typename2 must not begin with "__", which is reserved by GraphQL introspection.`,
+		`"Node" expects field "id" but "BadObject" does not provide it.`,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %v errors, got %v", len(expected), errs)
+	}
+	for i, message := range expected {
+		if errs[i].Error() != message {
+			t.Fatalf("Expected error #%v: %v, got %v", i, message, errs[i])
+		}
+	}
+}
@@ -1,9 +1,10 @@
 package graphql
 
 import (
+	"context"
+
 	"github.com/tailor-inc/graphql/gqlerrors"
 	"github.com/tailor-inc/graphql/language/ast"
-	"github.com/tailor-inc/graphql/language/kinds"
 	"github.com/tailor-inc/graphql/language/visitor"
 )
 
@@ -27,6 +28,12 @@ type ValidationResult struct {
  */
 
 func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
+	return ValidateDocumentWithContext(context.Background(), schema, astDoc, rules)
+}
+
+// ValidateDocumentWithContext is ValidateDocument for a request with the context ctx,
+// which decides the visibility of the members of the schema.
+func ValidateDocumentWithContext(ctx context.Context, schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
 	if len(rules) == 0 {
 		rules = SpecifiedRules
 	}
@@ -41,7 +48,8 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	}
 
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
-		Schema: schema,
+		Schema:  schema,
+		Context: ctx,
 	})
 	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules)
 	if len(vr.Errors) == 0 {
@@ -50,6 +58,34 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	return vr
 }
 
+// ValidateSDL implements the validation of type system documents, such as the ones
+// given to ParseSDL. When schemaToExtend is given, the document is validated as an
+// extension of it. If no rules are given, SpecifiedSDLRules are used.
+func ValidateSDL(astDoc *ast.Document, schemaToExtend *Schema, rules []ValidationRuleFn) (vr ValidationResult) {
+	if len(rules) == 0 {
+		rules = SpecifiedSDLRules
+	}
+
+	if astDoc == nil {
+		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide document"))
+		return vr
+	}
+
+	context := NewValidationContext(schemaToExtend, astDoc, nil)
+	walkers := []*visitor.Walker{}
+	for _, rule := range rules {
+		instance := rule(context)
+		walkers = append(walkers, visitor.NewWalker(instance.VisitorOpts))
+	}
+	visitor.Walk(astDoc, visitor.WalkInParallel(walkers...))
+
+	vr.Errors = context.Errors()
+	if len(vr.Errors) == 0 {
+		vr.IsValid = true
+	}
+	return vr
+}
+
 // VisitUsingRules This uses a specialized visitor which runs multiple visitors in parallel,
 // while maintaining the visitor skip and break API.
 //
@@ -59,15 +95,15 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
 
 	context := NewValidationContext(schema, astDoc, typeInfo)
-	visitors := []*visitor.VisitorOptions{}
+	walkers := []*visitor.Walker{}
 
 	for _, rule := range rules {
 		instance := rule(context)
-		visitors = append(visitors, instance.VisitorOpts)
+		walkers = append(walkers, visitor.NewWalker(instance.VisitorOpts))
 	}
 
-	// Visit the whole document with each instance of all provided rules.
-	visitor.Visit(astDoc, visitor.VisitWithTypeInfo(typeInfo, visitor.VisitInParallel(visitors...)), nil)
+	// Walk the whole document with each instance of all provided rules.
+	visitor.Walk(astDoc, visitor.WalkWithTypeInfo(typeInfo, visitor.WalkInParallel(walkers...)))
 	return context.Errors()
 }
 
@@ -228,26 +264,20 @@ func (ctx *ValidationContext) VariableUsages(node HasSelectionSet) []*VariableUs
 		Schema: ctx.schema,
 	})
 
-	visitor.Visit(node, visitor.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
-		KindFuncMap: map[string]visitor.NamedVisitFuncs{
-			kinds.VariableDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
-			kinds.Variable: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					if node, ok := p.Node.(*ast.Variable); ok && node != nil {
-						usages = append(usages, &VariableUsage{
-							Node: node,
-							Type: typeInfo.InputType(),
-						})
-					}
-					return visitor.ActionNoChange, nil
-				},
-			},
+	visitor.Walk(node, visitor.WalkWithTypeInfo(typeInfo, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			switch node := c.Node().(type) {
+			case *ast.VariableDefinition:
+				return visitor.ActionSkip
+			case *ast.Variable:
+				usages = append(usages, &VariableUsage{
+					Node: node,
+					Type: typeInfo.InputType(),
+				})
+			}
+			return visitor.ActionNoChange
 		},
-	}), nil)
+	}))
 
 	ctx.variableUsages[node] = usages
 	return usages
@@ -285,3 +315,12 @@ func (ctx *ValidationContext) Directive() *Directive {
 func (ctx *ValidationContext) Argument() *Argument {
 	return ctx.typeInfo.Argument()
 }
+
+// Context returns the context of the validated request, which decides the visibility
+// of the members of the schema.
+func (ctx *ValidationContext) Context() context.Context {
+	if ctx.typeInfo == nil {
+		return context.Background()
+	}
+	return ctx.typeInfo.Context()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tailor-inc/graphql/language/location"
 	"github.com/tailor-inc/graphql/language/parser"
 	"github.com/tailor-inc/graphql/language/source"
+	"github.com/tailor-inc/graphql/language/visitor"
 	"github.com/tailor-inc/graphql/testutil"
 )
 
@@ -95,3 +96,91 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, errors))
 	}
 }
+
+func TestTypeInfo_ToleratesVariablesWithoutTypes(t *testing.T) {
+	document := testutil.TestParse(t, `query Q($bar: Int = 1) { dog { name } }`)
+	variable := document.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0]
+	variable.Type = nil
+
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: testutil.TestSchema})
+	var inputType graphql.Input = graphql.Int
+	visitor.Walk(document, visitor.WalkWithTypeInfo(typeInfo, &visitor.Walker{
+		Enter: func(c *visitor.Cursor) string {
+			if c.Node() == variable.DefaultValue {
+				inputType = typeInfo.InputType()
+			}
+			return visitor.ActionNoChange
+		},
+	}))
+	if inputType != nil {
+		t.Fatalf("expected no input type for the variable, got %v", inputType)
+	}
+}
+
+func TestValidator_ValidateSDL_ReportsLocatedErrors(t *testing.T) {
+	AST, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(`type Query {
+  user: User
+  user: String
+}
+
+extend enum Query {
+  A
+}
+`),
+		Name: "schema.graphql",
+	})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result := graphql.ValidateSDL(AST, nil, nil)
+	expectedErrors := []gqlerrors.FormattedError{
+		{
+			Message:   `Cannot extend non-enum type "Query".`,
+			Locations: []location.SourceLocation{{Line: 6, Column: 13}},
+		},
+		{
+			Message:   `Field "Query.user" can only be defined once.`,
+			Locations: []location.SourceLocation{{Line: 2, Column: 3}, {Line: 3, Column: 3}},
+		},
+		{
+			Message:   `Unknown type "User".`,
+			Locations: []location.SourceLocation{{Line: 2, Column: 9}},
+		},
+	}
+	if result.IsValid {
+		t.Fatalf("Expected SDL to be invalid")
+	}
+	if !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestValidator_ValidateSDL_AcceptsValidSDL(t *testing.T) {
+	AST, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(`
+schema { query: Query }
+
+type Query implements Node {
+  id: ID!
+  pets(kind: Kind = DOG): [Pet] @deprecated
+}
+
+interface Node { id: ID! }
+
+union Pet = Query
+
+enum Kind { DOG }
+
+extend enum Kind { CAT }
+
+directive @tag(name: String!) repeatable on OBJECT
+`),
+	})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result := graphql.ValidateSDL(AST, nil, nil); !result.IsValid {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
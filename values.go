@@ -20,14 +20,15 @@ import (
 func getVariableValues(
 	schema Schema,
 	definitionASTs []*ast.VariableDefinition,
-	inputs map[string]interface{}) (map[string]interface{}, error) {
+	inputs map[string]interface{},
+	isVisible isVisibleFn) (map[string]interface{}, error) {
 	values := map[string]interface{}{}
 	for _, defAST := range definitionASTs {
 		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		if varValue, err := getVariableValue(schema, defAST, inputs[varName]); err != nil {
+		if varValue, err := getVariableValue(schema, defAST, inputs[varName], isVisible); err != nil {
 			return values, err
 		} else {
 			values[varName] = varValue
@@ -73,8 +74,9 @@ func getArgumentValues(
 }
 
 // Given a variable definition, and any value of input, return a value which
-// adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
+// adheres to the variable definition, or throw an error. Enum values and input
+// fields for which isVisible does not hold are unknown.
+func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}, isVisible isVisibleFn) (interface{}, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
@@ -93,7 +95,7 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		)
 	}
 
-	isValid, messages := isValidInputValue(input, ttype)
+	isValid, messages := isValidInputValue(input, ttype, isVisible)
 	if isValid {
 		if isNullish(input) {
 			if definitionAST.DefaultValue != nil {
@@ -189,6 +191,9 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 // TODO: change to *Schema
 func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 	switch inputTypeAST := inputTypeAST.(type) {
+	case nil:
+		// Partial documents, as those of editors, may miss types.
+		return nil, nil
 	case *ast.List:
 		innerType, err := typeFromAST(schema, inputTypeAST.Type)
 		if err != nil {
@@ -216,8 +221,9 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 // isValidInputValue alias isValidJSValue
 // Given a value and a GraphQL type, determine if the value will be
 // accepted for that type. This is primarily useful for validating the
-// runtime values of query variables.
-func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
+// runtime values of query variables. Enum values and input fields for which
+// isVisible does not hold are unknown.
+func isValidInputValue(value interface{}, ttype Input, isVisible isVisibleFn) (bool, []string) {
 	if isNullish(value) {
 		if ttype, ok := ttype.(*NonNull); ok {
 			if ttype.OfType.Name() != "" {
@@ -229,7 +235,7 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 	}
 	switch ttype := ttype.(type) {
 	case *NonNull:
-		return isValidInputValue(value, ttype.OfType)
+		return isValidInputValue(value, ttype.OfType, isVisible)
 	case *List:
 		valType := reflect.ValueOf(value)
 		if valType.Kind() == reflect.Ptr {
@@ -239,14 +245,14 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 			messagesReduce := []string{}
 			for i := 0; i < valType.Len(); i++ {
 				val := valType.Index(i).Interface()
-				_, messages := isValidInputValue(val, ttype.OfType)
+				_, messages := isValidInputValue(val, ttype.OfType, isVisible)
 				for idx, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
 				}
 			}
 			return (len(messagesReduce) == 0), messagesReduce
 		}
-		return isValidInputValue(value, ttype.OfType)
+		return isValidInputValue(value, ttype.OfType, isVisible)
 
 	case *InputObject:
 		messagesReduce := []string{}
@@ -255,7 +261,7 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 		if !ok {
 			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
 		}
-		fields := ttype.Fields()
+		fields := visibleInputFields(ttype, isVisible)
 
 		// to ensure stable order of field evaluation
 		fieldNames := []string{}
@@ -280,20 +286,29 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 
 		// Ensure every defined field is valid.
 		for _, fieldName := range fieldNames {
-			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type)
+			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type, isVisible)
 			if messages != nil {
 				for _, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": %v`, fieldName, message))
 				}
 			}
 		}
+
+		// Ensure exactly one non-null field is provided for OneOf input objects.
+		if ttype.IsOneOf() {
+			if len(valueMapFieldNames) != 1 {
+				messagesReduce = append(messagesReduce, fmt.Sprintf(`Exactly one key must be specified for OneOf type "%v".`, ttype.Name()))
+			} else if isNullish(valueMap[valueMapFieldNames[0]]) {
+				messagesReduce = append(messagesReduce, fmt.Sprintf(`Field "%v.%v" must be non-null.`, ttype.Name(), valueMapFieldNames[0]))
+			}
+		}
 		return (len(messagesReduce) == 0), messagesReduce
 	case *Scalar:
 		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
 	case *Enum:
-		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
+		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) || !isVisible(enumValueNamed(ttype, value)) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
 	}
@@ -301,6 +316,18 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 	return true, nil
 }
 
+// enumValueNamed returns the value of ttype whose name is name, a string or a
+// *string, or nil when there is none.
+func enumValueNamed(ttype *Enum, name interface{}) *EnumValueDefinition {
+	switch name := name.(type) {
+	case string:
+		return ttype.getNameLookup()[name]
+	case *string:
+		return ttype.getNameLookup()[*name]
+	}
+	return nil
+}
+
 // Returns true if a value is null, undefined, or NaN.
 func isNullish(src interface{}) bool {
 	if src == nil {
@@ -0,0 +1,144 @@
+package graphql
+
+import (
+	"context"
+)
+
+// VisibilityFn reports whether a member of a schema is visible to the request of ctx.
+// member is a named type, a *FieldDefinition, an *Argument, an *EnumValueDefinition or
+// an *InputObjectField.
+//
+// Hidden members are left out of introspection, reported as unknown by validation and
+// never resolved during execution. Fields, arguments and input fields whose type is
+// hidden are hidden as well. Introspection types and their fields are always visible.
+type VisibilityFn func(ctx context.Context, member interface{}) bool
+
+// IsVisible reports whether member is visible to the request of ctx. Every member is
+// visible in a schema without a VisibilityFn.
+func (gq *Schema) IsVisible(ctx context.Context, member interface{}) bool {
+	if gq.visibility == nil {
+		return true
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	switch member := member.(type) {
+	case *FieldDefinition:
+		if member == SchemaMetaFieldDef || member == TypeMetaFieldDef || member == TypeNameMetaFieldDef {
+			return true
+		}
+		return gq.visibility(ctx, member) && gq.IsVisible(ctx, GetNamed(member.Type))
+	case *Argument:
+		return gq.visibility(ctx, member) && gq.IsVisible(ctx, GetNamed(member.Type))
+	case *InputObjectField:
+		return gq.visibility(ctx, member) && gq.IsVisible(ctx, GetNamed(member.Type))
+	case *EnumValueDefinition:
+		return gq.visibility(ctx, member)
+	case Type:
+		if member == nil || isIntrospectionType(member) {
+			return true
+		}
+		return gq.visibility(ctx, GetNamed(member))
+	}
+	return true
+}
+
+// HasVisibility reports whether the schema has a VisibilityFn, which makes the members
+// it exposes depend on the request.
+func (gq *Schema) HasVisibility() bool {
+	return gq.visibility != nil
+}
+
+// isFieldVisible is IsVisible for a field of parentType, which keeps the fields of
+// introspection types visible.
+func (gq *Schema) isFieldVisible(ctx context.Context, parentType Type, field *FieldDefinition) bool {
+	return gq.visibility == nil || isIntrospectionType(parentType) || gq.IsVisible(ctx, field)
+}
+
+// visibleFields returns the fields of ttype visible to ctx.
+func (gq *Schema) visibleFields(ctx context.Context, ttype implementingType) FieldDefinitionMap {
+	fields := ttype.Fields()
+	if gq.visibility == nil || isIntrospectionType(ttype) {
+		return fields
+	}
+	visible := make(FieldDefinitionMap, len(fields))
+	for name, field := range fields {
+		if gq.IsVisible(ctx, field) {
+			visible[name] = field
+		}
+	}
+	return visible
+}
+
+// visibleArguments returns the arguments visible to ctx of a field of parentType, or of
+// a directive when parentType is nil.
+func (gq *Schema) visibleArguments(ctx context.Context, parentType Type, args []*Argument) []*Argument {
+	if gq.visibility == nil || isIntrospectionType(parentType) {
+		return args
+	}
+	visible := make([]*Argument, 0, len(args))
+	for _, arg := range args {
+		if gq.IsVisible(ctx, arg) {
+			visible = append(visible, arg)
+		}
+	}
+	return visible
+}
+
+func (gq *Schema) visibleObjects(ctx context.Context, types []*Object) []*Object {
+	if gq.visibility == nil {
+		return types
+	}
+	visible := make([]*Object, 0, len(types))
+	for _, ttype := range types {
+		if gq.IsVisible(ctx, ttype) {
+			visible = append(visible, ttype)
+		}
+	}
+	return visible
+}
+
+func (gq *Schema) visibleInterfaces(ctx context.Context, interfaces []*Interface) []*Interface {
+	if gq.visibility == nil {
+		return interfaces
+	}
+	visible := make([]*Interface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if gq.IsVisible(ctx, iface) {
+			visible = append(visible, iface)
+		}
+	}
+	return visible
+}
+
+// isVisibleFn reports whether an enum value or an input field is visible to a request.
+// Input values are checked against it, so that hidden members are unknown to them.
+type isVisibleFn func(member interface{}) bool
+
+// allVisible is the isVisibleFn of values which belong to no request, as the default
+// values of a schema.
+func allVisible(member interface{}) bool {
+	return true
+}
+
+// inputVisibility returns the isVisibleFn of the request of ctx.
+func (gq *Schema) inputVisibility(ctx context.Context) isVisibleFn {
+	if gq.visibility == nil {
+		return allVisible
+	}
+	return func(member interface{}) bool {
+		return gq.IsVisible(ctx, member)
+	}
+}
+
+// visibleInputFields returns the fields of ttype for which isVisible holds.
+func visibleInputFields(ttype *InputObject, isVisible isVisibleFn) InputObjectFieldMap {
+	fields := ttype.Fields()
+	visible := make(InputObjectFieldMap, len(fields))
+	for name, field := range fields {
+		if isVisible(field) {
+			visible[name] = field
+		}
+	}
+	return visible
+}
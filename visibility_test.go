@@ -0,0 +1,366 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/tailor-inc/graphql"
+	"github.com/tailor-inc/graphql/gqlerrors"
+	"github.com/tailor-inc/graphql/language/location"
+)
+
+type staffKey struct{}
+
+var staffContext = context.WithValue(context.Background(), staffKey{}, true)
+
+// visibilityTestSchema hides everything named or described as internal from requests
+// without staffContext. resolved counts the calls of the internal resolvers.
+func visibilityTestSchema(t *testing.T, resolved *int) graphql.Schema {
+	statusType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Status",
+		Values: graphql.EnumValueConfigMap{
+			"ACTIVE":   &graphql.EnumValueConfig{Value: "active"},
+			"ARCHIVED": &graphql.EnumValueConfig{Value: "archived", Description: "internal"},
+		},
+	})
+	auditType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Audit",
+		Fields: graphql.Fields{
+			"by": &graphql.Field{Type: graphql.String},
+		},
+	})
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name":   &graphql.Field{Type: graphql.String},
+			"status": &graphql.Field{Type: statusType},
+			"email": &graphql.Field{
+				Type:        graphql.String,
+				Description: "internal",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					*resolved++
+					return "ada@example.com", nil
+				},
+			},
+			"audit": &graphql.Field{
+				Type: auditType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					*resolved++
+					return map[string]interface{}{"by": "root"}, nil
+				},
+			},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"secret": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "internal"},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"users": &graphql.Field{
+					Type: graphql.NewList(userType),
+					Args: graphql.FieldConfigArgument{
+						"status": &graphql.ArgumentConfig{Type: statusType},
+						"filter": &graphql.ArgumentConfig{Type: filterType},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{map[string]interface{}{"name": "Ada", "status": p.Args["status"]}}, nil
+					},
+				},
+				"user": &graphql.Field{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"name":           &graphql.ArgumentConfig{Type: graphql.String},
+						"includeDeleted": &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "internal"},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "Ada", "status": "active"}, nil
+					},
+				},
+			},
+		}),
+		Visibility: func(ctx context.Context, member interface{}) bool {
+			if staff, _ := ctx.Value(staffKey{}).(bool); staff {
+				return true
+			}
+			switch member := member.(type) {
+			case *graphql.FieldDefinition:
+				return member.Description != "internal"
+			case *graphql.Argument:
+				return member.Description() != "internal"
+			case *graphql.EnumValueDefinition:
+				return member.Description != "internal"
+			case *graphql.InputObjectField:
+				return member.Description() != "internal"
+			case graphql.Type:
+				return member.Name() != "Audit"
+			}
+			return true
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating schema: %v", err)
+	}
+	return schema
+}
+
+func TestVisibility_HidesMembersFromIntrospection(t *testing.T) {
+	schema := visibilityTestSchema(t, new(int))
+	query := `
+		{
+			__schema { types { name } }
+			audit: __type(name: "Audit") { name }
+			user: __type(name: "User") { fields { name } }
+			status: __type(name: "Status") { enumValues { name } }
+			query: __type(name: "Query") { fields { args { name } } }
+		}
+	`
+	introspect := func(ctx context.Context) map[string]interface{} {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: ctx})
+		if len(result.Errors) > 0 {
+			t.Fatalf("Unexpected errors: %v", result.Errors)
+		}
+		return result.DataMap()
+	}
+	names := func(list interface{}) []string {
+		var names []string
+		for _, item := range list.([]interface{}) {
+			names = append(names, item.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+	hasType := func(data map[string]interface{}, name string) bool {
+		for _, typeName := range names(data["__schema"].(map[string]interface{})["types"]) {
+			if typeName == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	public := introspect(context.Background())
+	if hasType(public, "Audit") || public["audit"] != nil {
+		t.Fatalf("Expected Audit to be hidden, got %v", public)
+	}
+	if got := names(public["user"].(map[string]interface{})["fields"]); !reflect.DeepEqual(got, []string{"name", "status"}) {
+		t.Fatalf("Unexpected User fields %v", got)
+	}
+	if got := names(public["status"].(map[string]interface{})["enumValues"]); !reflect.DeepEqual(got, []string{"ACTIVE"}) {
+		t.Fatalf("Unexpected Status values %v", got)
+	}
+	userField := public["query"].(map[string]interface{})["fields"].([]interface{})[0].(map[string]interface{})
+	if got := names(userField["args"]); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Fatalf("Unexpected user arguments %v", got)
+	}
+
+	staff := introspect(staffContext)
+	if !hasType(staff, "Audit") || staff["audit"] == nil {
+		t.Fatalf("Expected Audit to be visible to staff, got %v", staff)
+	}
+	if got := names(staff["user"].(map[string]interface{})["fields"]); len(got) != 4 {
+		t.Fatalf("Unexpected User fields %v", got)
+	}
+	if got := names(staff["status"].(map[string]interface{})["enumValues"]); len(got) != 2 {
+		t.Fatalf("Unexpected Status values %v", got)
+	}
+}
+
+func TestVisibility_ReportsHiddenMembersAsUnknown(t *testing.T) {
+	schema := visibilityTestSchema(t, new(int))
+	query := `{ user(includeDeleted: true) { emai audit { by } } }`
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: context.Background()})
+	expected := []gqlerrors.FormattedError{
+		{
+			Message:   `Unknown argument "includeDeleted" on field "user" of type "Query".`,
+			Locations: []location.SourceLocation{{Line: 1, Column: 8}},
+		},
+		{
+			Message:   `Cannot query field "emai" on type "User".`,
+			Locations: []location.SourceLocation{{Line: 1, Column: 32}},
+		},
+		{
+			Message:   `Cannot query field "audit" on type "User".`,
+			Locations: []location.SourceLocation{{Line: 1, Column: 37}},
+		},
+	}
+	if len(result.Errors) != len(expected) {
+		t.Fatalf("Unexpected errors %v", result.Errors)
+	}
+	for i, err := range result.Errors {
+		if err.Message != expected[i].Message || !reflect.DeepEqual(err.Locations, expected[i].Locations) {
+			t.Fatalf("Expected error %v, got %v", expected[i], err)
+		}
+	}
+
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: staffContext})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "emai" on type "User". Did you mean "email"?` {
+		t.Fatalf("Unexpected errors %v", result.Errors)
+	}
+}
+
+func TestVisibility_NeverResolvesHiddenFields(t *testing.T) {
+	resolved := 0
+	schema := visibilityTestSchema(t, &resolved)
+	document := parseSignatureDocument(t, `{ user { name email audit { by } } }`)
+
+	result := graphql.Execute(graphql.ExecuteParams{Schema: schema, AST: document, Context: context.Background()})
+	expected := map[string]interface{}{"user": map[string]interface{}{"name": "Ada"}}
+	if len(result.Errors) > 0 || !reflect.DeepEqual(result.DataMap(), expected) {
+		t.Fatalf("Unexpected result %v", result)
+	}
+	if resolved != 0 {
+		t.Fatalf("Expected no hidden field to be resolved, resolved %v", resolved)
+	}
+
+	result = graphql.Execute(graphql.ExecuteParams{Schema: schema, AST: document, Context: staffContext})
+	if len(result.Errors) > 0 || resolved != 2 {
+		t.Fatalf("Unexpected result %v, resolved %v", result, resolved)
+	}
+}
+
+func TestVisibility_RejectsHiddenInputValues(t *testing.T) {
+	schema := visibilityTestSchema(t, new(int))
+	do := func(ctx context.Context, query string, variables map[string]interface{}) []gqlerrors.FormattedError {
+		return graphql.Do(graphql.Params{Schema: schema, RequestString: query, VariableValues: variables, Context: ctx}).Errors
+	}
+
+	literals := []struct {
+		query, message string
+	}{
+		{
+			`{ users(status: ARCHIVED) { name } }`,
+			"Argument \"status\" has invalid value ARCHIVED.\nExpected type \"Status\", found ARCHIVED.",
+		},
+		{
+			`{ users(filter: {secret: "x"}) { name } }`,
+			"Argument \"filter\" has invalid value {secret: \"x\"}.\nIn field \"secret\": Unknown field.",
+		},
+		{
+			`query ($status: Status = ARCHIVED) { users(status: $status) { name } }`,
+			"Variable \"$status\" has invalid default value: ARCHIVED.\nExpected type \"Status\", found ARCHIVED.",
+		},
+	}
+	for _, literal := range literals {
+		if errs := do(context.Background(), literal.query, nil); len(errs) != 1 || errs[0].Message != literal.message {
+			t.Fatalf("Unexpected errors for %v: %v", literal.query, errs)
+		}
+		if errs := do(staffContext, literal.query, nil); len(errs) > 0 {
+			t.Fatalf("Unexpected errors for %v: %v", literal.query, errs)
+		}
+	}
+
+	variables := []struct {
+		variables map[string]interface{}
+		message   string
+	}{
+		{
+			map[string]interface{}{"status": "ARCHIVED"},
+			"Variable \"$status\" got invalid value \"ARCHIVED\".\nExpected type \"Status\", found \"ARCHIVED\".",
+		},
+		{
+			map[string]interface{}{"filter": map[string]interface{}{"secret": "x"}},
+			"Variable \"$filter\" got invalid value {\"secret\":\"x\"}.\nIn field \"secret\": Unknown field.",
+		},
+	}
+	query := `query ($status: Status, $filter: Filter) { users(status: $status, filter: $filter) { name } }`
+	for _, variable := range variables {
+		if errs := do(context.Background(), query, variable.variables); len(errs) != 1 || errs[0].Message != variable.message {
+			t.Fatalf("Unexpected errors for %v: %v", variable.variables, errs)
+		}
+		if errs := do(staffContext, query, variable.variables); len(errs) > 0 {
+			t.Fatalf("Unexpected errors for %v: %v", variable.variables, errs)
+		}
+	}
+}
+
+func TestVisibility_HidesRuntimeTypesAndArguments(t *testing.T) {
+	node := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:   "Node",
+		Fields: graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{node},
+		Fields:     graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	internalUser := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "InternalUser",
+		Description: "internal",
+		Interfaces:  []*graphql.Interface{node},
+		Fields:      graphql.Fields{"id": &graphql.Field{Type: graphql.ID}},
+	})
+	node.ResolveType = func(p graphql.ResolveTypeParams) *graphql.Object {
+		if p.Value.(map[string]interface{})["id"] == "1" {
+			return internalUser
+		}
+		return user
+	}
+	var args map[string]interface{}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": &graphql.Field{
+					Type: node,
+					Args: graphql.FieldConfigArgument{
+						"id":             &graphql.ArgumentConfig{Type: graphql.ID},
+						"includeDeleted": &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "internal"},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						args = p.Args
+						return map[string]interface{}{"id": p.Args["id"]}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{user, internalUser},
+		Visibility: func(ctx context.Context, member interface{}) bool {
+			if staff, _ := ctx.Value(staffKey{}).(bool); staff {
+				return true
+			}
+			switch member := member.(type) {
+			case *graphql.Argument:
+				return member.Description() != "internal"
+			case *graphql.Object:
+				return member.Description() != "internal"
+			}
+			return true
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating schema: %v", err)
+	}
+
+	execute := func(ctx context.Context, query string) *graphql.Result {
+		return graphql.Execute(graphql.ExecuteParams{Schema: schema, AST: parseSignatureDocument(t, query), Context: ctx})
+	}
+	result := execute(context.Background(), `{ node(id: "1") { id __typename } }`)
+	message := `Abstract type "Node" must resolve to a visible Object type at runtime for field Query.node.`
+	if !reflect.DeepEqual(result.DataMap(), map[string]interface{}{"node": nil}) || len(result.Errors) != 1 ||
+		result.Errors[0].Message != message || !reflect.DeepEqual(result.Errors[0].Path, []interface{}{"node"}) ||
+		!reflect.DeepEqual(result.Errors[0].Locations, []location.SourceLocation{{Line: 1, Column: 3}}) {
+		t.Fatalf("Unexpected result %v", result)
+	}
+	result = execute(staffContext, `{ node(id: "1") { __typename } }`)
+	if expected := map[string]interface{}{"node": map[string]interface{}{"__typename": "InternalUser"}}; len(result.Errors) > 0 ||
+		!reflect.DeepEqual(result.DataMap(), expected) {
+		t.Fatalf("Unexpected result %v", result)
+	}
+
+	// Hidden arguments are dropped, even when the document is not validated.
+	execute(context.Background(), `{ node(id: "2", includeDeleted: true) { id } }`)
+	if expected := map[string]interface{}{"id": "2"}; !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Unexpected arguments %v", args)
+	}
+	execute(staffContext, `{ node(id: "2", includeDeleted: true) { id } }`)
+	if expected := map[string]interface{}{"id": "2", "includeDeleted": true}; !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Unexpected arguments %v", args)
+	}
+}